@@ -66,7 +66,7 @@ func authCmd() *cobra.Command {
 				whoop.WithAPIKey(result.APIKey),
 			)
 			repo := repository.New(querier)
-			syncSvc := xsync.NewService(client, repo, logger)
+			syncSvc := xsync.NewService(client, repo, logger, xsync.WithBackfillHorizon(cfg.BackfillHorizon()))
 
 			fmt.Println("Starting background data sync...")
 			if err := syncSvc.StartBackfill(ctx); err != nil {
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/config"
+	"github.com/garrettladley/thoop/internal/oauth"
+	sqlitec "github.com/garrettladley/thoop/internal/sqlc/sqlite"
+)
+
+var errNotAuthenticated = errors.New("not authenticated: run thoop to sign in with WHOOP")
+
+// newWhoopClient builds a proxied WHOOP client from the stored token and API key.
+func newWhoopClient(ctx context.Context, cfg config.Config, querier sqlitec.Querier, opts ...whoop.Option) (*whoop.Client, error) {
+	tokenSource := oauth.NewProxyTokenSource(cfg.ServerURL, querier)
+
+	hasToken, err := tokenSource.HasToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token: %w", err)
+	}
+	if !hasToken {
+		return nil, errNotAuthenticated
+	}
+
+	var apiKey string
+	if apiKeyPtr, err := querier.GetAPIKey(ctx); err == nil && apiKeyPtr != nil {
+		apiKey = *apiKeyPtr
+	}
+
+	opts = append([]whoop.Option{
+		whoop.WithProxyURL(cfg.ServerURL + "/api/whoop"),
+		whoop.WithAPIKey(apiKey),
+	}, opts...)
+	return whoop.New(tokenSource, opts...), nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/garrettladley/thoop/internal/export"
+)
+
+const dateLayout = "2006-01-02"
+
+func exportCmd() *cobra.Command {
+	var (
+		from   string
+		to     string
+		entity string
+		format string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export cached WHOOP data as CSV or JSON Lines",
+		Long: `Export cached WHOOP data as CSV or JSON Lines.
+
+Scores are flattened into columns with stable headers. A single entity is
+written to stdout unless --output is set. --entity all writes one file per
+entity into the --output directory.`,
+		Example: `  thoop export --entity sleeps --from 2025-01-01 > sleeps.csv
+  thoop export --entity all --format jsonl --output ./whoop`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			e, err := export.ParseEntity(entity)
+			if err != nil {
+				return err
+			}
+			f, err := export.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+			start, end, err := parseDateRange(from, to)
+			if err != nil {
+				return err
+			}
+
+			sqlDB, repo, err := openRepository(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = sqlDB.Close() }()
+
+			exporter := export.New(repo)
+
+			if e != export.EntityAll {
+				return exportTo(ctx, exporter, output, e, f, start, end)
+			}
+
+			if output == "" {
+				return errors.New("--entity all requires --output to be a directory")
+			}
+			if err := os.MkdirAll(output, 0o750); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+			for _, e := range export.Entities {
+				path := filepath.Join(output, string(e)+"."+f.Extension())
+				if err := exportTo(ctx, exporter, path, e, f, start, end); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Start date (YYYY-MM-DD, inclusive); defaults to the oldest cached record")
+	cmd.Flags().StringVar(&to, "to", "", "End date (YYYY-MM-DD, inclusive); defaults to now")
+	cmd.Flags().StringVar(&entity, "entity", string(export.EntityCycles), "Entity to export: cycles, recoveries, sleeps, workouts, all")
+	cmd.Flags().StringVar(&format, "format", string(export.FormatCSV), "Output format: csv, jsonl")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file, or directory for --entity all; defaults to stdout")
+
+	return cmd
+}
+
+// exportTo exports a single entity to path, or to stdout when path is empty.
+func exportTo(ctx context.Context, exporter *export.Exporter, path string, entity export.Entity, format export.Format, start, end time.Time) error {
+	if path == "" {
+		if err := exporter.Export(ctx, os.Stdout, entity, format, start, end); err != nil {
+			return fmt.Errorf("failed to export %s: %w", entity, err)
+		}
+		return nil
+	}
+
+	file, err := os.Create(path) //nolint:gosec // path is provided by the user
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := exporter.Export(ctx, file, entity, format, start, end); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to export %s: %w", entity, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
+	return nil
+}
+
+// parseDateRange parses inclusive local dates. An empty from means the
+// beginning of time and an empty to means now.
+func parseDateRange(from, to string) (time.Time, time.Time, error) {
+	var (
+		start time.Time
+		end   = time.Now()
+	)
+
+	if from != "" {
+		t, err := time.ParseInLocation(dateLayout, from, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --from date %q: %w", from, err)
+		}
+		start = t
+	}
+	if to != "" {
+		t, err := time.ParseInLocation(dateLayout, to, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --to date %q: %w", to, err)
+		}
+		end = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, errors.New("--to must not be before --from")
+	}
+	return start, end, nil
+}
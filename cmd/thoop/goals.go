@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	go_json "github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+
+	"github.com/garrettladley/thoop/internal/goals"
+)
+
+func goalsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "goals",
+		Short: "Set targets and track them against your data",
+		Long: `Set targets and track them against your data.
+
+A goal holds a metric against a target over the current week (from Monday)
+or calendar month. Without --count, the period's average has to meet the
+target; with it, at least that many days, nights or workouts have to meet
+it on their own. Progress is shown here and as gauges on the dashboard.
+
+Metrics: ` + metricNames() + `.
+Bedtime and wake targets are times of day as HH:MM.`,
+	}
+
+	cmd.AddCommand(goalsAddCmd(), goalsListCmd(), goalsRmCmd())
+	return cmd
+}
+
+func metricNames() string {
+	names := make([]string, len(goals.Metrics))
+	for i, m := range goals.Metrics {
+		names[i] = string(m)
+	}
+	return strings.Join(names, ", ")
+}
+
+func goalsAddCmd() *cobra.Command {
+	var (
+		count  int
+		period string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <metric> <comparison> <target>",
+		Short: "Add a goal",
+		Example: `  thoop goals add sleep_performance '>=' 85
+  thoop goals add workout_strain '>' 10 --count 3
+  thoop goals add bedtime '<' 23:30 --count 5
+  thoop goals add hrv '>=' 60 --period month`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			var n *int
+			if cmd.Flags().Changed("count") {
+				n = &count
+			}
+			g, err := goals.New(args[0], args[1], args[2], n, period)
+			if err != nil {
+				return err
+			}
+
+			sqlDB, repo, err := openRepository(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = sqlDB.Close() }()
+
+			record := g.Record()
+			if err := repo.Goals.Create(ctx, &record); err != nil {
+				return fmt.Errorf("failed to save goal: %w", err)
+			}
+			_, _ = fmt.Fprintf(os.Stdout, "added goal %d: %s\n", record.ID, g)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&count, "count", 0, "Days, nights or workouts that must meet the target, rather than the average")
+	cmd.Flags().StringVar(&period, "period", string(goals.PeriodWeek), "Period to track over: week, month")
+
+	return cmd
+}
+
+func goalsListCmd() *cobra.Command {
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List goals and how far along they are",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			sqlDB, repo, err := openRepository(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = sqlDB.Close() }()
+
+			progress, err := goals.Track(ctx, repo, time.Now())
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				return writeGoalsJSON(os.Stdout, progress)
+			}
+			printGoals(os.Stdout, progress)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print goals and progress as JSON")
+
+	return cmd
+}
+
+func goalsRmCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "rm <id>",
+		Aliases: []string{"remove"},
+		Short:   "Remove a goal",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid goal id %q: %w", args[0], err)
+			}
+
+			sqlDB, repo, err := openRepository(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = sqlDB.Close() }()
+
+			removed, err := repo.Goals.Delete(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to remove goal: %w", err)
+			}
+			if !removed {
+				return fmt.Errorf("no goal %d", id)
+			}
+			_, _ = fmt.Fprintf(os.Stdout, "removed goal %d\n", id)
+			return nil
+		},
+	}
+}
+
+func printGoals(w io.Writer, progress []goals.Progress) {
+	if len(progress) == 0 {
+		_, _ = fmt.Fprintln(w, "no goals yet; add one with thoop goals add")
+		return
+	}
+
+	for _, p := range progress {
+		mark := " "
+		if p.Met {
+			mark = "✓"
+		}
+		_, _ = fmt.Fprintf(w, "%s %3d  %-52s %3.0f%%  %s\n",
+			mark, p.Goal.ID, p.Goal, p.Fraction*100, p.Describe())
+	}
+}
+
+type goalJSON struct {
+	ID         int64   `json:"id"`
+	Goal       string  `json:"goal"`
+	Metric     string  `json:"metric"`
+	Comparison string  `json:"comparison"`
+	Target     float64 `json:"target"`
+	Count      *int    `json:"count"`
+	Period     string  `json:"period"`
+	Current    float64 `json:"current"`
+	Samples    int     `json:"samples"`
+	Fraction   float64 `json:"fraction"`
+	Met        bool    `json:"met"`
+}
+
+func writeGoalsJSON(w io.Writer, progress []goals.Progress) error {
+	out := make([]goalJSON, len(progress))
+	for i, p := range progress {
+		g := p.Goal
+		out[i] = goalJSON{
+			ID:         g.ID,
+			Goal:       g.String(),
+			Metric:     string(g.Metric),
+			Comparison: string(g.Comparison),
+			Target:     g.Value,
+			Count:      g.Count,
+			Period:     string(g.Period),
+			Current:    p.Current,
+			Samples:    p.Samples,
+			Fraction:   p.Fraction,
+			Met:        p.Met,
+		}
+	}
+
+	data, err := go_json.Marshal(out)
+	if err != nil {
+		return fmt.Errorf("failed to marshal goals: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, string(data)); err != nil {
+		return fmt.Errorf("failed to write goals: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,456 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	go_json "github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+
+	"github.com/garrettladley/thoop/internal/insights"
+	"github.com/garrettladley/thoop/internal/xtime"
+)
+
+func insightsCmd() *cobra.Command {
+	var (
+		days       int
+		windowDays int
+		threshold  float64
+		asJSON     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "insights",
+		Short: "Flag recovery metrics that stray from your baseline",
+		Long: `Flag recovery metrics that stray from your baseline.
+
+HRV, resting heart rate, SpO2, skin temperature and respiratory rate are each
+compared against their mean and standard deviation over the --window days
+before. Days at least --threshold standard deviations away are listed.
+Recoveries scored while WHOOP is still calibrating are ignored, and a
+baseline needs at least 7 days of data.
+
+Only the local cache is read; run thoop sync first for a full history.`,
+		Example: `  thoop insights
+  thoop insights --days 90 --threshold 2.5`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			a := insights.New(
+				insights.WithWindow(time.Duration(windowDays)*24*time.Hour),
+				insights.WithThreshold(threshold),
+			)
+
+			var (
+				end   = time.Now()
+				start = end.AddDate(0, 0, -days)
+			)
+
+			sqlDB, repo, err := openRepository(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = sqlDB.Close() }()
+
+			points, err := insights.Load(ctx, repo, start.Add(-a.Window()), end)
+			if err != nil {
+				return fmt.Errorf("failed to load insights: %w", err)
+			}
+
+			var anomalies []insights.Deviation
+			for _, d := range a.Anomalies(points) {
+				if !d.Start.Before(start) {
+					anomalies = append(anomalies, d)
+				}
+			}
+			baselines := a.Baselines(points, end)
+
+			if asJSON {
+				return writeInsightsJSON(os.Stdout, baselines, anomalies)
+			}
+			printInsights(os.Stdout, baselines, anomalies, windowDays, days)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&days, "days", 14, "Days back to look for anomalies")
+	cmd.Flags().IntVar(&windowDays, "window", int(insights.DefaultWindow.Hours()/24), "Days each baseline covers")
+	cmd.Flags().Float64Var(&threshold, "threshold", insights.DefaultThreshold, "Standard deviations from the baseline that count as an anomaly")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print baselines and anomalies as JSON")
+
+	cmd.AddCommand(correlateCmd(), circadianCmd())
+
+	return cmd
+}
+
+func correlateCmd() *cobra.Command {
+	var (
+		days   int
+		asJSON bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "correlate",
+		Short: "Correlate strain, workouts and sleep with the following days' recovery",
+		Long: `Correlate strain, workouts and sleep with the following days' recovery.
+
+Each day's strain, the time its last workout ended and the stages of the
+night after it are paired with the recovery 1, 2 and 3 days later. Pearson
+and Spearman correlations are listed with 95% confidence intervals; an
+interval that excludes zero is marked with *. Predictors need at least 10
+pairs to be listed.
+
+Only the local cache is read; run thoop sync first for a full history.`,
+		Example: `  thoop insights correlate
+  thoop insights correlate --days 365 --json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			var (
+				end   = time.Now()
+				start = end.AddDate(0, 0, -days)
+			)
+
+			sqlDB, repo, err := openRepository(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = sqlDB.Close() }()
+
+			cached, err := insights.LoadDays(ctx, repo, start, end)
+			if err != nil {
+				return fmt.Errorf("failed to load days: %w", err)
+			}
+			correlations := insights.Correlate(cached)
+
+			if asJSON {
+				return writeCorrelationsJSON(os.Stdout, start, end, correlations)
+			}
+			printCorrelations(os.Stdout, correlations, days)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&days, "days", 90, "Days of history to correlate")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print correlations as JSON")
+
+	return cmd
+}
+
+func printCorrelations(w io.Writer, correlations []insights.Correlation, days int) {
+	_, _ = fmt.Fprintf(w, "correlations with recovery over the last %d days\n", days)
+	if len(correlations) == 0 {
+		_, _ = fmt.Fprintln(w, "  not enough data yet")
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "  %-16s %-4s %4s  %-24s %s\n", "predictor", "lag", "n", "pearson", "spearman")
+	for _, c := range correlations {
+		_, _ = fmt.Fprintf(w, "  %-16s %-4s %4d  %-24s %s\n",
+			c.Predictor.Label(), fmt.Sprintf("+%dd", c.Lag), c.N,
+			formatEstimate(c.Pearson), formatEstimate(c.Spearman))
+	}
+}
+
+// formatEstimate renders an estimate like "-0.42 [-0.61, -0.19] *".
+func formatEstimate(e insights.Estimate) string {
+	s := fmt.Sprintf("%+.2f [%+.2f, %+.2f]", e.R, e.Low, e.High)
+	if e.Significant() {
+		s += " *"
+	}
+	return s
+}
+
+type correlationsJSON struct {
+	From         string                 `json:"from"`
+	To           string                 `json:"to"`
+	Correlations []insights.Correlation `json:"correlations"`
+}
+
+func writeCorrelationsJSON(w io.Writer, start, end time.Time, correlations []insights.Correlation) error {
+	out := correlationsJSON{
+		From:         start.Format(dateLayout),
+		To:           end.Format(dateLayout),
+		Correlations: correlations,
+	}
+	if out.Correlations == nil {
+		out.Correlations = []insights.Correlation{}
+	}
+
+	data, err := go_json.Marshal(out)
+	if err != nil {
+		return fmt.Errorf("failed to marshal correlations: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, string(data)); err != nil {
+		return fmt.Errorf("failed to write correlations: %w", err)
+	}
+	return nil
+}
+
+func printInsights(w io.Writer, baselines map[insights.Metric]insights.Baseline, anomalies []insights.Deviation, windowDays, days int) {
+	_, _ = fmt.Fprintf(w, "baselines over the last %d days\n", windowDays)
+	if len(baselines) == 0 {
+		_, _ = fmt.Fprintln(w, "  not enough data yet")
+	}
+	for _, m := range insights.Metrics {
+		b, ok := baselines[m]
+		if !ok {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "  %-11s %s ± %s  (%d days)\n",
+			m.Label(), m.Format(b.Mean), m.Format(b.StdDev), b.Samples)
+	}
+
+	_, _ = fmt.Fprintf(w, "\nanomalies in the last %d days\n", days)
+	if len(anomalies) == 0 {
+		_, _ = fmt.Fprintln(w, "  none")
+	}
+	for _, d := range anomalies {
+		_, _ = fmt.Fprintf(w, "  %s  %s\n", d.Date().Format(dateLayout), d)
+	}
+}
+
+type insightsJSON struct {
+	Baselines map[insights.Metric]baselineJSON `json:"baselines"`
+	Anomalies []anomalyJSON                    `json:"anomalies"`
+}
+
+type baselineJSON struct {
+	Mean    float64 `json:"mean"`
+	StdDev  float64 `json:"std_dev"`
+	Samples int     `json:"samples"`
+}
+
+type anomalyJSON struct {
+	Date     string          `json:"date"`
+	CycleID  int64           `json:"cycle_id"`
+	Metric   insights.Metric `json:"metric"`
+	Value    float64         `json:"value"`
+	Baseline baselineJSON    `json:"baseline"`
+	Z        float64         `json:"z"`
+}
+
+func toBaselineJSON(b insights.Baseline) baselineJSON {
+	return baselineJSON{Mean: b.Mean, StdDev: b.StdDev, Samples: b.Samples}
+}
+
+func writeInsightsJSON(w io.Writer, baselines map[insights.Metric]insights.Baseline, anomalies []insights.Deviation) error {
+	out := insightsJSON{
+		Baselines: make(map[insights.Metric]baselineJSON, len(baselines)),
+		Anomalies: make([]anomalyJSON, len(anomalies)),
+	}
+	for m, b := range baselines {
+		out.Baselines[m] = toBaselineJSON(b)
+	}
+	for i, d := range anomalies {
+		out.Anomalies[i] = anomalyJSON{
+			Date:     d.Date().Format(dateLayout),
+			CycleID:  d.CycleID,
+			Metric:   d.Metric,
+			Value:    d.Value,
+			Baseline: toBaselineJSON(d.Baseline),
+			Z:        d.Z,
+		}
+	}
+
+	data, err := go_json.Marshal(out)
+	if err != nil {
+		return fmt.Errorf("failed to marshal insights: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, string(data)); err != nil {
+		return fmt.Errorf("failed to write insights: %w", err)
+	}
+	return nil
+}
+
+func circadianCmd() *cobra.Command {
+	var (
+		days   int
+		wake   string
+		asJSON bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "circadian",
+		Short: "Describe when you sleep and recommend a bedtime",
+		Long: `Describe when you sleep and recommend a bedtime.
+
+Bedtimes, wake times and the middle of each night are read in the timezone
+the night was slept in, so travel doesn't skew them. Nights before Saturday
+and Sunday are compared with the rest for social jetlag, and the weekend
+mid-sleep, corrected for catching up on sleep, gives your chronotype.
+
+The bedtime recommendation works back from --wake, or your usual workday
+wake time, by the sleep WHOOP last asked for and your usual time awake in bed.
+
+Only the local cache is read; run thoop sync first for a full history.`,
+		Example: `  thoop insights circadian
+  thoop insights circadian --wake 06:30 --json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			var target *insights.Clock
+			if wake != "" {
+				c, err := insights.ParseClock(wake)
+				if err != nil {
+					return err
+				}
+				target = &c
+			}
+
+			var (
+				end   = time.Now()
+				start = end.AddDate(0, 0, -days)
+			)
+
+			sqlDB, repo, err := openRepository(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = sqlDB.Close() }()
+
+			nights, err := insights.LoadNights(ctx, repo, start, end)
+			if err != nil {
+				return fmt.Errorf("failed to load nights: %w", err)
+			}
+			c := insights.Analyze(nights, target, end)
+
+			if asJSON {
+				return writeCircadianJSON(os.Stdout, c)
+			}
+			printCircadian(os.Stdout, c, days)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&days, "days", 60, "Days of nights to analyze")
+	cmd.Flags().StringVar(&wake, "wake", "", "Time to wake up tomorrow, as HH:MM (default your usual workday wake time)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the analysis as JSON")
+
+	return cmd
+}
+
+func printCircadian(w io.Writer, c insights.Circadian, days int) {
+	_, _ = fmt.Fprintf(w, "sleep timing over the last %d days\n", days)
+	if c.Bedtime.Nights == 0 {
+		_, _ = fmt.Fprintln(w, "  no nights cached yet")
+		return
+	}
+
+	for _, d := range []struct {
+		label string
+		dist  insights.Distribution
+	}{
+		{"bedtime", c.Bedtime},
+		{"wake", c.Wake},
+		{"mid-sleep", c.Midpoint},
+	} {
+		_, _ = fmt.Fprintf(w, "  %-10s %7s ± %-7s  %s – %s  (%d nights)\n",
+			d.label, d.dist.Mean, xtime.FormatDuration(d.dist.StdDev), d.dist.Earliest, d.dist.Latest, d.dist.Nights)
+	}
+
+	_, _ = fmt.Fprintln(w)
+	if j := c.SocialJetlag; j != nil {
+		_, _ = fmt.Fprintf(w, "  social jetlag  %s  (mid-sleep %s before workdays, %s before weekends)\n",
+			xtime.FormatDuration(j.Shift), j.Workday.Mean, j.FreeDay.Mean)
+	} else {
+		_, _ = fmt.Fprintln(w, "  social jetlag  needs nights before both workdays and weekends")
+	}
+	if ct := c.Chronotype; ct != nil {
+		_, _ = fmt.Fprintf(w, "  chronotype     %s  (corrected weekend mid-sleep %s)\n", ct.Type, ct.MidSleep)
+	}
+
+	if r := c.Recommendation; r != nil {
+		_, _ = fmt.Fprintf(w, "\nfor %s, get in bed by %s: %s of sleep needs %s in bed\n",
+			r.Wake.Format("Mon 3:04pm"), r.Bedtime.Format("3:04pm"), xtime.FormatDuration(r.Need), xtime.FormatDuration(r.InBed))
+	}
+}
+
+type circadianJSON struct {
+	Bedtime        distributionJSON    `json:"bedtime"`
+	Wake           distributionJSON    `json:"wake"`
+	Midpoint       distributionJSON    `json:"midpoint"`
+	SocialJetlag   *socialJetlagJSON   `json:"social_jetlag"`
+	Chronotype     *chronotypeJSON     `json:"chronotype"`
+	Recommendation *recommendationJSON `json:"recommendation"`
+}
+
+// distributionJSON gives times of day as HH:MM and durations in minutes.
+type distributionJSON struct {
+	Nights        int     `json:"nights"`
+	Mean          string  `json:"mean"`
+	StdDevMinutes float64 `json:"std_dev_minutes"`
+	Earliest      string  `json:"earliest"`
+	Median        string  `json:"median"`
+	Latest        string  `json:"latest"`
+	Hours         [24]int `json:"hours"`
+}
+
+type socialJetlagJSON struct {
+	Workday      distributionJSON `json:"workday"`
+	FreeDay      distributionJSON `json:"free_day"`
+	ShiftMinutes float64          `json:"shift_minutes"`
+}
+
+type chronotypeJSON struct {
+	MidSleep string `json:"mid_sleep"`
+	Type     string `json:"type"`
+}
+
+type recommendationJSON struct {
+	Wake         time.Time `json:"wake"`
+	Bedtime      time.Time `json:"bedtime"`
+	NeedMinutes  float64   `json:"need_minutes"`
+	InBedMinutes float64   `json:"in_bed_minutes"`
+}
+
+const clockLayout = "15:04"
+
+func toDistributionJSON(d insights.Distribution) distributionJSON {
+	return distributionJSON{
+		Nights:        d.Nights,
+		Mean:          d.Mean.Format(clockLayout),
+		StdDevMinutes: d.StdDev.Minutes(),
+		Earliest:      d.Earliest.Format(clockLayout),
+		Median:        d.Median.Format(clockLayout),
+		Latest:        d.Latest.Format(clockLayout),
+		Hours:         d.Hours,
+	}
+}
+
+func writeCircadianJSON(w io.Writer, c insights.Circadian) error {
+	out := circadianJSON{
+		Bedtime:  toDistributionJSON(c.Bedtime),
+		Wake:     toDistributionJSON(c.Wake),
+		Midpoint: toDistributionJSON(c.Midpoint),
+	}
+	if j := c.SocialJetlag; j != nil {
+		out.SocialJetlag = &socialJetlagJSON{
+			Workday:      toDistributionJSON(j.Workday),
+			FreeDay:      toDistributionJSON(j.FreeDay),
+			ShiftMinutes: j.Shift.Minutes(),
+		}
+	}
+	if ct := c.Chronotype; ct != nil {
+		out.Chronotype = &chronotypeJSON{MidSleep: ct.MidSleep.Format(clockLayout), Type: ct.Type}
+	}
+	if r := c.Recommendation; r != nil {
+		out.Recommendation = &recommendationJSON{
+			Wake:         r.Wake,
+			Bedtime:      r.Bedtime,
+			NeedMinutes:  r.Need.Minutes(),
+			InBedMinutes: r.InBed.Minutes(),
+		}
+	}
+
+	data, err := go_json.Marshal(out)
+	if err != nil {
+		return fmt.Errorf("failed to marshal circadian analysis: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, string(data)); err != nil {
+		return fmt.Errorf("failed to write circadian analysis: %w", err)
+	}
+	return nil
+}
@@ -21,8 +21,18 @@ func main() {
 		Version: version.Get(),
 		RunE:    runTUI,
 	}
+	rootCmd.Flags().Bool("offline", false, "Run without network access, rendering from the local cache")
+	rootCmd.Flags().String("theme", "", "Color theme: dark, light, high-contrast, colorblind, or a palette in the themes directory")
 
 	rootCmd.AddCommand(upgradeCmd())
+	rootCmd.AddCommand(exportCmd())
+	rootCmd.AddCommand(syncCmd())
+	rootCmd.AddCommand(todayCmd())
+	rootCmd.AddCommand(queryCmd())
+	rootCmd.AddCommand(insightsCmd())
+	rootCmd.AddCommand(reportCmd())
+	rootCmd.AddCommand(trainingCmd())
+	rootCmd.AddCommand(goalsCmd())
 	addDevCommands(rootCmd)
 
 	if err := fang.Execute(context.Background(), rootCmd, fang.WithNotifySignal(os.Interrupt, syscall.SIGTERM)); err != nil {
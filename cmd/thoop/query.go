@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/garrettladley/thoop/internal/query"
+)
+
+func queryCmd() *cobra.Command {
+	var (
+		from   string
+		to     string
+		spec   query.Spec
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:       "query [cycles|workouts]",
+		Short:     "Filter and sort cached WHOOP data",
+		ValidArgs: []string{string(query.SourceCycles), string(query.SourceWorkouts)},
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		Long: `Filter and sort cached WHOOP data.
+
+Each row is a cycle joined with its recovery and sleep, or with the workouts
+source, a workout joined with the cycle it happened in. --where takes a
+condition built from fields, numbers and quoted strings with = != < <= > >=,
+and, or, not, parentheses and "is null". Prefix a field with prev. to read the
+day before. Comparisons against an unscored value never match.
+
+Fields:
+` + query.Fields(query.SourceWorkouts) + `
+Workout fields are only available with the workouts source.`,
+		Example: `  thoop query --where 'prev.strain > 15' --select date,hrv,recovery
+  thoop query --order-by 'hrv desc' --limit 10 --format csv
+  thoop query workouts --where "workout.sport = 'running'" --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			src := query.SourceCycles
+			if len(args) == 1 {
+				src = query.Source(args[0])
+			}
+			f, err := query.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+			q, err := query.New(src, spec)
+			if err != nil {
+				return err
+			}
+			start, end, err := parseDateRange(from, to)
+			if err != nil {
+				return err
+			}
+
+			sqlDB, repo, err := openRepository(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = sqlDB.Close() }()
+
+			rows, err := query.Load(ctx, repo, src, start, end)
+			if err != nil {
+				return err
+			}
+			return q.Run(rows).Write(os.Stdout, f)
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Start date (YYYY-MM-DD, inclusive); defaults to the oldest cached record")
+	cmd.Flags().StringVar(&to, "to", "", "End date (YYYY-MM-DD, inclusive); defaults to now")
+	cmd.Flags().StringVar(&spec.Where, "where", "", "Condition rows must match, e.g. 'strain > 15 and sleep < 70'")
+	cmd.Flags().StringVar(&spec.Select, "select", "", "Comma separated fields to print")
+	cmd.Flags().StringVar(&spec.OrderBy, "order-by", "", "Comma separated fields to sort by, each optionally followed by asc or desc")
+	cmd.Flags().IntVar(&spec.Limit, "limit", 0, "Print at most this many rows; 0 prints all")
+	cmd.Flags().StringVar(&format, "format", string(query.FormatTable), "Output format: table, csv, json")
+
+	return cmd
+}
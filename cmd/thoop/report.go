@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/garrettladley/thoop/internal/report"
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+func reportCmd() *cobra.Command {
+	var (
+		period string
+		format string
+		end    string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Write a weekly or monthly recap as Markdown or HTML",
+		Long: `Write a weekly or monthly recap as Markdown or HTML.
+
+The report covers the 7 days, or the calendar month, up to and including --end. It
+lists average recovery, HRV, resting heart rate, strain and sleep, the best
+and worst days by recovery, total strain, how sleep debt moved, workouts by
+sport and time in each heart rate zone. The output is a single file with no
+external assets; the HTML version draws its trends as inline SVG.
+
+Only the local cache is read; run thoop sync first for a full history.`,
+		Example: `  thoop report > week.md
+  thoop report --period month --format html -o march.html --end 2025-03-31`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			p, err := report.ParsePeriod(period)
+			if err != nil {
+				return err
+			}
+			f, err := report.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+			last := time.Now()
+			if end != "" {
+				if last, err = time.ParseInLocation(dateLayout, end, time.Local); err != nil {
+					return fmt.Errorf("invalid --end date %q: %w", end, err)
+				}
+			}
+			start, stop := p.Range(last)
+
+			sqlDB, repo, err := openRepository(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = sqlDB.Close() }()
+
+			data, err := repository.LoadRecords(ctx, repo, start, stop)
+			if err != nil {
+				return fmt.Errorf("failed to load report: %w", err)
+			}
+
+			r := report.Build(p, start, stop, data)
+			if output == "" {
+				return report.Render(os.Stdout, f, r)
+			}
+
+			file, err := os.Create(output) //nolint:gosec // path is provided by the user
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", output, err)
+			}
+			if err := report.Render(file, f, r); err != nil {
+				_ = file.Close()
+				return err
+			}
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("failed to close %s: %w", output, err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&period, "period", string(report.PeriodWeek), "Period to cover: week, month")
+	cmd.Flags().StringVar(&format, "format", string(report.FormatMarkdown), "Output format: markdown, html")
+	cmd.Flags().StringVar(&end, "end", "", "Last day to cover (YYYY-MM-DD); defaults to today")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file; defaults to stdout")
+
+	return cmd
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/garrettladley/thoop/internal/db"
+	"github.com/garrettladley/thoop/internal/paths"
+	"github.com/garrettladley/thoop/internal/repository"
+	sqlitec "github.com/garrettladley/thoop/internal/sqlc/sqlite"
+)
+
+// openDB opens the local cache database. The caller must close the returned *sql.DB.
+func openDB(ctx context.Context) (*sql.DB, sqlitec.Querier, error) {
+	if _, err := paths.EnsureDir(); err != nil {
+		return nil, nil, fmt.Errorf("failed to ensure directory: %w", err)
+	}
+
+	dbPath, err := paths.DB()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get database path: %w", err)
+	}
+
+	sqlDB, querier, err := db.Open(ctx, dbPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return sqlDB, querier, nil
+}
+
+// openRepository opens the local cache for commands that only read and write WHOOP data.
+func openRepository(ctx context.Context) (*sql.DB, *repository.Repository, error) {
+	sqlDB, querier, err := openDB(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sqlDB, repository.New(querier), nil
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/config"
+	"github.com/garrettladley/thoop/internal/repository"
+	"github.com/garrettladley/thoop/internal/xslog"
+	"github.com/garrettladley/thoop/internal/xsync"
+)
+
+const progressInterval = 2 * time.Second
+
+func syncCmd() *cobra.Command {
+	var (
+		days int
+		all  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Backfill WHOOP history into the local cache",
+		Long: `Backfill WHOOP history into the local cache.
+
+The backfill resumes from where the last run stopped and checkpoints after
+every page, so it is safe to interrupt. The horizon defaults to BACKFILL_DAYS
+(30); --all (or --days 0) walks back through the entire account history.
+
+When the rate limit runs low or the proxy responds 429, the backfill pauses
+until the window resets instead of failing. Once the backfill is done, records
+still waiting on a WHOOP score are re-fetched.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			cfg, err := config.Read()
+			if err != nil {
+				return fmt.Errorf("failed to read config: %w", err)
+			}
+			switch {
+			case all:
+				cfg.BackfillDays = 0
+			case cmd.Flags().Changed("days"):
+				cfg.BackfillDays = days
+			}
+
+			sqlDB, querier, err := openDB(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = sqlDB.Close() }()
+
+			logger := xslog.NewTextLogger(os.Stderr, xslog.LevelWarn)
+			client, err := newWhoopClient(ctx, cfg, querier,
+				whoop.WithLogger(logger),
+				whoop.WithRetry(whoop.DefaultRetryPolicy()),
+			)
+			if err != nil {
+				return err
+			}
+
+			repo := repository.New(querier)
+			syncSvc := xsync.NewService(client, repo, logger,
+				xsync.WithBackfillHorizon(cfg.BackfillHorizon()),
+			)
+
+			done := make(chan struct{})
+			go reportProgress(ctx, os.Stdout, syncSvc, done)
+
+			err = syncSvc.Backfill(ctx)
+			close(done)
+			if err != nil {
+				return fmt.Errorf("backfill failed: %w", err)
+			}
+
+			if err := printProgress(ctx, os.Stdout, syncSvc); err != nil {
+				return err
+			}
+
+			result, err := xsync.NewPendingReconciler(client, repo, logger).Reconcile(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to reconcile pending scores: %w", err)
+			}
+			_, _ = fmt.Fprintln(os.Stdout, formatPendingResult(result))
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&days, "days", 0, "Days of history to backfill; 0 fetches the entire account (default BACKFILL_DAYS)")
+	cmd.Flags().BoolVar(&all, "all", false, "Backfill the entire account history")
+	cmd.MarkFlagsMutuallyExclusive("days", "all")
+	cmd.AddCommand(syncStatusCmd())
+
+	return cmd
+}
+
+func syncStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show backfill progress",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			cfg, err := config.Read()
+			if err != nil {
+				return fmt.Errorf("failed to read config: %w", err)
+			}
+
+			sqlDB, repo, err := openRepository(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = sqlDB.Close() }()
+
+			// progress only reads the cache, so no client is needed
+			syncSvc := xsync.NewService(nil, repo, xslog.NewTextLogger(io.Discard, xslog.LevelError),
+				xsync.WithBackfillHorizon(cfg.BackfillHorizon()),
+			)
+			return printProgress(ctx, os.Stdout, syncSvc)
+		},
+	}
+}
+
+// reportProgress prints a progress summary until done is closed.
+func reportProgress(ctx context.Context, w io.Writer, syncSvc *xsync.Service, done <-chan struct{}) {
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			progress, err := syncSvc.BackfillProgress(ctx)
+			if err != nil {
+				continue
+			}
+			_, _ = fmt.Fprintln(w, formatProgressSummary(progress))
+		}
+	}
+}
+
+func printProgress(ctx context.Context, w io.Writer, syncSvc *xsync.Service) error {
+	progress, err := syncSvc.BackfillProgress(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get backfill progress: %w", err)
+	}
+
+	horizon := "full history"
+	if progress.Horizon != xsync.FullHistory {
+		horizon = fmt.Sprintf("%d days", int(progress.Horizon.Hours()/24))
+	}
+	_, _ = fmt.Fprintf(w, "horizon: %s\n", horizon)
+
+	for _, e := range progress.Entities {
+		_, _ = fmt.Fprintf(w, "  %-8s %s\n", e.Entity, formatEntityProgress(e))
+	}
+	_, _ = fmt.Fprintln(w, formatProgressSummary(progress))
+	return nil
+}
+
+func formatEntityProgress(e xsync.EntityProgress) string {
+	parts := []string{fmt.Sprintf("%d records", e.Records)}
+	if e.Oldest != nil {
+		parts = append(parts, "back to "+e.Oldest.Local().Format(dateLayout))
+	}
+	switch {
+	case e.Complete:
+		parts = append(parts, "complete")
+	case e.RequestsLeft != nil:
+		parts = append(parts, fmt.Sprintf("~%d requests left", *e.RequestsLeft))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatProgressSummary(p *xsync.BackfillProgress) string {
+	if p.Complete() {
+		return fmt.Sprintf("backfill complete: %d records", p.Records())
+	}
+
+	parts := []string{fmt.Sprintf("%d records", p.Records())}
+	if oldest := p.Oldest(); oldest != nil {
+		parts = append(parts, "back to "+oldest.Local().Format(dateLayout))
+	}
+	if left, ok := p.RequestsLeft(); ok {
+		parts = append(parts, fmt.Sprintf("~%d requests left", left))
+	}
+	if p.PausedUntil != nil {
+		wait := max(time.Until(*p.PausedUntil), 0).Round(time.Second)
+		parts = append(parts, fmt.Sprintf("rate limited, resuming in %s", wait))
+	}
+	return "backfill: " + strings.Join(parts, ", ")
+}
+
+func formatPendingResult(r xsync.PendingResult) string {
+	return fmt.Sprintf("pending scores: %d scored, %d unscorable, %d still pending",
+		r.Scored, r.Unscorable, r.Pending)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/config"
+	"github.com/garrettladley/thoop/internal/repository"
+	"github.com/garrettladley/thoop/internal/today"
+	"github.com/garrettladley/thoop/internal/xslog"
+	"github.com/garrettladley/thoop/internal/xsync"
+)
+
+func todayCmd() *cobra.Command {
+	var (
+		asJSON  bool
+		format  string
+		oneline bool
+		cached  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "today",
+		Aliases: []string{"status"},
+		Short:   "Print today's recovery, strain and sleep",
+		Long: `Print today's recovery, strain and sleep without starting the TUI.
+
+The current cycle is always fetched from WHOOP, since its strain keeps
+changing until the cycle ends. Its recovery and sleep come from the cache once
+they are scored and are only fetched while they aren't. Without --cached a
+network failure is an error; --cached never goes to the API, so it is safe to
+run from a shell prompt or status bar.
+
+--format takes a Go template executed against the summary. Its fields are
+.Start, .TimezoneOffset, .Recovery, .HRV, .RestingHeartRate, .Strain, .Sleep
+and .SleepHours; scores are unset until WHOOP scores them, which the score
+function prints as "--".`,
+		Example: `  thoop today --oneline --cached
+  thoop today --json
+  thoop today --format '{{score .Recovery "%.0f%%"}} {{score .Strain "%.1f"}}'`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			sqlDB, querier, err := openDB(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = sqlDB.Close() }()
+
+			repo := repository.New(querier)
+
+			var fetcher xsync.DataFetcher = xsync.NewCacheFetcher(repo)
+			if !cached {
+				cfg, err := config.Read()
+				if err != nil {
+					return fmt.Errorf("failed to read config: %w", err)
+				}
+				logger := xslog.NewTextLogger(os.Stderr, xslog.LevelWarn)
+				client, err := newWhoopClient(ctx, cfg, querier, whoop.WithLogger(logger))
+				if err != nil {
+					return err
+				}
+				fetcher = xsync.NewFetcher(client, repo, logger)
+			}
+
+			summary, err := today.Load(ctx, fetcher)
+			if err != nil {
+				return fmt.Errorf("failed to load today: %w", err)
+			}
+			if summary == nil {
+				return errors.New("no cycles yet: run thoop sync first")
+			}
+
+			switch {
+			case asJSON:
+				return summary.WriteJSON(os.Stdout)
+			case format != "":
+				tmpl, err := today.ParseTemplate(format)
+				if err != nil {
+					return err
+				}
+				if err := tmpl.Execute(os.Stdout, summary); err != nil {
+					return fmt.Errorf("failed to execute format template: %w", err)
+				}
+				_, _ = fmt.Fprintln(os.Stdout)
+				return nil
+			case oneline:
+				_, _ = fmt.Fprintln(os.Stdout, summary.Line())
+				return nil
+			default:
+				_, _ = fmt.Fprintln(os.Stdout, summary.Long())
+				return nil
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the summary as JSON")
+	cmd.Flags().StringVar(&format, "format", "", "Print the summary with a Go template")
+	cmd.Flags().BoolVar(&oneline, "oneline", false, "Print the summary on one line")
+	cmd.Flags().BoolVar(&cached, "cached", false, "Read only the local cache, making no API requests")
+	cmd.MarkFlagsMutuallyExclusive("json", "format", "oneline")
+
+	return cmd
+}
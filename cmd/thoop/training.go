@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	go_json "github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+
+	"github.com/garrettladley/thoop/internal/training"
+)
+
+func trainingCmd() *cobra.Command {
+	var (
+		measure string
+		end     string
+		asJSON  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "training",
+		Short: "Show acute and chronic training load and injury-risk warnings",
+		Long: `Show acute and chronic training load and injury-risk warnings.
+
+Acute load is the average daily load over the last 7 days and chronic load
+over the last 28, counted in workout strain, kilojoules or TRIMP (minutes in
+each heart rate zone weighted by the zone). Their ratio, the ACWR, is placed
+in a zone: below 0.8 is low, up to 1.3 optimal, up to 1.5 caution and past
+that danger. Monotony is the last week's mean daily load over its standard
+deviation, and strain is the week's load times its monotony. Balance is
+chronic less acute load. Every figure is given overall and per sport.
+
+Only the local cache is read; run thoop sync first for a full history.`,
+		Example: `  thoop training
+  thoop training --measure trimp --json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			m, err := training.ParseMeasure(measure)
+			if err != nil {
+				return err
+			}
+			at := time.Now()
+			if end != "" {
+				if at, err = time.ParseInLocation(dateLayout, end, time.Local); err != nil {
+					return fmt.Errorf("invalid --end date %q: %w", end, err)
+				}
+			}
+
+			sqlDB, repo, err := openRepository(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = sqlDB.Close() }()
+
+			workouts, err := training.Load(ctx, repo, at)
+			if err != nil {
+				return err
+			}
+			r := training.Compute(workouts, m, at)
+
+			if asJSON {
+				return writeTrainingJSON(os.Stdout, r)
+			}
+			printTraining(os.Stdout, r)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&measure, "measure", string(training.MeasureStrain), "Load measure: strain, kilojoule, trimp")
+	cmd.Flags().StringVar(&end, "end", "", "Day to report on (YYYY-MM-DD); defaults to today")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the workload as JSON")
+
+	return cmd
+}
+
+func printTraining(w io.Writer, r training.Report) {
+	_, _ = fmt.Fprintf(w, "training load in %s as of %s\n", r.Measure, r.Date.Format(dateLayout))
+
+	const columns = "  %-20s %8s %8s %8s %5s  %-8s %8s %9s %8s\n"
+	_, _ = fmt.Fprintf(w, columns, "sport", "workouts", "acute", "chronic", "acwr", "zone", "monotony", "strain", "balance")
+	for _, l := range append([]training.Workload{r.Total}, r.Sports...) {
+		sport := l.Sport
+		if sport == "" {
+			sport = "all"
+		}
+		_, _ = fmt.Fprintf(w, columns,
+			sport,
+			fmt.Sprintf("%d", l.Workouts),
+			fmt.Sprintf("%.1f", l.Acute),
+			fmt.Sprintf("%.1f", l.Chronic),
+			fmt.Sprintf("%.2f", l.ACWR),
+			l.Zone,
+			fmt.Sprintf("%.2f", l.Monotony),
+			fmt.Sprintf("%.0f", l.Strain),
+			fmt.Sprintf("%+.1f", l.Balance),
+		)
+	}
+
+	if len(r.Warnings) > 0 {
+		_, _ = fmt.Fprintln(w, "\nwarnings")
+	}
+	for _, warning := range r.Warnings {
+		_, _ = fmt.Fprintf(w, "  %s\n", warning)
+	}
+}
+
+type trainingJSON struct {
+	Date     string         `json:"date"`
+	Measure  string         `json:"measure"`
+	Total    workloadJSON   `json:"total"`
+	Sports   []workloadJSON `json:"sports"`
+	Warnings []warningJSON  `json:"warnings"`
+}
+
+type workloadJSON struct {
+	Sport    string  `json:"sport,omitempty"`
+	Workouts int     `json:"workouts"`
+	Acute    float64 `json:"acute"`
+	Chronic  float64 `json:"chronic"`
+	ACWR     float64 `json:"acwr"`
+	Zone     string  `json:"zone"`
+	Monotony float64 `json:"monotony"`
+	Strain   float64 `json:"strain"`
+	Balance  float64 `json:"balance"`
+}
+
+type warningJSON struct {
+	Sport   string `json:"sport,omitempty"`
+	Message string `json:"message"`
+}
+
+func toWorkloadJSON(l training.Workload) workloadJSON {
+	return workloadJSON{
+		Sport:    l.Sport,
+		Workouts: l.Workouts,
+		Acute:    l.Acute,
+		Chronic:  l.Chronic,
+		ACWR:     l.ACWR,
+		Zone:     string(l.Zone),
+		Monotony: l.Monotony,
+		Strain:   l.Strain,
+		Balance:  l.Balance,
+	}
+}
+
+func writeTrainingJSON(w io.Writer, r training.Report) error {
+	out := trainingJSON{
+		Date:     r.Date.Format(dateLayout),
+		Measure:  string(r.Measure),
+		Total:    toWorkloadJSON(r.Total),
+		Sports:   make([]workloadJSON, len(r.Sports)),
+		Warnings: make([]warningJSON, len(r.Warnings)),
+	}
+	for i, l := range r.Sports {
+		out.Sports[i] = toWorkloadJSON(l)
+	}
+	for i, warning := range r.Warnings {
+		out.Warnings[i] = warningJSON{Sport: warning.Sport, Message: warning.Message}
+	}
+
+	data, err := go_json.Marshal(out)
+	if err != nil {
+		return fmt.Errorf("failed to marshal training load: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, string(data)); err != nil {
+		return fmt.Errorf("failed to write training load: %w", err)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"github.com/spf13/cobra"
 
+	"github.com/garrettladley/thoop/internal/client/health"
 	"github.com/garrettladley/thoop/internal/client/sse"
 	"github.com/garrettladley/thoop/internal/client/whoop"
 	"github.com/garrettladley/thoop/internal/config"
@@ -19,6 +20,7 @@ import (
 	"github.com/garrettladley/thoop/internal/session"
 	"github.com/garrettladley/thoop/internal/storage"
 	"github.com/garrettladley/thoop/internal/tui"
+	"github.com/garrettladley/thoop/internal/tui/theme"
 	"github.com/garrettladley/thoop/internal/xslog"
 	"github.com/garrettladley/thoop/internal/xsync"
 )
@@ -79,29 +81,46 @@ func runTUI(cmd *cobra.Command, _ []string) error {
 		whoop.WithSessionID(sessionID),
 		whoop.WithAPIKey(apiKey),
 		whoop.WithLogger(logger),
+		whoop.WithRetry(whoop.DefaultRetryPolicy()),
 	)
 	logger.InfoContext(ctx, "starting thoop", xslog.Version())
 
 	repo := repository.New(querier)
-	syncSvc := xsync.NewService(client, repo, logger)
+	syncSvc := xsync.NewService(client, repo, logger, xsync.WithBackfillHorizon(cfg.BackfillHorizon()))
 	dataFetcher := xsync.NewFetcher(client, repo, logger)
 
 	sseClient := sse.NewClient(cfg.ServerURL, tokenSource, sessionID, apiKey, logger)
 	notifProcessor := xsync.NewNotificationProcessor(client, repo, logger)
 	notifChan := make(chan storage.Notification, 10)
 
-	if hasToken, _ := tokenSource.HasToken(ctx); hasToken {
-		if err := syncSvc.RefreshCurrent(ctx); err != nil {
-			logger.WarnContext(ctx, "failed to refresh current data", xslog.Error(err))
-		}
+	forceOffline, err := cmd.Flags().GetBool("offline")
+	if err != nil {
+		return fmt.Errorf("failed to get offline flag: %w", err)
+	}
 
-		if complete, err := syncSvc.IsBackfillComplete(ctx); err == nil && !complete {
-			if err := syncSvc.StartBackfill(ctx); err != nil {
-				logger.WarnContext(ctx, "failed to start backfill", xslog.Error(err))
-			}
+	healthClient := health.NewClient(cfg.ServerURL)
+	offline := forceOffline
+	if !offline {
+		if err := healthClient.Check(ctx); err != nil {
+			logger.WarnContext(ctx, "server unreachable, starting in offline mode", xslog.Error(err))
+			offline = true
 		}
 	}
 
+	keymapPath, err := paths.Keymap()
+	if err != nil {
+		return fmt.Errorf("failed to get keymap path: %w", err)
+	}
+	keymap, err := tui.LoadKeymap(keymapPath)
+	if err != nil {
+		logger.WarnContext(ctx, "failed to load key overrides, using defaults", xslog.Error(err))
+	}
+
+	t, err := loadTheme(ctx, cmd, logger)
+	if err != nil {
+		return err
+	}
+
 	deps := tui.Deps{
 		Ctx:              ctx,
 		Cancel:           cancel,
@@ -116,6 +135,12 @@ func runTUI(cmd *cobra.Command, _ []string) error {
 		SSEClient:        sseClient,
 		NotifProcessor:   notifProcessor,
 		NotificationChan: notifChan,
+		HealthClient:     healthClient,
+		Reconciler:       xsync.NewPendingReconciler(client, repo, logger),
+		Keymap:           keymap,
+		Theme:            &t,
+		Offline:          offline,
+		ForceOffline:     forceOffline,
 	}
 	model := tui.New(deps)
 
@@ -128,3 +153,44 @@ func runTUI(cmd *cobra.Command, _ []string) error {
 
 	return nil
 }
+
+// loadTheme picks the theme from --theme, then the config file, falling back
+// to dark. A bad --theme fails the command; a bad config file only warns.
+func loadTheme(ctx context.Context, cmd *cobra.Command, logger *slog.Logger) (theme.Theme, error) {
+	themesDir, err := paths.ThemesDir()
+	if err != nil {
+		return theme.Theme{}, fmt.Errorf("failed to get themes directory: %w", err)
+	}
+
+	name, err := cmd.Flags().GetString("theme")
+	if err != nil {
+		return theme.Theme{}, fmt.Errorf("failed to get theme flag: %w", err)
+	}
+	if name != "" {
+		t, err := theme.Load(themesDir, name)
+		if err != nil {
+			return theme.Theme{}, fmt.Errorf("failed to load theme: %w", err)
+		}
+		return t, nil
+	}
+
+	configPath, err := paths.ConfigFile()
+	if err != nil {
+		return theme.Theme{}, fmt.Errorf("failed to get config file path: %w", err)
+	}
+	file, err := config.ReadFile(configPath)
+	if err != nil {
+		logger.WarnContext(ctx, "failed to read config file, using defaults", xslog.Error(err))
+		return theme.New(), nil
+	}
+	if file.Theme == "" {
+		return theme.New(), nil
+	}
+
+	t, err := theme.Load(themesDir, file.Theme)
+	if err != nil {
+		logger.WarnContext(ctx, "failed to load theme, using dark", xslog.Error(err))
+		return theme.New(), nil
+	}
+	return t, nil
+}
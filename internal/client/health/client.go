@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/xhttp"
+)
+
+const defaultTimeout = 3 * time.Second
+
+// Client probes the thoop server's health endpoint to detect connectivity.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+type Option func(*Client)
+
+func WithHTTPClient(c *http.Client) Option {
+	return func(client *Client) { client.httpClient = c }
+}
+
+func NewClient(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		httpClient: xhttp.NewHTTPClient(xhttp.WithTimeout(defaultTimeout)),
+		baseURL:    baseURL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Check returns nil when the server is reachable and healthy.
+func (c *Client) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/health", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return nil
+}
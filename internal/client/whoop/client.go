@@ -8,9 +8,11 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/garrettladley/thoop/internal/xhttp"
+	"github.com/garrettladley/thoop/internal/xslog"
 	go_json "github.com/goccy/go-json"
 	"golang.org/x/oauth2"
 )
@@ -26,6 +28,13 @@ type Client struct {
 	httpClient *http.Client
 	transport  *whoopTransport
 	logger     *slog.Logger
+	retry      *RetryPolicy
+	sleep      func(ctx context.Context, d time.Duration) error
+	governor   *governor
+
+	rateLimitMu         sync.Mutex
+	rateLimit           *RateLimitInfo
+	rateLimitObservedAt time.Time
 }
 
 func New(tokenSource oauth2.TokenSource, opts ...Option) *Client {
@@ -53,6 +62,9 @@ func New(tokenSource oauth2.TokenSource, opts ...Option) *Client {
 		httpClient: &http.Client{Transport: transport, Timeout: cfg.timeout},
 		transport:  transport,
 		logger:     cfg.logger,
+		retry:      cfg.retry,
+		sleep:      sleepContext,
+		governor:   newGovernor(),
 	}
 
 	c.User = &userService{client: c}
@@ -72,6 +84,7 @@ type clientConfig struct {
 	sessionID    string
 	apiKey       string
 	timeout      time.Duration
+	retry        *RetryPolicy
 }
 
 type Option func(*clientConfig)
@@ -100,6 +113,35 @@ func WithTimeout(d time.Duration) Option {
 }
 
 func (c *Client) do(ctx context.Context, method string, path string, query url.Values, result any) error {
+	// only idempotent requests are safe to retry
+	if c.retry == nil || method != http.MethodGet {
+		return c.doOnce(ctx, method, path, query, result)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := c.doOnce(ctx, method, path, query, result)
+		if err == nil || attempt+1 >= c.retry.MaxAttempts {
+			return err
+		}
+
+		delay, ok := c.retry.retryDelay(ctx, attempt, err)
+		if !ok {
+			return err
+		}
+
+		c.logger.DebugContext(ctx, "retrying request",
+			xslog.Path(path),
+			xslog.Attempt(attempt+1),
+			xslog.Backoff(delay),
+			xslog.Error(err))
+
+		if err := c.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) doOnce(ctx context.Context, method string, path string, query url.Values, result any) error {
 	u := c.baseURL + path
 	if len(query) > 0 {
 		u += "?" + query.Encode()
@@ -110,14 +152,24 @@ func (c *Client) do(ctx context.Context, method string, path string, query url.V
 		return fmt.Errorf("creating request: %w", err)
 	}
 
+	if err := c.governor.wait(ctx, priorityFrom(ctx)); err != nil {
+		return err
+	}
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("executing request: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	c.observeRateLimit(ctx, resp.Header)
+
 	if resp.StatusCode >= 400 {
-		return parseAPIError(resp)
+		apiErr := parseAPIError(resp)
+		if apiErr.IsRateLimited() {
+			c.governor.blockFor(apiErr.RetryAfter)
+		}
+		return apiErr
 	}
 
 	if result != nil && resp.StatusCode != http.StatusNoContent {
@@ -168,6 +220,40 @@ func (t *whoopTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
+// RateLimit returns the rate limit reported by the most recent response, with
+// Reset adjusted for the time elapsed since. It is nil until a response carries
+// rate limit headers.
+func (c *Client) RateLimit() *RateLimitInfo {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+
+	if c.rateLimit == nil {
+		return nil
+	}
+
+	info := *c.rateLimit
+	info.Reset = max(info.Reset-time.Since(c.rateLimitObservedAt), 0)
+	return &info
+}
+
+func (c *Client) observeRateLimit(ctx context.Context, headers http.Header) {
+	info, err := ParseRateLimitHeaders(headers)
+	if err != nil {
+		c.logger.DebugContext(ctx, "failed to parse rate limit headers", xslog.Error(err))
+		return
+	}
+	if info == nil {
+		return
+	}
+
+	c.governor.observe(info)
+
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	c.rateLimit = info
+	c.rateLimitObservedAt = time.Now()
+}
+
 func (c *Client) SetAPIKey(apiKey string) {
 	c.transport.apiKey = apiKey
 }
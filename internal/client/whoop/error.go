@@ -24,7 +24,11 @@ func (e *APIError) IsRateLimited() bool {
 	return e.StatusCode == http.StatusTooManyRequests
 }
 
-func parseAPIError(resp *http.Response) error {
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+func parseAPIError(resp *http.Response) *APIError {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return &APIError{
@@ -58,7 +62,7 @@ func parseAPIError(resp *http.Response) error {
 		Message:    msg,
 	}
 
-	if resp.StatusCode == http.StatusTooManyRequests {
+	if resp.StatusCode == http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "" {
 		apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
 	}
 
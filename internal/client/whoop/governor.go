@@ -0,0 +1,185 @@
+package whoop
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Priority orders requests queued by the governor. Lower values go first.
+type Priority int
+
+const (
+	// PriorityInteractive is for requests the user is waiting on. It's the
+	// default for contexts without a priority.
+	PriorityInteractive Priority = iota
+	PriorityNotification
+	PriorityBackfill
+
+	numPriorities = int(PriorityBackfill) + 1
+)
+
+type priorityKey struct{}
+
+// WithPriority tags requests made with ctx with p.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+func priorityFrom(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok && p >= 0 && int(p) < numPriorities {
+		return p
+	}
+	return PriorityInteractive
+}
+
+const (
+	// defaultRequestsPerWindow is WHOOP's documented per-minute limit, assumed
+	// until a response reports the actual one.
+	defaultRequestsPerWindow = 100
+	rateLimitWindow          = time.Minute
+
+	minGovernorWait = 10 * time.Millisecond
+)
+
+// governor is a token bucket shared by every request a client makes. It
+// learns the limit and remaining budget from rate limit headers, and holds
+// back lower priorities while higher ones are waiting, plus a reserve so
+// background work never spends the last requests in a window.
+type governor struct {
+	mu           sync.Mutex
+	now          func() time.Time
+	sleep        func(ctx context.Context, d time.Duration) error
+	limit        int
+	tokens       float64
+	refilledAt   time.Time
+	blockedUntil time.Time
+	waiting      [numPriorities]int
+}
+
+func newGovernor() *governor {
+	g := &governor{
+		now:   time.Now,
+		sleep: sleepContext,
+		limit: defaultRequestsPerWindow,
+	}
+	g.tokens = float64(g.limit)
+	g.refilledAt = g.now()
+	return g
+}
+
+// wait blocks until a request at priority p may be sent.
+func (g *governor) wait(ctx context.Context, p Priority) error {
+	g.mu.Lock()
+	g.waiting[p]++
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		g.waiting[p]--
+		g.mu.Unlock()
+	}()
+
+	for {
+		delay, ok := g.take(p)
+		if ok {
+			return nil
+		}
+
+		if err := g.sleep(ctx, delay); err != nil {
+			return fmt.Errorf("waiting for rate limit: %w", err)
+		}
+	}
+}
+
+// take spends a token for priority p if one is available, otherwise it
+// returns how long to wait before trying again.
+func (g *governor) take(p Priority) (time.Duration, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	g.refill(now)
+
+	if now.Before(g.blockedUntil) {
+		return g.blockedUntil.Sub(now), false
+	}
+
+	for higher := range p {
+		if g.waiting[higher] > 0 {
+			return g.tokenInterval(), false
+		}
+	}
+
+	need := 1 + g.reserve(p)
+	if g.tokens < need {
+		wait := time.Duration((need - g.tokens) * float64(g.tokenInterval()))
+		return max(wait, minGovernorWait), false
+	}
+
+	g.tokens--
+	return 0, true
+}
+
+func (g *governor) refill(now time.Time) {
+	elapsed := now.Sub(g.refilledAt)
+	if elapsed <= 0 {
+		return
+	}
+	g.tokens = min(g.tokens+float64(elapsed)/float64(g.tokenInterval()), float64(g.limit))
+	g.refilledAt = now
+}
+
+func (g *governor) tokenInterval() time.Duration {
+	return rateLimitWindow / time.Duration(g.limit)
+}
+
+// reserve is the number of tokens priority p leaves for higher priorities.
+func (g *governor) reserve(p Priority) float64 {
+	switch p {
+	case PriorityInteractive:
+		return 0
+	case PriorityNotification:
+		return float64(g.limit) / 20
+	case PriorityBackfill:
+		return float64(g.limit) / 10
+	default:
+		return 0
+	}
+}
+
+// observe syncs the bucket with the server's view of the rate limit.
+func (g *governor) observe(info *RateLimitInfo) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	g.refill(now)
+
+	if info.Limit > 0 {
+		g.limit = info.Limit
+	}
+	g.tokens = min(g.tokens, float64(info.Remaining))
+	if info.Remaining <= 0 {
+		g.block(now.Add(info.Reset))
+	}
+}
+
+// blockFor stops every request for d, e.g. after a 429.
+func (g *governor) blockFor(d time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	g.tokens = 0
+	g.refilledAt = now
+	g.block(now.Add(d))
+}
+
+func (g *governor) block(until time.Time) {
+	if until.After(g.blockedUntil) {
+		g.blockedUntil = until
+		g.refilledAt = until
+	}
+}
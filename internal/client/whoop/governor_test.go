@@ -0,0 +1,156 @@
+package whoop
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock stands in for time in the governor; sleeping advances it.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+	return ctx.Err()
+}
+
+func newTestGovernor(clock *fakeClock) *governor {
+	g := newGovernor()
+	g.now = clock.Now
+	g.sleep = clock.Sleep
+	g.refilledAt = clock.Now()
+	return g
+}
+
+func TestPriorityFrom(t *testing.T) {
+	t.Parallel()
+
+	if got := priorityFrom(t.Context()); got != PriorityInteractive {
+		t.Errorf("priorityFrom(untagged) = %v, want %v", got, PriorityInteractive)
+	}
+	if got := priorityFrom(WithPriority(t.Context(), PriorityBackfill)); got != PriorityBackfill {
+		t.Errorf("priorityFrom(backfill) = %v, want %v", got, PriorityBackfill)
+	}
+}
+
+func TestGovernorObserve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		info     RateLimitInfo
+		priority Priority
+		wantWait time.Duration
+	}{
+		{
+			name:     "budget left",
+			info:     RateLimitInfo{Limit: 60, Remaining: 30, Reset: 30 * time.Second},
+			priority: PriorityInteractive,
+			wantWait: 0,
+		},
+		{
+			name:     "exhausted waits for reset",
+			info:     RateLimitInfo{Limit: 60, Remaining: 0, Reset: 30 * time.Second},
+			priority: PriorityInteractive,
+			wantWait: 31 * time.Second, // the reset, then refilling one token
+		},
+		{
+			name:     "interactive may spend the reserve",
+			info:     RateLimitInfo{Limit: 60, Remaining: 2, Reset: 30 * time.Second},
+			priority: PriorityInteractive,
+			wantWait: 0,
+		},
+		{
+			name:     "backfill leaves the reserve",
+			info:     RateLimitInfo{Limit: 60, Remaining: 2, Reset: 30 * time.Second},
+			priority: PriorityBackfill,
+			wantWait: 5 * time.Second, // 7 tokens at 1/s minus the 2 left
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clock := newFakeClock()
+			g := newTestGovernor(clock)
+			g.observe(&tt.info)
+
+			start := clock.Now()
+			if err := g.wait(t.Context(), tt.priority); err != nil {
+				t.Fatalf("wait() error = %v", err)
+			}
+			if got := clock.Now().Sub(start); got != tt.wantWait {
+				t.Errorf("waited %v, want %v", got, tt.wantWait)
+			}
+		})
+	}
+}
+
+func TestGovernorBlockFor(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	g := newTestGovernor(clock)
+	g.blockFor(10 * time.Second)
+
+	if _, ok := g.take(PriorityInteractive); ok {
+		t.Fatal("take() succeeded while blocked")
+	}
+
+	start := clock.Now()
+	if err := g.wait(t.Context(), PriorityInteractive); err != nil {
+		t.Fatalf("wait() error = %v", err)
+	}
+	if got := clock.Now().Sub(start); got < 10*time.Second {
+		t.Errorf("waited %v, want at least 10s", got)
+	}
+}
+
+func TestGovernorHigherPriorityGoesFirst(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	g := newTestGovernor(clock)
+
+	g.mu.Lock()
+	g.waiting[PriorityInteractive]++
+	g.mu.Unlock()
+
+	if _, ok := g.take(PriorityBackfill); ok {
+		t.Error("backfill took a token while an interactive request was waiting")
+	}
+	if _, ok := g.take(PriorityInteractive); !ok {
+		t.Error("interactive could not take a token")
+	}
+}
+
+func TestGovernorRespectsContext(t *testing.T) {
+	t.Parallel()
+
+	g := newGovernor()
+	g.blockFor(time.Hour)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	if err := g.wait(ctx, PriorityInteractive); err == nil {
+		t.Error("wait() error = nil, want context error")
+	}
+}
@@ -0,0 +1,110 @@
+package whoop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RetryPolicy controls how idempotent requests are retried on 429, 5xx and
+// network errors. Zero fields fall back to DefaultRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry. It doubles every retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff. It doesn't cap a Retry-After from the server.
+	MaxDelay time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// WithRetry retries GET requests according to policy.
+func WithRetry(policy RetryPolicy) Option {
+	return func(cfg *clientConfig) {
+		defaults := DefaultRetryPolicy()
+		if policy.MaxAttempts <= 0 {
+			policy.MaxAttempts = defaults.MaxAttempts
+		}
+		if policy.BaseDelay <= 0 {
+			policy.BaseDelay = defaults.BaseDelay
+		}
+		if policy.MaxDelay <= 0 {
+			policy.MaxDelay = defaults.MaxDelay
+		}
+		cfg.retry = &policy
+	}
+}
+
+// retryDelay returns how long to wait before retrying after err on the given
+// attempt (0-indexed), and whether err is retryable at all.
+func (p *RetryPolicy) retryDelay(ctx context.Context, attempt int, err error) (time.Duration, bool) {
+	if ctx.Err() != nil {
+		return 0, false
+	}
+
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &apiErr):
+		if apiErr.StatusCode != http.StatusTooManyRequests && apiErr.StatusCode < http.StatusInternalServerError {
+			return 0, false
+		}
+		if apiErr.RetryAfter > 0 {
+			return apiErr.RetryAfter, true
+		}
+	case !isNetworkError(err):
+		return 0, false
+	}
+
+	return p.backoff(attempt), true
+}
+
+// isNetworkError reports whether err is a transient failure to reach the
+// server, as opposed to e.g. a token or decoding error.
+func isNetworkError(err error) bool {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return false
+	}
+
+	var opErr *net.OpError
+	return urlErr.Timeout() ||
+		errors.As(err, &opErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// backoff returns an exponential delay with equal jitter, so retries from
+// concurrent callers spread out without ever retrying immediately.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MaxDelay
+	if attempt < 32 {
+		d = min(p.BaseDelay<<attempt, p.MaxDelay)
+	}
+	half := d / 2
+	return half + rand.N(half+1) //nolint:gosec // jitter doesn't need a secure source
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("%w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
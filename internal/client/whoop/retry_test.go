@@ -0,0 +1,205 @@
+package whoop
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+type sleepRecorder struct {
+	mu     sync.Mutex
+	delays []time.Duration
+}
+
+func (r *sleepRecorder) sleep(ctx context.Context, d time.Duration) error {
+	r.mu.Lock()
+	r.delays = append(r.delays, d)
+	r.mu.Unlock()
+	return ctx.Err()
+}
+
+// newRetryTestClient returns a client pointed at a server that replies with
+// statuses in turn, then 200 once they run out.
+func newRetryTestClient(t *testing.T, statuses []int, headers http.Header, opts ...Option) (*Client, *sleepRecorder, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := int(calls.Add(1)) - 1
+		if n < len(statuses) {
+			for k, v := range headers {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(statuses[n])
+			_, _ = w.Write([]byte(`{"message":"try again"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+	c := New(tokenSource, append([]Option{WithProxyURL(srv.URL)}, opts...)...)
+
+	// keep 429s from blocking the governor in real time
+	c.governor = newTestGovernor(newFakeClock())
+
+	recorder := &sleepRecorder{}
+	c.sleep = recorder.sleep
+	return c, recorder, &calls
+}
+
+func TestClientRetry(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	tests := []struct {
+		name       string
+		method     string
+		statuses   []int
+		headers    http.Header
+		opts       []Option
+		wantCalls  int32
+		wantStatus int
+		wantDelays []time.Duration
+	}{
+		{
+			name:      "retries server errors until success",
+			method:    http.MethodGet,
+			statuses:  []int{http.StatusBadGateway, http.StatusServiceUnavailable},
+			opts:      []Option{WithRetry(policy)},
+			wantCalls: 3,
+		},
+		{
+			name:       "honours retry-after",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusTooManyRequests},
+			headers:    http.Header{"Retry-After": []string{"42"}},
+			opts:       []Option{WithRetry(policy)},
+			wantCalls:  2,
+			wantDelays: []time.Duration{42 * time.Second},
+		},
+		{
+			name:       "gives up after max attempts",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			opts:       []Option{WithRetry(policy)},
+			wantCalls:  3,
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "does not retry client errors",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusNotFound},
+			opts:       []Option{WithRetry(policy)},
+			wantCalls:  1,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "does not retry non-idempotent requests",
+			method:     http.MethodPost,
+			statuses:   []int{http.StatusServiceUnavailable},
+			opts:       []Option{WithRetry(policy)},
+			wantCalls:  1,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "does not retry without a policy",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusServiceUnavailable},
+			wantCalls:  1,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, recorder, calls := newRetryTestClient(t, tt.statuses, tt.headers, tt.opts...)
+
+			err := c.do(t.Context(), tt.method, "/test", nil, nil)
+
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+
+			var apiErr *APIError
+			switch {
+			case tt.wantStatus == 0 && err != nil:
+				t.Errorf("do() error = %v, want nil", err)
+			case tt.wantStatus != 0 && !errors.As(err, &apiErr):
+				t.Errorf("do() error = %v, want APIError", err)
+			case tt.wantStatus != 0 && apiErr.StatusCode != tt.wantStatus:
+				t.Errorf("status = %d, want %d", apiErr.StatusCode, tt.wantStatus)
+			}
+
+			if tt.wantDelays != nil {
+				if len(recorder.delays) != len(tt.wantDelays) {
+					t.Fatalf("delays = %v, want %v", recorder.delays, tt.wantDelays)
+				}
+				for i, d := range tt.wantDelays {
+					if recorder.delays[i] != d {
+						t.Errorf("delay[%d] = %v, want %v", i, recorder.delays[i], d)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestClientRetryRespectsContext(t *testing.T) {
+	t.Parallel()
+
+	c, _, calls := newRetryTestClient(t,
+		[]int{http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+		nil,
+		WithRetry(RetryPolicy{MaxAttempts: 5}),
+	)
+	c.sleep = sleepContext
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	err := c.do(ctx, http.MethodGet, "/test", nil, nil)
+	if err == nil {
+		t.Fatal("do() error = nil, want context error")
+	}
+	if got := calls.Load(); got > 1 {
+		t.Errorf("calls = %d, want at most 1", got)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	tests := []struct {
+		attempt int
+		ceiling time.Duration
+	}{
+		{attempt: 0, ceiling: time.Second},
+		{attempt: 1, ceiling: 2 * time.Second},
+		{attempt: 2, ceiling: 4 * time.Second},
+		{attempt: 3, ceiling: 5 * time.Second},
+		{attempt: 40, ceiling: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		for range 100 {
+			got := policy.backoff(tt.attempt)
+			if got < tt.ceiling/2 || got > tt.ceiling {
+				t.Fatalf("backoff(%d) = %v, want within [%v, %v]", tt.attempt, got, tt.ceiling/2, tt.ceiling)
+			}
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package whoop
+
+import (
+	"fmt"
+	"time"
+)
+
+// Location returns the fixed zone for a WHOOP timezone offset such as
+// "-05:00", falling back to the local zone when the offset can't be parsed.
+func Location(offset string) *time.Location {
+	t, err := time.Parse("-07:00", offset)
+	if err != nil {
+		return time.Local
+	}
+	_, seconds := t.Zone()
+	return time.FixedZone(fmt.Sprintf("UTC%s", offset), seconds)
+}
@@ -0,0 +1,42 @@
+package whoop
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		offset string
+		want   int // seconds east of UTC
+		local  bool
+	}{
+		{name: "negative offset", offset: "-05:00", want: -5 * 60 * 60},
+		{name: "positive offset with minutes", offset: "+05:30", want: 5*60*60 + 30*60},
+		{name: "utc", offset: "+00:00", want: 0},
+		{name: "empty falls back to local", offset: "", local: true},
+		{name: "garbage falls back to local", offset: "EST", local: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			loc := Location(tt.offset)
+			if tt.local {
+				if loc != time.Local {
+					t.Errorf("Location(%q) = %v, want local", tt.offset, loc)
+				}
+				return
+			}
+
+			_, got := time.Date(2025, 1, 1, 0, 0, 0, 0, loc).Zone()
+			if got != tt.want {
+				t.Errorf("Location(%q) offset = %d, want %d", tt.offset, got, tt.want)
+			}
+		})
+	}
+}
@@ -88,6 +88,11 @@ type SleepStages struct {
 	DisturbanceCount            int `json:"disturbance_count"`
 }
 
+// TotalSleepTimeMilli is the time actually asleep: light, slow wave and REM sleep.
+func (s SleepStages) TotalSleepTimeMilli() int {
+	return s.TotalLightSleepTimeMilli + s.TotalSlowWaveSleepTimeMilli + s.TotalREMSleepTimeMilli
+}
+
 type SleepNeeded struct {
 	BaselineMilli             int `json:"baseline_milli"`
 	NeedFromSleepDebtMilli    int `json:"need_from_sleep_debt_milli"`
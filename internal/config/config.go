@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/caarlos0/env/v11"
 )
 
@@ -8,6 +10,14 @@ const DefaultServerURL = "https://thoop.fly.dev"
 
 type Config struct {
 	ServerURL string `env:"SERVER_URL" envDefault:"https://thoop.fly.dev"`
+	// BackfillDays is how many days of history the backfill fetches.
+	// 0 fetches the entire account history.
+	BackfillDays int `env:"BACKFILL_DAYS" envDefault:"30"`
+}
+
+// BackfillHorizon returns the backfill horizon, or 0 for the full account history.
+func (c Config) BackfillHorizon() time.Duration {
+	return time.Duration(max(c.BackfillDays, 0)) * 24 * time.Hour
 }
 
 func Read() (Config, error) {
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/BurntSushi/toml"
+)
+
+// File is the optional config.toml in the thoop directory:
+//
+//	theme = "light"
+type File struct {
+	// Theme names a built-in theme or a palette in the themes directory.
+	Theme string `toml:"theme"`
+}
+
+// ReadFile reads the config file at path. A missing file is an empty config.
+func ReadFile(path string) (File, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is from trusted paths package
+	if errors.Is(err, os.ErrNotExist) {
+		return File{}, nil
+	}
+	if err != nil {
+		return File{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var f File
+	md, err := toml.Decode(string(data), &f)
+	if err != nil {
+		return File{}, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		return File{}, fmt.Errorf("failed to parse config file: unknown key %q", undecoded[0].String())
+	}
+	return f, nil
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, contents string) string {
+		path := filepath.Join(dir, name+".toml")
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		return path
+	}
+
+	t.Run("theme", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := ReadFile(write("theme", "# thoop config\ntheme = \"light\" # trailing comment\n"))
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		if got.Theme != "light" {
+			t.Errorf("ReadFile() theme = %q, want %q", got.Theme, "light")
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := ReadFile(filepath.Join(dir, "absent.toml"))
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		if got != (File{}) {
+			t.Errorf("ReadFile() = %+v, want an empty config", got)
+		}
+	})
+
+	invalid := []struct {
+		name     string
+		contents string
+	}{
+		{name: "array", contents: "theme = [1, 2]\n"},
+		{name: "inline table", contents: "theme = { name = \"light\" }\n"},
+		{name: "unquoted", contents: "theme = light\n"},
+		{name: "number", contents: "theme = 1\n"},
+		{name: "unknown key", contents: "thme = \"light\"\n"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := ReadFile(write(tt.name, tt.contents)); err == nil {
+				t.Errorf("ReadFile(%q) error = nil, want error", tt.contents)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package export
+
+import (
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+)
+
+// column is a single flattened field of T. value returns nil when the field is
+// absent, e.g. when the record hasn't been scored yet.
+type column[T any] struct {
+	name  string
+	value func(T) any
+}
+
+// scored returns a column that reads from the score of T, or nil when unscored.
+func scored[T, S any](name string, score func(T) *S, value func(*S) any) column[T] {
+	return column[T]{
+		name: name,
+		value: func(t T) any {
+			s := score(t)
+			if s == nil {
+				return nil
+			}
+			return value(s)
+		},
+	}
+}
+
+func timestamp(t time.Time) any {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func optionalTimestamp(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return timestamp(*t)
+}
+
+func optional[T any](v *T) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+// headers returns the column names in order.
+func headers[T any](columns []column[T]) []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+var cycleColumns = func() []column[whoop.Cycle] {
+	score := func(c whoop.Cycle) *whoop.CycleScore { return c.Score }
+	return []column[whoop.Cycle]{
+		{"id", func(c whoop.Cycle) any { return c.ID }},
+		{"user_id", func(c whoop.Cycle) any { return c.UserID }},
+		{"start", func(c whoop.Cycle) any { return timestamp(c.Start) }},
+		{"end", func(c whoop.Cycle) any { return optionalTimestamp(c.End) }},
+		{"timezone_offset", func(c whoop.Cycle) any { return c.TimezoneOffset }},
+		{"score_state", func(c whoop.Cycle) any { return string(c.ScoreState) }},
+		scored("strain", score, func(s *whoop.CycleScore) any { return s.Strain }),
+		scored("kilojoule", score, func(s *whoop.CycleScore) any { return s.Kilojoule }),
+		scored("average_heart_rate", score, func(s *whoop.CycleScore) any { return s.AverageHeartRate }),
+		scored("max_heart_rate", score, func(s *whoop.CycleScore) any { return s.MaxHeartRate }),
+		{"created_at", func(c whoop.Cycle) any { return timestamp(c.CreatedAt) }},
+		{"updated_at", func(c whoop.Cycle) any { return timestamp(c.UpdatedAt) }},
+	}
+}()
+
+var recoveryColumns = func() []column[whoop.Recovery] {
+	score := func(r whoop.Recovery) *whoop.RecoveryScore { return r.Score }
+	return []column[whoop.Recovery]{
+		{"cycle_id", func(r whoop.Recovery) any { return r.CycleID }},
+		{"sleep_id", func(r whoop.Recovery) any { return r.SleepID }},
+		{"user_id", func(r whoop.Recovery) any { return r.UserID }},
+		{"score_state", func(r whoop.Recovery) any { return string(r.ScoreState) }},
+		scored("user_calibrating", score, func(s *whoop.RecoveryScore) any { return s.UserCalibrating }),
+		scored("recovery_score", score, func(s *whoop.RecoveryScore) any { return s.RecoveryScore }),
+		scored("resting_heart_rate", score, func(s *whoop.RecoveryScore) any { return s.RestingHeartRate }),
+		scored("hrv_rmssd_milli", score, func(s *whoop.RecoveryScore) any { return s.HRVRmssdMilli }),
+		scored("spo2_percentage", score, func(s *whoop.RecoveryScore) any { return s.SpO2Percentage }),
+		scored("skin_temp_celsius", score, func(s *whoop.RecoveryScore) any { return s.SkinTempCelsius }),
+		{"created_at", func(r whoop.Recovery) any { return timestamp(r.CreatedAt) }},
+		{"updated_at", func(r whoop.Recovery) any { return timestamp(r.UpdatedAt) }},
+	}
+}()
+
+var sleepColumns = func() []column[whoop.Sleep] {
+	score := func(s whoop.Sleep) *whoop.SleepScore { return s.Score }
+	return []column[whoop.Sleep]{
+		{"id", func(s whoop.Sleep) any { return s.ID }},
+		{"cycle_id", func(s whoop.Sleep) any { return s.CycleID }},
+		{"user_id", func(s whoop.Sleep) any { return s.UserID }},
+		{"start", func(s whoop.Sleep) any { return timestamp(s.Start) }},
+		{"end", func(s whoop.Sleep) any { return timestamp(s.End) }},
+		{"timezone_offset", func(s whoop.Sleep) any { return s.TimezoneOffset }},
+		{"nap", func(s whoop.Sleep) any { return s.Nap }},
+		{"score_state", func(s whoop.Sleep) any { return string(s.ScoreState) }},
+		scored("total_in_bed_time_milli", score, func(s *whoop.SleepScore) any { return s.StageSummary.TotalInBedTimeMilli }),
+		scored("total_awake_time_milli", score, func(s *whoop.SleepScore) any { return s.StageSummary.TotalAwakeTimeMilli }),
+		scored("total_no_data_time_milli", score, func(s *whoop.SleepScore) any { return s.StageSummary.TotalNoDataTimeMilli }),
+		scored("total_light_sleep_time_milli", score, func(s *whoop.SleepScore) any { return s.StageSummary.TotalLightSleepTimeMilli }),
+		scored("total_slow_wave_sleep_time_milli", score, func(s *whoop.SleepScore) any { return s.StageSummary.TotalSlowWaveSleepTimeMilli }),
+		scored("total_rem_sleep_time_milli", score, func(s *whoop.SleepScore) any { return s.StageSummary.TotalREMSleepTimeMilli }),
+		scored("sleep_cycle_count", score, func(s *whoop.SleepScore) any { return s.StageSummary.SleepCycleCount }),
+		scored("disturbance_count", score, func(s *whoop.SleepScore) any { return s.StageSummary.DisturbanceCount }),
+		scored("baseline_milli", score, func(s *whoop.SleepScore) any { return s.SleepNeeded.BaselineMilli }),
+		scored("need_from_sleep_debt_milli", score, func(s *whoop.SleepScore) any { return s.SleepNeeded.NeedFromSleepDebtMilli }),
+		scored("need_from_recent_strain_milli", score, func(s *whoop.SleepScore) any { return s.SleepNeeded.NeedFromRecentStrainMilli }),
+		scored("need_from_recent_nap_milli", score, func(s *whoop.SleepScore) any { return s.SleepNeeded.NeedFromRecentNapMilli }),
+		scored("respiratory_rate", score, func(s *whoop.SleepScore) any { return s.RespiratoryRate }),
+		scored("sleep_performance_percentage", score, func(s *whoop.SleepScore) any { return s.SleepPerformancePercentage }),
+		scored("sleep_consistency_percentage", score, func(s *whoop.SleepScore) any { return s.SleepConsistencyPercentage }),
+		scored("sleep_efficiency_percentage", score, func(s *whoop.SleepScore) any { return s.SleepEfficiencyPercentage }),
+		{"created_at", func(s whoop.Sleep) any { return timestamp(s.CreatedAt) }},
+		{"updated_at", func(s whoop.Sleep) any { return timestamp(s.UpdatedAt) }},
+	}
+}()
+
+var workoutColumns = func() []column[whoop.Workout] {
+	score := func(w whoop.Workout) *whoop.WorkoutScore { return w.Score }
+	return []column[whoop.Workout]{
+		{"id", func(w whoop.Workout) any { return w.ID }},
+		{"user_id", func(w whoop.Workout) any { return w.UserID }},
+		{"start", func(w whoop.Workout) any { return timestamp(w.Start) }},
+		{"end", func(w whoop.Workout) any { return timestamp(w.End) }},
+		{"timezone_offset", func(w whoop.Workout) any { return w.TimezoneOffset }},
+		{"sport_name", func(w whoop.Workout) any { return w.SportName }},
+		{"score_state", func(w whoop.Workout) any { return string(w.ScoreState) }},
+		scored("strain", score, func(s *whoop.WorkoutScore) any { return s.Strain }),
+		scored("average_heart_rate", score, func(s *whoop.WorkoutScore) any { return s.AverageHeartRate }),
+		scored("max_heart_rate", score, func(s *whoop.WorkoutScore) any { return s.MaxHeartRate }),
+		scored("kilojoule", score, func(s *whoop.WorkoutScore) any { return s.Kilojoule }),
+		scored("percent_recorded", score, func(s *whoop.WorkoutScore) any { return s.PercentRecorded }),
+		scored("distance_meter", score, func(s *whoop.WorkoutScore) any { return optional(s.DistanceMeter) }),
+		scored("altitude_gain_meter", score, func(s *whoop.WorkoutScore) any { return optional(s.AltitudeGainMeter) }),
+		scored("altitude_change_meter", score, func(s *whoop.WorkoutScore) any { return optional(s.AltitudeChangeMeter) }),
+		scored("zone_zero_milli", score, func(s *whoop.WorkoutScore) any { return s.ZoneDurations.ZoneZeroMilli }),
+		scored("zone_one_milli", score, func(s *whoop.WorkoutScore) any { return s.ZoneDurations.ZoneOneMilli }),
+		scored("zone_two_milli", score, func(s *whoop.WorkoutScore) any { return s.ZoneDurations.ZoneTwoMilli }),
+		scored("zone_three_milli", score, func(s *whoop.WorkoutScore) any { return s.ZoneDurations.ZoneThreeMilli }),
+		scored("zone_four_milli", score, func(s *whoop.WorkoutScore) any { return s.ZoneDurations.ZoneFourMilli }),
+		scored("zone_five_milli", score, func(s *whoop.WorkoutScore) any { return s.ZoneDurations.ZoneFiveMilli }),
+		{"created_at", func(w whoop.Workout) any { return timestamp(w.CreatedAt) }},
+		{"updated_at", func(w whoop.Workout) any { return timestamp(w.UpdatedAt) }},
+	}
+}()
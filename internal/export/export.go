@@ -0,0 +1,145 @@
+// Package export flattens cached WHOOP data into tabular formats.
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+type Entity string
+
+const (
+	EntityCycles     Entity = "cycles"
+	EntityRecoveries Entity = "recoveries"
+	EntitySleeps     Entity = "sleeps"
+	EntityWorkouts   Entity = "workouts"
+	EntityAll        Entity = "all"
+)
+
+// Entities lists every exportable entity, in export order.
+var Entities = []Entity{EntityCycles, EntityRecoveries, EntitySleeps, EntityWorkouts}
+
+func ParseEntity(s string) (Entity, error) {
+	switch e := Entity(s); e {
+	case EntityCycles, EntityRecoveries, EntitySleeps, EntityWorkouts, EntityAll:
+		return e, nil
+	default:
+		return "", fmt.Errorf("unknown entity %q: must be one of cycles, recoveries, sleeps, workouts, all", s)
+	}
+}
+
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatCSV, FormatJSONL:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown format %q: must be one of csv, jsonl", s)
+	}
+}
+
+// Extension returns the file extension for the format, without the dot.
+func (f Format) Extension() string {
+	return string(f)
+}
+
+type Exporter struct {
+	repo *repository.Repository
+}
+
+func New(repo *repository.Repository) *Exporter {
+	return &Exporter{repo: repo}
+}
+
+// Export writes every record of entity that starts within [start, end] to w.
+// Recoveries have no start of their own, so they follow their cycle's start.
+func (e *Exporter) Export(ctx context.Context, w io.Writer, entity Entity, format Format, start, end time.Time) error {
+	switch entity {
+	case EntityCycles:
+		cycles, err := e.cycles(ctx, start, end)
+		if err != nil {
+			return err
+		}
+		return write(w, format, cycleColumns, cycles)
+	case EntityRecoveries:
+		cycles, err := e.cycles(ctx, start, end)
+		if err != nil {
+			return err
+		}
+		recoveries, err := e.repo.Recoveries.GetByCycleIDs(ctx, cycleIDs(cycles))
+		if err != nil {
+			return fmt.Errorf("failed to get recoveries: %w", err)
+		}
+		return write(w, format, recoveryColumns, inCycleOrder(cycles, recoveries))
+	case EntitySleeps:
+		sleeps, err := repository.Collect(ctx, func(ctx context.Context, cursor *repository.CursorParams) (*repository.CursorResult[whoop.Sleep], error) {
+			return e.repo.Sleeps.GetByDateRange(ctx, start, end, cursor)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get sleeps: %w", err)
+		}
+		slices.Reverse(sleeps)
+		return write(w, format, sleepColumns, sleeps)
+	case EntityWorkouts:
+		workouts, err := repository.Collect(ctx, func(ctx context.Context, cursor *repository.CursorParams) (*repository.CursorResult[whoop.Workout], error) {
+			return e.repo.Workouts.GetByDateRange(ctx, start, end, cursor)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get workouts: %w", err)
+		}
+		slices.Reverse(workouts)
+		return write(w, format, workoutColumns, workouts)
+	case EntityAll:
+		return fmt.Errorf("entity %q must be exported one entity at a time", entity)
+	default:
+		return fmt.Errorf("unknown entity %q", entity)
+	}
+}
+
+func (e *Exporter) cycles(ctx context.Context, start, end time.Time) ([]whoop.Cycle, error) {
+	cycles, err := repository.Collect(ctx, func(ctx context.Context, cursor *repository.CursorParams) (*repository.CursorResult[whoop.Cycle], error) {
+		return e.repo.Cycles.GetByDateRange(ctx, start, end, cursor)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cycles: %w", err)
+	}
+	// the repository pages newest first, exports read oldest first
+	slices.Reverse(cycles)
+	return cycles, nil
+}
+
+// inCycleOrder orders recoveries to match cycles, dropping cycles without one.
+func inCycleOrder(cycles []whoop.Cycle, recoveries []whoop.Recovery) []whoop.Recovery {
+	byCycle := make(map[int64]whoop.Recovery, len(recoveries))
+	for _, r := range recoveries {
+		byCycle[r.CycleID] = r
+	}
+
+	ordered := make([]whoop.Recovery, 0, len(recoveries))
+	for _, c := range cycles {
+		if r, ok := byCycle[c.ID]; ok {
+			ordered = append(ordered, r)
+		}
+	}
+	return ordered
+}
+
+func cycleIDs(cycles []whoop.Cycle) []int64 {
+	ids := make([]int64, len(cycles))
+	for i, c := range cycles {
+		ids[i] = c.ID
+	}
+	return ids
+}
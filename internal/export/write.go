@@ -0,0 +1,94 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	go_json "github.com/goccy/go-json"
+)
+
+func write[T any](w io.Writer, format Format, columns []column[T], records []T) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, columns, records)
+	case FormatJSONL:
+		return writeJSONL(w, columns, records)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// writeCSV writes a header row followed by one row per record. Absent values
+// are written as empty cells.
+func writeCSV[T any](w io.Writer, columns []column[T], records []T) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(headers(columns)); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	row := make([]string, len(columns))
+	for _, record := range records {
+		for i, c := range columns {
+			row[i] = formatCell(c.value(record))
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to flush csv: %w", err)
+	}
+	return nil
+}
+
+// writeJSONL writes one JSON object per record with keys in column order,
+// so the output is stable across runs. Absent values are written as null.
+func writeJSONL[T any](w io.Writer, columns []column[T], records []T) error {
+	var buf bytes.Buffer
+	for _, record := range records {
+		buf.Reset()
+		buf.WriteByte('{')
+		for i, c := range columns {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := go_json.Marshal(c.name)
+			if err != nil {
+				return fmt.Errorf("failed to marshal key: %w", err)
+			}
+			value, err := go_json.Marshal(c.value(record))
+			if err != nil {
+				return fmt.Errorf("failed to marshal %s: %w", c.name, err)
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteString("}\n")
+
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("failed to write record: %w", err)
+		}
+	}
+	return nil
+}
+
+func formatCell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,104 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+)
+
+func testCycles() []whoop.Cycle {
+	start := time.Date(2025, 1, 2, 6, 30, 0, 0, time.UTC)
+	return []whoop.Cycle{
+		{
+			ID:             1,
+			UserID:         42,
+			Start:          start,
+			TimezoneOffset: "-05:00",
+			ScoreState:     whoop.ScoreStateScored,
+			Score: &whoop.CycleScore{
+				Strain:           12.5,
+				Kilojoule:        8000,
+				AverageHeartRate: 70,
+				MaxHeartRate:     160,
+			},
+		},
+		{
+			ID:             2,
+			UserID:         42,
+			Start:          start.Add(24 * time.Hour),
+			TimezoneOffset: "-05:00",
+			ScoreState:     whoop.ScoreStatePendingScore,
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := write(&buf, FormatCSV, cycleColumns, testCycles()); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3", len(lines))
+	}
+
+	wantHeader := "id,user_id,start,end,timezone_offset,score_state,strain,kilojoule,average_heart_rate,max_heart_rate,created_at,updated_at"
+	if lines[0] != wantHeader {
+		t.Errorf("header = %q, want %q", lines[0], wantHeader)
+	}
+	if !strings.HasPrefix(lines[1], "1,42,2025-01-02T06:30:00Z,,-05:00,SCORED,12.5,8000,70,160,") {
+		t.Errorf("scored row = %q", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "2,42,2025-01-03T06:30:00Z,,-05:00,PENDING_SCORE,,,,,") {
+		t.Errorf("unscored row = %q", lines[2])
+	}
+}
+
+func TestWriteJSONL(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := write(&buf, FormatJSONL, cycleColumns, testCycles()); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+
+	want := `{"id":1,"user_id":42,"start":"2025-01-02T06:30:00Z","end":null,"timezone_offset":"-05:00","score_state":"SCORED","strain":12.5,"kilojoule":8000,"average_heart_rate":70,"max_heart_rate":160,`
+	if !strings.HasPrefix(lines[0], want) {
+		t.Errorf("scored record = %q, want prefix %q", lines[0], want)
+	}
+	if !strings.Contains(lines[1], `"strain":null`) {
+		t.Errorf("unscored record = %q, want null strain", lines[1])
+	}
+}
+
+func TestColumnHeaders_Unique(t *testing.T) {
+	t.Parallel()
+
+	tests := map[Entity][]string{
+		EntityCycles:     headers(cycleColumns),
+		EntityRecoveries: headers(recoveryColumns),
+		EntitySleeps:     headers(sleepColumns),
+		EntityWorkouts:   headers(workoutColumns),
+	}
+
+	for entity, names := range tests {
+		seen := make(map[string]bool, len(names))
+		for _, name := range names {
+			if seen[name] {
+				t.Errorf("%s: duplicate column %q", entity, name)
+			}
+			seen[name] = true
+		}
+	}
+}
@@ -0,0 +1,292 @@
+// Package goals tracks the targets the user sets, such as an average sleep
+// performance for the week or a number of hard workouts, against the cache.
+package goals
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+// Comparison is how a value is held against a goal's target.
+type Comparison string
+
+const (
+	AtLeast Comparison = ">="
+	Above   Comparison = ">"
+	AtMost  Comparison = "<="
+	Below   Comparison = "<"
+)
+
+// ParseComparison parses one of >=, >, <=, <, ≥ or ≤.
+func ParseComparison(s string) (Comparison, error) {
+	switch c := Comparison(s); c {
+	case AtLeast, Above, AtMost, Below:
+		return c, nil
+	case "≥":
+		return AtLeast, nil
+	case "≤":
+		return AtMost, nil
+	default:
+		return "", fmt.Errorf("unknown comparison %q: want >=, >, <= or <", s)
+	}
+}
+
+// Holds reports whether v compares to target.
+func (c Comparison) Holds(v, target float64) bool {
+	switch c {
+	case AtLeast:
+		return v >= target
+	case Above:
+		return v > target
+	case AtMost:
+		return v <= target
+	case Below:
+		return v < target
+	default:
+		return false
+	}
+}
+
+// higher reports whether higher values move toward the target.
+func (c Comparison) higher() bool {
+	return c == AtLeast || c == Above
+}
+
+func (c Comparison) String() string {
+	switch c {
+	case AtLeast:
+		return "≥"
+	case AtMost:
+		return "≤"
+	default:
+		return string(c)
+	}
+}
+
+// Period is the stretch of time a goal is tracked over.
+type Period string
+
+const (
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+)
+
+func ParsePeriod(s string) (Period, error) {
+	switch p := Period(s); p {
+	case PeriodWeek, PeriodMonth:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown period %q: want week or month", s)
+	}
+}
+
+// Range returns the calendar period containing now, from the start of the
+// Monday or the first of the month to now.
+func (p Period) Range(now time.Time) (time.Time, time.Time) {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch p {
+	case PeriodMonth:
+		return day.AddDate(0, 0, 1-day.Day()), now
+	default:
+		// weeks start on Monday
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7), now
+	}
+}
+
+// Goal is a target for a metric over a period. An average goal compares the
+// period's average with Value; a counted goal wants at least Count days,
+// nights or workouts that compare with Value on their own.
+type Goal struct {
+	ID         int64
+	Metric     Metric
+	Comparison Comparison
+	Value      float64
+	Count      *int
+	Period     Period
+}
+
+// New parses a goal from its parts as a user would write them.
+func New(metric, comparison, value string, count *int, period string) (Goal, error) {
+	g, err := parse(metric, comparison, count, period)
+	if err != nil {
+		return Goal{}, err
+	}
+	if g.Value, err = g.Metric.ParseValue(value); err != nil {
+		return Goal{}, err
+	}
+	return g, nil
+}
+
+// FromRecord reads a stored goal.
+func FromRecord(r repository.Goal) (Goal, error) {
+	g, err := parse(r.Metric, r.Comparison, r.Count, r.Period)
+	if err != nil {
+		return Goal{}, fmt.Errorf("invalid goal %d: %w", r.ID, err)
+	}
+	g.ID, g.Value = r.ID, r.Value
+	return g, nil
+}
+
+// parse reads every part of a goal but its target.
+func parse(metric, comparison string, count *int, period string) (Goal, error) {
+	m := Metric(metric)
+	if !slices.Contains(Metrics, m) {
+		return Goal{}, fmt.Errorf("unknown metric %q", metric)
+	}
+	c, err := ParseComparison(comparison)
+	if err != nil {
+		return Goal{}, err
+	}
+	if count != nil && *count < 1 {
+		return Goal{}, fmt.Errorf("count must be at least 1, got %d", *count)
+	}
+	p, err := ParsePeriod(period)
+	if err != nil {
+		return Goal{}, err
+	}
+	return Goal{Metric: m, Comparison: c, Count: count, Period: p}, nil
+}
+
+// Record returns g as it is stored.
+func (g Goal) Record() repository.Goal {
+	return repository.Goal{
+		ID:         g.ID,
+		Metric:     string(g.Metric),
+		Comparison: string(g.Comparison),
+		Value:      g.Value,
+		Count:      g.Count,
+		Period:     string(g.Period),
+	}
+}
+
+// String describes the goal, like "average sleep performance ≥ 85% this
+// week" or "3 workouts with strain > 10 this week".
+func (g Goal) String() string {
+	target := fmt.Sprintf("%s %s %s this %s", g.Metric.Label(), g.Comparison, g.Metric.Format(g.Value), g.Period)
+	if g.Count == nil {
+		return "average " + target
+	}
+	return fmt.Sprintf("%d %s with %s", *g.Count, g.Metric.Unit(), target)
+}
+
+// Short labels the goal on a gauge, like "SLEEP≥85%" or "3×WORKOUT>10.0".
+func (g Goal) Short() string {
+	target := g.Metric.Short() + g.Comparison.String() + g.Metric.Format(g.Value)
+	if g.Count == nil {
+		return target
+	}
+	return strconv.Itoa(*g.Count) + "×" + target
+}
+
+// Progress is how far a goal has come this period.
+type Progress struct {
+	Goal Goal
+	// Current is the average so far for an average goal and the number of
+	// measurements meeting the target for a counted one. Samples is how many
+	// measurements there were.
+	Current float64
+	Samples int
+	Met     bool
+	// Fraction runs from 0 to 1 as the goal nears being met.
+	Fraction float64
+}
+
+// Evaluate measures g against d, which should cover g's period.
+func Evaluate(g Goal, d Data) Progress {
+	values := g.Metric.values(d)
+	p := Progress{Goal: g, Samples: len(values)}
+
+	if g.Count != nil {
+		for _, v := range values {
+			if g.Comparison.Holds(v, g.Value) {
+				p.Current++
+			}
+		}
+		p.Met = int(p.Current) >= *g.Count
+		p.Fraction = min(p.Current/float64(*g.Count), 1)
+		return p
+	}
+
+	if len(values) == 0 {
+		return p
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	p.Current = sum / float64(len(values))
+	p.Met = g.Comparison.Holds(p.Current, g.Value)
+
+	switch {
+	case p.Met:
+		p.Fraction = 1
+	case g.Comparison.higher() && g.Value > 0:
+		p.Fraction = max(p.Current/g.Value, 0)
+	case !g.Comparison.higher() && p.Current > 0:
+		p.Fraction = min(g.Value/p.Current, 1)
+	default:
+	}
+	return p
+}
+
+// Describe reports progress in words, like "average 82% over 5 nights" or
+// "2 of 3 workouts".
+func (p Progress) Describe() string {
+	g := p.Goal
+	if g.Count != nil {
+		return fmt.Sprintf("%d of %d %s", int(p.Current), *g.Count, g.Metric.Unit())
+	}
+	if p.Samples == 0 {
+		return "no " + g.Metric.Unit() + " yet"
+	}
+	return fmt.Sprintf("average %s over %d %s", g.Metric.Format(p.Current), p.Samples, g.Metric.Unit())
+}
+
+// Data is the cached data a goal is measured against.
+type Data = repository.Records
+
+// Load reads the cached cycles, sleeps and workouts starting within
+// [start, end], and the cycles' recoveries.
+func Load(ctx context.Context, repo *repository.Repository, start, end time.Time) (Data, error) {
+	records, err := repository.LoadRecords(ctx, repo, start, end)
+	if err != nil {
+		return Data{}, err
+	}
+	// sleeps count toward the period they start in, not their cycle's
+	records.Sleeps = slices.DeleteFunc(records.Sleeps, func(s whoop.Sleep) bool { return s.Start.Before(start) })
+	return *records, nil
+}
+
+// Track reads every stored goal and evaluates it over its period up to now.
+func Track(ctx context.Context, repo *repository.Repository, now time.Time) ([]Progress, error) {
+	records, err := repo.Goals.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list goals: %w", err)
+	}
+
+	data := make(map[Period]Data)
+	progress := make([]Progress, 0, len(records))
+	for _, r := range records {
+		g, err := FromRecord(r)
+		if err != nil {
+			return nil, err
+		}
+
+		d, ok := data[g.Period]
+		if !ok {
+			start, end := g.Period.Range(now)
+			if d, err = Load(ctx, repo, start, end); err != nil {
+				return nil, err
+			}
+			data[g.Period] = d
+		}
+		progress = append(progress, Evaluate(g, d))
+	}
+	return progress, nil
+}
@@ -0,0 +1,183 @@
+package goals
+
+import (
+	"testing"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+func count(n int) *int { return &n }
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		metric, comparison, value string
+		count                     *int
+		want                      string
+		short                     string
+	}{
+		{"sleep_performance", ">=", "85", nil, "average sleep performance ≥ 85% this week", "SLEEP≥85%"},
+		{"workout_strain", ">", "10", count(3), "3 workouts with strain > 10.0 this week", "3×WORKOUT>10.0"},
+		{"bedtime", "<", "23:30", count(5), "5 nights with bedtime < 11:30pm this week", "5×BED<11:30pm"},
+	}
+
+	for _, tt := range tests {
+		g, err := New(tt.metric, tt.comparison, tt.value, tt.count, "week")
+		if err != nil {
+			t.Fatalf("New(%s) error = %v", tt.metric, err)
+		}
+		if got := g.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+		if got := g.Short(); got != tt.short {
+			t.Errorf("Short() = %q, want %q", got, tt.short)
+		}
+
+		back, err := FromRecord(g.Record())
+		if err != nil || back != g {
+			t.Errorf("FromRecord(Record()) = %+v, %v, want %+v", back, err, g)
+		}
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                              string
+		metric, comparison, value, period string
+		count                             *int
+	}{
+		{"metric", "steps", ">=", "1", "week", nil},
+		{"comparison", "hrv", "==", "1", "week", nil},
+		{"value", "hrv", ">=", "lots", "week", nil},
+		{"clock", "bedtime", "<", "11pm", "week", nil},
+		{"count", "hrv", ">=", "60", "week", count(0)},
+		{"period", "hrv", ">=", "60", "year", nil},
+	}
+
+	for _, tt := range tests {
+		if _, err := New(tt.metric, tt.comparison, tt.value, tt.count, tt.period); err == nil {
+			t.Errorf("New() with a bad %s didn't fail", tt.name)
+		}
+	}
+	if _, err := FromRecord(repository.Goal{Metric: "steps", Comparison: ">=", Period: "week"}); err == nil {
+		t.Error("FromRecord() with a bad metric didn't fail")
+	}
+}
+
+func TestPeriodRange(t *testing.T) {
+	t.Parallel()
+
+	// a Sunday
+	now := time.Date(2025, 3, 16, 20, 0, 0, 0, time.UTC)
+
+	if start, end := PeriodWeek.Range(now); !start.Equal(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)) || !end.Equal(now) {
+		t.Errorf("week Range() = %v, %v, want from Monday Mar 10", start, end)
+	}
+	if start, _ := PeriodMonth.Range(now); !start.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("month Range() = %v, want from Mar 1", start)
+	}
+}
+
+// nightAt returns a scored main sleep from bed at the given UTC-5 wall time
+// on Mar 10 + day.
+func nightAt(day, hour, minute int, performance float64) whoop.Sleep {
+	loc := whoop.Location("-05:00")
+	start := time.Date(2025, 3, 10+day, hour, minute, 0, 0, loc)
+	return whoop.Sleep{
+		Start:          start,
+		End:            start.Add(8 * time.Hour),
+		TimezoneOffset: "-05:00",
+		Score:          &whoop.SleepScore{SleepPerformancePercentage: performance},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	d := Data{
+		Sleeps: []whoop.Sleep{
+			nightAt(0, 23, 0, 90),
+			nightAt(1, 23, 45, 70),
+			nightAt(2, 22, 30, 80),
+			{Nap: true, Start: time.Date(2025, 3, 12, 14, 0, 0, 0, time.UTC), Score: &whoop.SleepScore{SleepPerformancePercentage: 100}},
+		},
+		Workouts: []whoop.Workout{
+			{Score: &whoop.WorkoutScore{Strain: 12}},
+			{Score: &whoop.WorkoutScore{Strain: 8}},
+			{},
+		},
+	}
+
+	tests := []struct {
+		metric, comparison, value string
+		count                     *int
+		current, fraction         float64
+		met                       bool
+		describe                  string
+	}{
+		{"sleep_performance", ">=", "85", nil, 80, 80.0 / 85, false, "average 80% over 3 nights"},
+		{"sleep_performance", ">=", "75", nil, 80, 1, true, "average 80% over 3 nights"},
+		{"bedtime", "<", "23:30", count(5), 2, 0.4, false, "2 of 5 nights"},
+		{"workout_strain", ">", "10", count(1), 1, 1, true, "1 of 1 workouts"},
+		{"hrv", ">=", "60", nil, 0, 0, false, "no days yet"},
+	}
+
+	for _, tt := range tests {
+		g, err := New(tt.metric, tt.comparison, tt.value, tt.count, "week")
+		if err != nil {
+			t.Fatalf("New(%s) error = %v", tt.metric, err)
+		}
+		p := Evaluate(g, d)
+		if p.Current != tt.current || p.Met != tt.met || p.Fraction != tt.fraction {
+			t.Errorf("Evaluate(%s) = %v, %v, %v, want %v, %v, %v",
+				g, p.Current, p.Met, p.Fraction, tt.current, tt.met, tt.fraction)
+		}
+		if got := p.Describe(); got != tt.describe {
+			t.Errorf("Describe() = %q, want %q", got, tt.describe)
+		}
+	}
+}
+
+func TestEvaluate_WakeAfterNoon(t *testing.T) {
+	t.Parallel()
+
+	// nights waking at 7am, 12:15pm and 1pm
+	d := Data{
+		Sleeps: []whoop.Sleep{
+			nightAt(0, 23, 0, 90),
+			nightAt(1, 4, 15, 80),
+			nightAt(2, 5, 0, 70),
+		},
+	}
+
+	tests := []struct {
+		comparison, value string
+		count             *int
+		current           float64
+		met               bool
+		describe          string
+	}{
+		{"<=", "07:00", count(3), 1, false, "1 of 3 nights"},
+		{">", "12:00", count(2), 2, true, "2 of 2 nights"},
+		{"<=", "09:00", nil, 645, false, "average 10:45am over 3 nights"},
+	}
+
+	for _, tt := range tests {
+		g, err := New("wake", tt.comparison, tt.value, tt.count, "week")
+		if err != nil {
+			t.Fatalf("New(wake %s %s) error = %v", tt.comparison, tt.value, err)
+		}
+		p := Evaluate(g, d)
+		if p.Current != tt.current || p.Met != tt.met {
+			t.Errorf("Evaluate(%s) = %v, %v, want %v, %v", g, p.Current, p.Met, tt.current, tt.met)
+		}
+		if got := p.Describe(); got != tt.describe {
+			t.Errorf("Describe(%s) = %q, want %q", g, got, tt.describe)
+		}
+	}
+}
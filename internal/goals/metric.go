@@ -0,0 +1,204 @@
+package goals
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/insights"
+	"github.com/garrettladley/thoop/internal/xtime"
+)
+
+// Metric is what a goal measures.
+type Metric string
+
+const (
+	MetricRecovery         Metric = "recovery"
+	MetricHRV              Metric = "hrv"
+	MetricRHR              Metric = "rhr"
+	MetricStrain           Metric = "strain"
+	MetricSleepPerformance Metric = "sleep_performance"
+	MetricSleepHours       Metric = "sleep_hours"
+	MetricBedtime          Metric = "bedtime"
+	MetricWake             Metric = "wake"
+	MetricWorkoutStrain    Metric = "workout_strain"
+)
+
+// Metrics lists every metric.
+var Metrics = []Metric{
+	MetricRecovery,
+	MetricHRV,
+	MetricRHR,
+	MetricStrain,
+	MetricSleepPerformance,
+	MetricSleepHours,
+	MetricBedtime,
+	MetricWake,
+	MetricWorkoutStrain,
+}
+
+// Label names the metric in a sentence.
+func (m Metric) Label() string {
+	switch m {
+	case MetricRecovery:
+		return "recovery"
+	case MetricHRV:
+		return "HRV"
+	case MetricRHR:
+		return "resting heart rate"
+	case MetricStrain:
+		return "day strain"
+	case MetricSleepPerformance:
+		return "sleep performance"
+	case MetricSleepHours:
+		return "time asleep"
+	case MetricBedtime:
+		return "bedtime"
+	case MetricWake:
+		return "wake time"
+	case MetricWorkoutStrain:
+		return "strain"
+	default:
+		return string(m)
+	}
+}
+
+// Short names the metric on a gauge.
+func (m Metric) Short() string {
+	switch m {
+	case MetricSleepPerformance:
+		return "SLEEP"
+	case MetricSleepHours:
+		return "ASLEEP"
+	case MetricBedtime:
+		return "BED"
+	case MetricWorkoutStrain:
+		return "WORKOUT"
+	default:
+		return strings.ToUpper(m.Label())
+	}
+}
+
+// Unit is what the metric is measured once per, pluralized.
+func (m Metric) Unit() string {
+	switch m {
+	case MetricSleepPerformance, MetricSleepHours, MetricBedtime, MetricWake:
+		return "nights"
+	case MetricWorkoutStrain:
+		return "workouts"
+	default:
+		return "days"
+	}
+}
+
+// clock reports whether the metric is a time of day, stored as minutes after
+// its anchor.
+func (m Metric) clock() bool {
+	return m == MetricBedtime || m == MetricWake
+}
+
+// anchor is where a clock metric's day starts, so that earlier and later
+// compare as they read: noon for bedtimes around midnight, and midnight for
+// wake times that can run past noon.
+func (m Metric) anchor() insights.Clock {
+	if m == MetricWake {
+		return insights.Midnight
+	}
+	return insights.Noon
+}
+
+// ParseValue parses a target for the metric: a time of day as HH:MM for
+// bedtime and wake, a number otherwise.
+func (m Metric) ParseValue(s string) (float64, error) {
+	if m.clock() {
+		c, err := insights.ParseClock(s)
+		if err != nil {
+			return 0, err
+		}
+		return float64(c.Since(m.anchor())), nil
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s target %q: %w", m, s, err)
+	}
+	return v, nil
+}
+
+// Format renders a value of the metric with its unit.
+func (m Metric) Format(v float64) string {
+	switch m {
+	case MetricRecovery, MetricSleepPerformance:
+		return fmt.Sprintf("%.0f%%", v)
+	case MetricHRV:
+		return fmt.Sprintf("%.0fms", v)
+	case MetricRHR:
+		return fmt.Sprintf("%.0f bpm", v)
+	case MetricSleepHours:
+		return fmt.Sprintf("%.1fh", v)
+	case MetricBedtime, MetricWake:
+		return m.anchor().Add(int(math.Round(v))).String()
+	default:
+		return fmt.Sprintf("%.1f", v)
+	}
+}
+
+// values returns every measurement of the metric in d.
+func (m Metric) values(d Data) []float64 {
+	var values []float64
+	switch m {
+	case MetricRecovery, MetricHRV, MetricRHR:
+		for _, r := range d.Recoveries {
+			if r.Score == nil {
+				continue
+			}
+			switch m {
+			case MetricHRV:
+				values = append(values, r.Score.HRVRmssdMilli)
+			case MetricRHR:
+				values = append(values, r.Score.RestingHeartRate)
+			default:
+				values = append(values, r.Score.RecoveryScore)
+			}
+		}
+	case MetricStrain:
+		for _, c := range d.Cycles {
+			if c.Score != nil {
+				values = append(values, c.Score.Strain)
+			}
+		}
+	case MetricSleepPerformance, MetricSleepHours:
+		for _, s := range d.Sleeps {
+			if s.Nap || s.Score == nil {
+				continue
+			}
+			if m == MetricSleepHours {
+				values = append(values, xtime.Millis(s.Score.StageSummary.TotalSleepTimeMilli()).Hours())
+			} else {
+				values = append(values, s.Score.SleepPerformancePercentage)
+			}
+		}
+	case MetricBedtime, MetricWake:
+		for _, s := range d.Sleeps {
+			if s.Nap {
+				continue
+			}
+			at := s.Start
+			if m == MetricWake {
+				at = s.End
+			}
+			c := insights.ClockOf(at.In(whoop.Location(s.TimezoneOffset)))
+			values = append(values, float64(c.Since(m.anchor())))
+		}
+	case MetricWorkoutStrain:
+		for _, w := range d.Workouts {
+			if w.Score != nil {
+				values = append(values, w.Score.Strain)
+			}
+		}
+	default:
+	}
+	return values
+}
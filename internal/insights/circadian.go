@@ -0,0 +1,350 @@
+package insights
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+// Clock is a local time of day as minutes after noon, so the hours around
+// midnight that bedtimes fall in sort and average without wrapping.
+type Clock int
+
+const minutesPerDay = 24 * 60
+
+// Noon and Midnight are where a day of clocks is cut to sort and average
+// them: noon for bedtimes, which straddle midnight, and midnight for wake
+// times, which late sleepers push past noon.
+const (
+	Noon     Clock = 0
+	Midnight Clock = minutesPerDay / 2
+)
+
+// ClockOf returns the time of day of t in its own location.
+func ClockOf(t time.Time) Clock {
+	return Clock((t.Hour()*60 + t.Minute() + minutesPerDay/2) % minutesPerDay)
+}
+
+// ParseClock parses a 24-hour time of day like "07:30".
+func ParseClock(s string) (Clock, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse time of day %q: %w", s, err)
+	}
+	return ClockOf(t), nil
+}
+
+// Since returns how many minutes after anchor the clock reads c, less than a day.
+func (c Clock) Since(anchor Clock) int {
+	return ((int(c)-int(anchor))%minutesPerDay + minutesPerDay) % minutesPerDay
+}
+
+// Add returns the clock minutes after c, wrapping around the day.
+func (c Clock) Add(minutes int) Clock {
+	return Clock(((int(c)+minutes)%minutesPerDay + minutesPerDay) % minutesPerDay)
+}
+
+// Hour returns the hour of the day, 0 through 23, that c falls in.
+func (c Clock) Hour() int {
+	return (int(c)/60 + 12) % 24
+}
+
+func (c Clock) String() string {
+	return c.Format("3:04pm")
+}
+
+// Format renders c with a time.Format layout.
+func (c Clock) Format(layout string) string {
+	return c.On(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).Format(layout)
+}
+
+// On returns the first time after noon on day's date, in day's location,
+// that the clock reads c.
+func (c Clock) On(day time.Time) time.Time {
+	noon := time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, day.Location())
+	return noon.Add(time.Duration(c) * time.Minute)
+}
+
+// Night is a main sleep in the local time of wherever it was slept, so a
+// night away reads by the clock on the wall there.
+type Night struct {
+	Start time.Time
+	End   time.Time
+	// Need is the sleep WHOOP asked for going into the night. Efficiency is
+	// the percentage of time in bed spent asleep. Both are zero while unscored.
+	Need       time.Duration
+	Efficiency float64
+}
+
+// Midpoint returns the local time halfway through the night.
+func (n Night) Midpoint() time.Time {
+	return n.Start.Add(n.End.Sub(n.Start) / 2)
+}
+
+// Free reports whether the night is followed by a weekend day, which is
+// taken to be free of an alarm.
+func (n Night) Free() bool {
+	switch n.End.Weekday() {
+	case time.Saturday, time.Sunday:
+		return true
+	default:
+		return false
+	}
+}
+
+// Nights returns the main sleeps, oldest first, each in its own timezone.
+func Nights(sleeps []whoop.Sleep) []Night {
+	nights := make([]Night, 0, len(sleeps))
+	for _, s := range sleeps {
+		if s.Nap {
+			continue
+		}
+
+		loc := whoop.Location(s.TimezoneOffset)
+		n := Night{Start: s.Start.In(loc), End: s.End.In(loc)}
+		if s.Score != nil {
+			need := s.Score.SleepNeeded
+			n.Need = time.Duration(need.BaselineMilli+need.NeedFromSleepDebtMilli+need.NeedFromRecentStrainMilli+need.NeedFromRecentNapMilli) * time.Millisecond
+			n.Efficiency = s.Score.SleepEfficiencyPercentage
+		}
+		nights = append(nights, n)
+	}
+
+	slices.SortFunc(nights, func(a, b Night) int { return a.Start.Compare(b.Start) })
+	return nights
+}
+
+// LoadNights reads the cached main sleeps starting within [start, end].
+func LoadNights(ctx context.Context, repo *repository.Repository, start, end time.Time) ([]Night, error) {
+	sleeps, err := repository.Collect(ctx, func(ctx context.Context, cursor *repository.CursorParams) (*repository.CursorResult[whoop.Sleep], error) {
+		return repo.Sleeps.GetByDateRange(ctx, start, end, cursor)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sleeps: %w", err)
+	}
+	return Nights(sleeps), nil
+}
+
+// Distribution summarizes when in the day something happens.
+type Distribution struct {
+	Nights   int
+	Mean     Clock
+	StdDev   time.Duration
+	Earliest Clock
+	Median   Clock
+	Latest   Clock
+	// Hours counts the nights by the hour of the day, midnight first.
+	Hours [24]int
+}
+
+// distribution summarizes clocks with the day cut at anchor, so the earliest
+// clock is the first one after it.
+func distribution(clocks []Clock, anchor Clock) Distribution {
+	var d Distribution
+	if len(clocks) == 0 {
+		return d
+	}
+
+	offsets := make([]int, len(clocks))
+	for i, c := range clocks {
+		offsets[i] = c.Since(anchor)
+		d.Hours[c.Hour()]++
+	}
+	slices.Sort(offsets)
+	d.Nights = len(offsets)
+	d.Earliest = anchor.Add(offsets[0])
+	d.Median = anchor.Add(offsets[len(offsets)/2])
+	d.Latest = anchor.Add(offsets[len(offsets)-1])
+
+	var sum float64
+	for _, o := range offsets {
+		sum += float64(o)
+	}
+	mean := sum / float64(len(offsets))
+	d.Mean = anchor.Add(int(math.Round(mean)))
+
+	if len(offsets) > 1 {
+		var ss float64
+		for _, o := range offsets {
+			ss += (float64(o) - mean) * (float64(o) - mean)
+		}
+		d.StdDev = time.Duration(math.Sqrt(ss/float64(len(offsets)-1)) * float64(time.Minute)).Round(time.Minute)
+	}
+	return d
+}
+
+// minNights is the fewest nights of a kind the weekday and weekend
+// comparisons are made with.
+const minNights = 2
+
+// SocialJetlag compares the middle of the night before workdays with the
+// nights before weekends. Shift is positive when weekend nights run later.
+type SocialJetlag struct {
+	Workday Distribution
+	FreeDay Distribution
+	Shift   time.Duration
+}
+
+// Chronotype is the mid-sleep on free days, corrected for the sleep debt
+// paid back on them (the MSFsc of the Munich ChronoType Questionnaire).
+type Chronotype struct {
+	MidSleep Clock
+	Type     string
+}
+
+// Chronotype types by corrected free-day mid-sleep.
+const (
+	ChronotypeEarly        = "early"
+	ChronotypeIntermediate = "intermediate"
+	ChronotypeLate         = "late"
+)
+
+// Recommendation is when to go to bed to get the sleep needed before a wake time.
+type Recommendation struct {
+	Wake time.Time
+	// Need is the sleep needed; InBed allows for the time usually spent
+	// awake in bed.
+	Need    time.Duration
+	InBed   time.Duration
+	Bedtime time.Time
+}
+
+// Circadian describes when the user sleeps.
+type Circadian struct {
+	Bedtime        Distribution
+	Wake           Distribution
+	Midpoint       Distribution
+	SocialJetlag   *SocialJetlag
+	Chronotype     *Chronotype
+	Recommendation *Recommendation
+}
+
+// Analyze describes the nights and recommends a bedtime for the next time
+// the clock reads wake after now. Without a wake time, the median wake time
+// before workdays is used. Every section that lacks the nights it needs is
+// left nil.
+func Analyze(nights []Night, wake *Clock, now time.Time) Circadian {
+	var (
+		bedtimes, wakes, midpoints []Clock
+		work, free                 []Night
+	)
+	for _, n := range nights {
+		bedtimes = append(bedtimes, ClockOf(n.Start))
+		wakes = append(wakes, ClockOf(n.End))
+		midpoints = append(midpoints, ClockOf(n.Midpoint()))
+		if n.Free() {
+			free = append(free, n)
+		} else {
+			work = append(work, n)
+		}
+	}
+
+	c := Circadian{
+		Bedtime:  distribution(bedtimes, Noon),
+		Wake:     distribution(wakes, Midnight),
+		Midpoint: distribution(midpoints, Noon),
+	}
+
+	if len(work) >= minNights && len(free) >= minNights {
+		jetlag := SocialJetlag{
+			Workday: distribution(clocksOf(work, Night.Midpoint), Noon),
+			FreeDay: distribution(clocksOf(free, Night.Midpoint), Noon),
+		}
+		jetlag.Shift = time.Duration(jetlag.FreeDay.Mean.Since(Noon)-jetlag.Workday.Mean.Since(Noon)) * time.Minute
+		c.SocialJetlag = &jetlag
+		c.Chronotype = chronotype(jetlag.FreeDay.Mean, meanDuration(work), meanDuration(free))
+	}
+
+	if wake == nil && len(work) > 0 {
+		median := distribution(clocksOf(work, func(n Night) time.Time { return n.End }), Midnight).Median
+		wake = &median
+	}
+	if wake != nil {
+		c.Recommendation = recommend(nights, *wake, now)
+	}
+	return c
+}
+
+// chronotype corrects the free-day mid-sleep for oversleeping on free days
+// to pay back the debt from workdays.
+func chronotype(midFree Clock, work, free time.Duration) *Chronotype {
+	mid := midFree
+	if free > work {
+		week := (5*work + 2*free) / 7
+		mid -= Clock(((free - week) / 2).Round(time.Minute) / time.Minute)
+	}
+
+	t := ChronotypeIntermediate
+	switch {
+	case mid < Clock(15*60):
+		t = ChronotypeEarly
+	case mid >= Clock(17*60):
+		t = ChronotypeLate
+	default:
+	}
+	return &Chronotype{MidSleep: mid, Type: t}
+}
+
+// recentNights is how many of the latest nights the usual sleep efficiency
+// is taken from.
+const recentNights = 7
+
+// recommend works back from the next wake time by the latest night's sleep
+// need, padded by the usual sleep efficiency, in the timezone the user last
+// slept in.
+func recommend(nights []Night, wake Clock, now time.Time) *Recommendation {
+	if len(nights) == 0 {
+		return nil
+	}
+	last := nights[len(nights)-1]
+	if last.Need <= 0 {
+		return nil
+	}
+
+	var sum float64
+	var scored int
+	for _, n := range nights[max(len(nights)-recentNights, 0):] {
+		if n.Efficiency > 0 {
+			sum += n.Efficiency
+			scored++
+		}
+	}
+	inBed := last.Need
+	if scored > 0 {
+		inBed = time.Duration(float64(last.Need) / (sum / float64(scored) / 100)).Round(time.Minute)
+	}
+
+	local := now.In(last.Start.Location())
+	at := wake.On(local.AddDate(0, 0, -1))
+	for !at.After(local) {
+		at = at.AddDate(0, 0, 1)
+	}
+
+	return &Recommendation{
+		Wake:    at,
+		Need:    last.Need,
+		InBed:   inBed,
+		Bedtime: at.Add(-inBed),
+	}
+}
+
+func clocksOf(nights []Night, at func(Night) time.Time) []Clock {
+	clocks := make([]Clock, len(nights))
+	for i, n := range nights {
+		clocks[i] = ClockOf(at(n))
+	}
+	return clocks
+}
+
+func meanDuration(nights []Night) time.Duration {
+	var sum time.Duration
+	for _, n := range nights {
+		sum += n.End.Sub(n.Start)
+	}
+	return sum / time.Duration(len(nights))
+}
@@ -0,0 +1,204 @@
+package insights
+
+import (
+	"testing"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+)
+
+func TestClock(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want Clock
+		hour int
+		str  string
+	}{
+		{"12:00", 0, 12, "12:00pm"},
+		{"23:30", 11*60 + 30, 23, "11:30pm"},
+		{"00:15", 12*60 + 15, 0, "12:15am"},
+		{"07:00", 19 * 60, 7, "7:00am"},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseClock(tt.in)
+		if err != nil {
+			t.Fatalf("ParseClock(%q) error = %v", tt.in, err)
+		}
+		if c != tt.want || c.Hour() != tt.hour || c.String() != tt.str {
+			t.Errorf("ParseClock(%q) = %d (hour %d, %s), want %d (hour %d, %s)",
+				tt.in, c, c.Hour(), c, tt.want, tt.hour, tt.str)
+		}
+	}
+}
+
+func TestNights_Timezones(t *testing.T) {
+	t.Parallel()
+
+	// the same 11pm bedtime at home in New York and away in Berlin
+	sleeps := []whoop.Sleep{
+		{Start: time.Date(2025, 3, 5, 22, 0, 0, 0, time.UTC), End: time.Date(2025, 3, 6, 6, 0, 0, 0, time.UTC), TimezoneOffset: "+01:00"},
+		{Start: time.Date(2025, 3, 2, 4, 0, 0, 0, time.UTC), End: time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC), TimezoneOffset: "-05:00"},
+		{Start: time.Date(2025, 3, 2, 18, 0, 0, 0, time.UTC), End: time.Date(2025, 3, 2, 19, 0, 0, 0, time.UTC), TimezoneOffset: "-05:00", Nap: true},
+	}
+
+	nights := Nights(sleeps)
+	if len(nights) != 2 {
+		t.Fatalf("Nights() = %d, want 2 without the nap", len(nights))
+	}
+	for _, n := range nights {
+		if got := ClockOf(n.Start).String(); got != "11:00pm" {
+			t.Errorf("bedtime = %s, want 11:00pm", got)
+		}
+		if got := ClockOf(n.Midpoint()).String(); got != "3:00am" {
+			t.Errorf("midpoint = %s, want 3:00am", got)
+		}
+	}
+}
+
+// night returns a night in UTC starting at bed on the given March 2025 day
+// and lasting hours.
+func night(day, bed int, hours float64) Night {
+	start := time.Date(2025, 3, day, bed, 0, 0, 0, time.UTC)
+	return Night{
+		Start:      start,
+		End:        start.Add(time.Duration(hours * float64(time.Hour))),
+		Need:       8 * time.Hour,
+		Efficiency: 80,
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	t.Parallel()
+
+	// Mar 3 2025 is a Monday: 7 hours at 11pm before workdays, 9 hours
+	// from 1am before the weekend
+	nights := []Night{
+		night(2, 23, 7), // Sun night, wakes Mon
+		night(3, 23, 7),
+		night(4, 23, 7),
+		night(5, 23, 7),
+		night(6, 23, 7),
+		night(8, 1, 9), // Fri night, wakes Sat
+		night(9, 1, 9),
+	}
+	now := time.Date(2025, 3, 9, 15, 0, 0, 0, time.UTC)
+
+	c := Analyze(nights, nil, now)
+
+	if c.Bedtime.Nights != 7 || c.Bedtime.Median.String() != "11:00pm" || c.Bedtime.Latest.String() != "1:00am" {
+		t.Errorf("bedtime = %+v, want 7 nights from a median of 11pm to 1am", c.Bedtime)
+	}
+	if c.Bedtime.Hours[23] != 5 || c.Bedtime.Hours[1] != 2 {
+		t.Errorf("bedtime hours = %v, want 5 at 11pm and 2 at 1am", c.Bedtime.Hours)
+	}
+
+	if c.SocialJetlag == nil {
+		t.Fatal("SocialJetlag is nil")
+	}
+	// workday mid-sleep is 2:30am and free-day 5:30am
+	if c.SocialJetlag.Shift != 3*time.Hour {
+		t.Errorf("Shift = %v, want 3h", c.SocialJetlag.Shift)
+	}
+
+	// free days run 9h against a 7.57h week, so 43 minutes of mid-sleep
+	// are put down to catching up
+	if c.Chronotype == nil || c.Chronotype.MidSleep.String() != "4:47am" || c.Chronotype.Type != ChronotypeIntermediate {
+		t.Errorf("Chronotype = %+v, want intermediate at 4:47am", c.Chronotype)
+	}
+
+	rec := c.Recommendation
+	if rec == nil {
+		t.Fatal("Recommendation is nil")
+	}
+	// the median workday wake of 6am, 10 hours in bed at 80% efficiency
+	wantWake := time.Date(2025, 3, 10, 6, 0, 0, 0, time.UTC)
+	if !rec.Wake.Equal(wantWake) || rec.InBed != 10*time.Hour || !rec.Bedtime.Equal(wantWake.Add(-10*time.Hour)) {
+		t.Errorf("Recommendation = %+v, want bed at 8pm to wake at 6am", rec)
+	}
+}
+
+func TestAnalyze_WakeInLastTimezone(t *testing.T) {
+	t.Parallel()
+
+	n := night(2, 23, 8)
+	loc := time.FixedZone("UTC+09:00", 9*60*60)
+	n.Start, n.End = n.Start.In(loc), n.End.In(loc)
+
+	wake, err := ParseClock("07:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := Analyze([]Night{n}, &wake, n.End)
+
+	if c.SocialJetlag != nil || c.Chronotype != nil {
+		t.Errorf("Analyze() = %+v, want no weekday comparison from one night", c)
+	}
+	want := time.Date(2025, 3, 4, 7, 0, 0, 0, loc)
+	if c.Recommendation == nil || !c.Recommendation.Wake.Equal(want) {
+		t.Errorf("Recommendation = %+v, want waking at %v", c.Recommendation, want)
+	}
+}
+
+func TestAnalyze_WakeAfterNoon(t *testing.T) {
+	t.Parallel()
+
+	// a late sleeper whose wakes straddle noon: 11am, 12:30pm and 1pm
+	// before workdays, and 12:15pm on Saturday
+	nights := []Night{
+		night(3, 4, 7),
+		night(4, 4, 8.5),
+		night(5, 5, 8),
+		night(8, 5, 7.25),
+	}
+	now := time.Date(2025, 3, 9, 15, 0, 0, 0, time.UTC)
+
+	c := Analyze(nights, nil, now)
+
+	w := c.Wake
+	if w.Earliest.String() != "11:00am" || w.Latest.String() != "1:00pm" || w.Median.String() != "12:30pm" {
+		t.Errorf("wake = %s to %s with median %s, want 11:00am to 1:00pm with median 12:30pm",
+			w.Earliest, w.Latest, w.Median)
+	}
+	if w.Mean.String() != "12:11pm" || w.StdDev != 51*time.Minute {
+		t.Errorf("wake = %s ± %v, want 12:11pm ± 51m", w.Mean, w.StdDev)
+	}
+
+	// the median workday wake, not the 1pm wake that reads earliest after noon
+	want := time.Date(2025, 3, 10, 12, 30, 0, 0, time.UTC)
+	if c.Recommendation == nil || !c.Recommendation.Wake.Equal(want) {
+		t.Errorf("Recommendation = %+v, want waking at %v", c.Recommendation, want)
+	}
+}
+
+func TestClockSince(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in     string
+		anchor Clock
+		want   int
+	}{
+		{"23:30", Noon, 11*60 + 30},
+		{"00:15", Noon, 12*60 + 15},
+		{"12:15", Noon, 15},
+		{"12:15", Midnight, 12*60 + 15},
+		{"07:00", Midnight, 7 * 60},
+		{"23:30", Midnight, 23*60 + 30},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseClock(tt.in)
+		if err != nil {
+			t.Fatalf("ParseClock(%q) error = %v", tt.in, err)
+		}
+		if got := c.Since(tt.anchor); got != tt.want {
+			t.Errorf("%s.Since(%s) = %d, want %d", tt.in, tt.anchor, got, tt.want)
+		}
+		if got := tt.anchor.Add(tt.want); got != c {
+			t.Errorf("%s.Add(%d) = %s, want %s", tt.anchor, tt.want, got, c)
+		}
+	}
+}
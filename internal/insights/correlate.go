@@ -0,0 +1,289 @@
+package insights
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+	"github.com/garrettladley/thoop/internal/xtime"
+)
+
+// Predictor is something about a day that may affect a later recovery.
+type Predictor string
+
+const (
+	PredictorStrain       Predictor = "strain"
+	PredictorWorkoutEnd   Predictor = "workout_end"
+	PredictorSleepHours   Predictor = "sleep_hours"
+	PredictorREMHours     Predictor = "rem_hours"
+	PredictorSWSHours     Predictor = "sws_hours"
+	PredictorDisturbances Predictor = "disturbances"
+)
+
+// Predictors lists every predictor, in display order.
+var Predictors = []Predictor{
+	PredictorStrain,
+	PredictorWorkoutEnd,
+	PredictorSleepHours,
+	PredictorREMHours,
+	PredictorSWSHours,
+	PredictorDisturbances,
+}
+
+func (p Predictor) Label() string {
+	switch p {
+	case PredictorStrain:
+		return "Day strain"
+	case PredictorWorkoutEnd:
+		return "Last workout end"
+	case PredictorSleepHours:
+		return "Time asleep"
+	case PredictorREMHours:
+		return "REM sleep"
+	case PredictorSWSHours:
+		return "Deep sleep"
+	case PredictorDisturbances:
+		return "Disturbances"
+	default:
+		return string(p)
+	}
+}
+
+// Lags are the day offsets between a predictor and the recovery it is
+// compared with.
+var Lags = []int{1, 2, 3}
+
+const (
+	// MinPairs is the fewest day pairs a correlation is reported for.
+	MinPairs = 10
+
+	// z95 is the normal quantile for a 95% confidence interval.
+	z95 = 1.959964
+)
+
+// Day is a cycle and the night that followed it, as predictors, along with
+// the cycle's own recovery as the outcome.
+type Day struct {
+	CycleID int64
+	// Date is the local day the cycle started.
+	Date     time.Time
+	Recovery *float64
+	Values   map[Predictor]float64
+}
+
+// Days joins cycles, oldest first, with their recovery, the latest workout
+// ending in them and the sleep that ended them, which WHOOP files under the
+// next cycle.
+func Days(cycles []whoop.Cycle, recoveries []whoop.Recovery, sleeps []whoop.Sleep, workouts []whoop.Workout) []Day {
+	cycles = slices.SortedFunc(slices.Values(cycles), func(a, b whoop.Cycle) int { return a.Start.Compare(b.Start) })
+
+	recoveryByCycle := make(map[int64]*whoop.Recovery, len(recoveries))
+	for i := range recoveries {
+		recoveryByCycle[recoveries[i].CycleID] = &recoveries[i]
+	}
+	sleepByCycle := make(map[int64]*whoop.Sleep, len(sleeps))
+	for i := range sleeps {
+		if !sleeps[i].Nap {
+			sleepByCycle[sleeps[i].CycleID] = &sleeps[i]
+		}
+	}
+
+	days := make([]Day, len(cycles))
+	for i, c := range cycles {
+		start := c.Start.In(whoop.Location(c.TimezoneOffset))
+		d := Day{
+			CycleID: c.ID,
+			Date:    time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC),
+			Values:  make(map[Predictor]float64, len(Predictors)),
+		}
+		if c.Score != nil {
+			d.Values[PredictorStrain] = c.Score.Strain
+		}
+		if r := recoveryByCycle[c.ID]; r != nil && r.Score != nil && !r.Score.UserCalibrating {
+			d.Recovery = &r.Score.RecoveryScore
+		}
+		if end, ok := lastWorkoutEnd(c, workouts); ok {
+			d.Values[PredictorWorkoutEnd] = end
+		}
+		if i+1 < len(cycles) {
+			if s := sleepByCycle[cycles[i+1].ID]; s != nil && s.Score != nil {
+				st := s.Score.StageSummary
+				d.Values[PredictorSleepHours] = xtime.Millis(st.TotalSleepTimeMilli()).Hours()
+				d.Values[PredictorREMHours] = xtime.Millis(st.TotalREMSleepTimeMilli).Hours()
+				d.Values[PredictorSWSHours] = xtime.Millis(st.TotalSlowWaveSleepTimeMilli).Hours()
+				d.Values[PredictorDisturbances] = float64(st.DisturbanceCount)
+			}
+		}
+		days[i] = d
+	}
+	return days
+}
+
+// lastWorkoutEnd returns when the last workout in c ended, in hours after the
+// local midnight the cycle started on, so a workout ending at 1am reads 25.
+func lastWorkoutEnd(c whoop.Cycle, workouts []whoop.Workout) (float64, bool) {
+	var (
+		last  time.Time
+		found bool
+	)
+	for _, w := range workouts {
+		if w.Start.Before(c.Start) || (c.End != nil && !w.Start.Before(*c.End)) {
+			continue
+		}
+		if !found || w.End.After(last) {
+			last, found = w.End, true
+		}
+	}
+	if !found {
+		return 0, false
+	}
+
+	loc := whoop.Location(c.TimezoneOffset)
+	start := c.Start.In(loc)
+	midnight := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	return last.Sub(midnight).Hours(), true
+}
+
+// LoadDays reads the cached cycles starting within [start, end] as days.
+func LoadDays(ctx context.Context, repo *repository.Repository, start, end time.Time) ([]Day, error) {
+	records, err := repository.LoadRecords(ctx, repo, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return Days(records.Cycles, records.Recoveries, records.Sleeps, records.Workouts), nil
+}
+
+// Estimate is a correlation coefficient with its 95% confidence interval.
+type Estimate struct {
+	R    float64 `json:"r"`
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+}
+
+// Significant reports whether the interval excludes zero.
+func (e Estimate) Significant() bool {
+	return e.Low > 0 || e.High < 0
+}
+
+// Correlation relates a predictor on one day to recovery Lag days later.
+type Correlation struct {
+	Predictor Predictor `json:"predictor"`
+	Lag       int       `json:"lag"`
+	N         int       `json:"n"`
+	Pearson   Estimate  `json:"pearson"`
+	Spearman  Estimate  `json:"spearman"`
+}
+
+// Correlate pairs every predictor with the recovery of each lag in Lags
+// calendar days later. Pairs need both values; predictors with fewer than
+// MinPairs pairs, or without any variation, are left out.
+func Correlate(days []Day) []Correlation {
+	byDate := make(map[time.Time]*Day, len(days))
+	for i := range days {
+		byDate[days[i].Date] = &days[i]
+	}
+
+	var correlations []Correlation
+	for _, p := range Predictors {
+		for _, lag := range Lags {
+			var xs, ys []float64
+			for _, d := range days {
+				x, ok := d.Values[p]
+				if !ok {
+					continue
+				}
+				later, ok := byDate[d.Date.AddDate(0, 0, lag)]
+				if !ok || later.Recovery == nil {
+					continue
+				}
+				xs = append(xs, x)
+				ys = append(ys, *later.Recovery)
+			}
+			if len(xs) < MinPairs {
+				continue
+			}
+
+			r, ok := pearson(xs, ys)
+			if !ok {
+				continue
+			}
+			rho, _ := pearson(ranks(xs), ranks(ys))
+
+			n := len(xs)
+			correlations = append(correlations, Correlation{
+				Predictor: p,
+				Lag:       lag,
+				N:         n,
+				Pearson:   estimate(r, 1/math.Sqrt(float64(n-3))),
+				// Fieller, Hartley and Pearson's standard error for Spearman's rho
+				Spearman: estimate(rho, math.Sqrt(1.06/float64(n-3))),
+			})
+		}
+	}
+	return correlations
+}
+
+// estimate puts a confidence interval around r through Fisher's z transform.
+func estimate(r, se float64) Estimate {
+	// atanh is infinite at ±1, which a perfect correlation would hit
+	z := math.Atanh(max(min(r, 1-1e-12), -1+1e-12))
+	return Estimate{
+		R:    r,
+		Low:  math.Tanh(z - z95*se),
+		High: math.Tanh(z + z95*se),
+	}
+}
+
+// pearson returns the Pearson correlation of xs and ys, and false when either
+// doesn't vary.
+func pearson(xs, ys []float64) (float64, bool) {
+	n := float64(len(xs))
+
+	var mx, my float64
+	for i := range xs {
+		mx += xs[i]
+		my += ys[i]
+	}
+	mx /= n
+	my /= n
+
+	var sxy, sxx, syy float64
+	for i := range xs {
+		dx, dy := xs[i]-mx, ys[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 || syy == 0 {
+		return 0, false
+	}
+	return sxy / math.Sqrt(sxx*syy), true
+}
+
+// ranks returns the rank of each value, averaging the ranks of ties.
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int { return cmp.Compare(values[a], values[b]) })
+
+	ranked := make([]float64, len(values))
+	for i := 0; i < len(order); {
+		j := i
+		for j+1 < len(order) && values[order[j+1]] == values[order[i]] {
+			j++
+		}
+		// positions i..j tie, and share the mean of ranks i+1..j+1
+		rank := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			ranked[order[k]] = rank
+		}
+		i = j + 1
+	}
+	return ranked
+}
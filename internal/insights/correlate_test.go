@@ -0,0 +1,140 @@
+package insights
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+)
+
+func TestDays(t *testing.T) {
+	t.Parallel()
+
+	end := day0.Add(24 * time.Hour)
+	cycles := []whoop.Cycle{
+		{ID: 2, Start: day0.AddDate(0, 0, 1)},
+		{ID: 1, Start: day0, End: &end, Score: &whoop.CycleScore{Strain: 14}},
+	}
+	recoveries := []whoop.Recovery{
+		{CycleID: 2, Score: &whoop.RecoveryScore{RecoveryScore: 70}},
+	}
+	sleeps := []whoop.Sleep{
+		{CycleID: 2, Nap: true, Score: &whoop.SleepScore{StageSummary: whoop.SleepStages{TotalREMSleepTimeMilli: 9_000_000}}},
+		{CycleID: 2, Score: &whoop.SleepScore{StageSummary: whoop.SleepStages{
+			TotalLightSleepTimeMilli:    3 * 3_600_000,
+			TotalSlowWaveSleepTimeMilli: 2 * 3_600_000,
+			TotalREMSleepTimeMilli:      3_600_000 / 2,
+			DisturbanceCount:            4,
+		}}},
+	}
+	workouts := []whoop.Workout{
+		{Start: day0.Add(6 * time.Hour), End: day0.Add(7 * time.Hour)},
+		// ends at 1am the next day
+		{Start: day0.Add(12 * time.Hour), End: day0.Add(14 * time.Hour)},
+		// belongs to cycle 2
+		{Start: end.Add(time.Hour), End: end.Add(2 * time.Hour)},
+	}
+
+	days := Days(cycles, recoveries, sleeps, workouts)
+	if len(days) != 2 || days[0].CycleID != 1 || days[1].CycleID != 2 {
+		t.Fatalf("Days() = %+v, want cycles 1 and 2 oldest first", days)
+	}
+	if days[0].Recovery != nil || days[1].Recovery == nil || *days[1].Recovery != 70 {
+		t.Errorf("recoveries = %v, %v, want none then 70", days[0].Recovery, days[1].Recovery)
+	}
+
+	want := map[Predictor]float64{
+		PredictorStrain:       14,
+		PredictorWorkoutEnd:   25,
+		PredictorSleepHours:   5.5,
+		PredictorREMHours:     0.5,
+		PredictorSWSHours:     2,
+		PredictorDisturbances: 4,
+	}
+	if diff := cmp.Diff(want, days[0].Values); diff != "" {
+		t.Errorf("day values mismatch (-want +got):\n%s", diff)
+	}
+	// the last day has no night after it yet
+	if diff := cmp.Diff(map[Predictor]float64{PredictorWorkoutEnd: 13}, days[1].Values); diff != "" {
+		t.Errorf("last day values mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// strainDays returns a day per strain, each with the given recovery.
+func strainDays(strains, recoveries []float64) []Day {
+	days := make([]Day, len(strains))
+	for i := range strains {
+		days[i] = Day{
+			CycleID:  int64(i + 1),
+			Date:     time.Date(2025, 3, 1+i, 0, 0, 0, 0, time.UTC),
+			Recovery: &recoveries[i],
+			Values:   map[Predictor]float64{PredictorStrain: strains[i]},
+		}
+	}
+	return days
+}
+
+func TestCorrelate(t *testing.T) {
+	t.Parallel()
+
+	// recovery drops the day after a hard day
+	strains := []float64{8, 16, 10, 18, 6, 12, 20, 9, 14, 7, 17, 11, 15, 5}
+	recoveries := make([]float64, len(strains))
+	recoveries[0] = 60
+	for i := 1; i < len(strains); i++ {
+		recoveries[i] = 100 - 4*strains[i-1]
+	}
+
+	correlations := Correlate(strainDays(strains, recoveries))
+	if len(correlations) != 3 {
+		t.Fatalf("Correlate() = %+v, want a correlation per lag", correlations)
+	}
+
+	c := correlations[0]
+	if c.Predictor != PredictorStrain || c.Lag != 1 || c.N != 13 {
+		t.Fatalf("first correlation = %+v, want strain at lag 1 over 13 pairs", c)
+	}
+	if math.Abs(c.Pearson.R+1) > 1e-9 || math.Abs(c.Spearman.R+1) > 1e-9 {
+		t.Errorf("lag 1 r = %v, rho = %v, want -1", c.Pearson.R, c.Spearman.R)
+	}
+	if !c.Pearson.Significant() || c.Pearson.High >= 0 {
+		t.Errorf("lag 1 interval = %+v, want it below zero", c.Pearson)
+	}
+	for _, c := range correlations[1:] {
+		if c.Pearson.Low > c.Pearson.R || c.Pearson.R > c.Pearson.High {
+			t.Errorf("lag %d interval %+v doesn't contain r", c.Lag, c.Pearson)
+		}
+	}
+}
+
+func TestCorrelate_TooFewPairs(t *testing.T) {
+	t.Parallel()
+
+	days := strainDays([]float64{1, 2, 3, 4, 5}, []float64{50, 60, 40, 70, 30})
+	if got := Correlate(days); len(got) != 0 {
+		t.Errorf("Correlate() = %+v, want none", got)
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	t.Parallel()
+
+	// r = 0.5 over 28 pairs: z = 0.5493 ± 1.96/5
+	e := estimate(0.5, 1/math.Sqrt(25))
+	if math.Abs(e.Low-0.1563) > 1e-3 || math.Abs(e.High-0.7356) > 1e-3 {
+		t.Errorf("estimate() = %+v, want [0.156, 0.736]", e)
+	}
+}
+
+func TestRanks(t *testing.T) {
+	t.Parallel()
+
+	got := ranks([]float64{30, 10, 20, 10, 40})
+	want := []float64{4, 1.5, 3, 1.5, 5}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ranks() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,241 @@
+// Package insights compares recovery metrics against the user's own rolling
+// baseline and flags the days that stand out, relates strain and sleep to
+// later recoveries, and describes when the user sleeps.
+package insights
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+const (
+	// DefaultWindow is how far back a day's baseline reaches.
+	DefaultWindow = 30 * 24 * time.Hour
+	// DefaultMinSamples is how many days a baseline needs before it is trusted.
+	DefaultMinSamples = 7
+	// DefaultThreshold is how many standard deviations from the baseline
+	// make a value an anomaly.
+	DefaultThreshold = 2.0
+)
+
+// Point is a cycle's metrics. A metric is absent when it wasn't measured or
+// the recovery was scored while calibrating.
+type Point struct {
+	CycleID        int64
+	Start          time.Time
+	TimezoneOffset string
+	Values         map[Metric]float64
+}
+
+// Points builds a point per cycle, oldest first, from its recovery and main sleep.
+func Points(cycles []whoop.Cycle, recoveries []whoop.Recovery, sleeps []whoop.Sleep) []Point {
+	recoveryByCycle := make(map[int64]*whoop.Recovery, len(recoveries))
+	for i := range recoveries {
+		recoveryByCycle[recoveries[i].CycleID] = &recoveries[i]
+	}
+	sleepByCycle := make(map[int64]*whoop.Sleep, len(sleeps))
+	for i := range sleeps {
+		if !sleeps[i].Nap {
+			sleepByCycle[sleeps[i].CycleID] = &sleeps[i]
+		}
+	}
+
+	points := make([]Point, 0, len(cycles))
+	for _, c := range cycles {
+		p := Point{
+			CycleID:        c.ID,
+			Start:          c.Start,
+			TimezoneOffset: c.TimezoneOffset,
+			Values:         make(map[Metric]float64, len(Metrics)),
+		}
+		// the sleep's metrics are measured while calibrating too, so a
+		// calibrating recovery keeps them out of the baseline as well
+		if r := recoveryByCycle[c.ID]; r == nil || r.Score == nil || !r.Score.UserCalibrating {
+			recoveryValues(r, p.Values)
+			sleepValues(sleepByCycle[c.ID], p.Values)
+		}
+		points = append(points, p)
+	}
+
+	slices.SortFunc(points, func(a, b Point) int { return a.Start.Compare(b.Start) })
+	return points
+}
+
+// Load reads the cached cycles starting within [start, end] as points.
+func Load(ctx context.Context, repo *repository.Repository, start, end time.Time) ([]Point, error) {
+	records, err := repository.LoadRecords(ctx, repo, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return Points(records.Cycles, records.Recoveries, records.Sleeps), nil
+}
+
+// Baseline is a metric's mean and standard deviation over the window before a day.
+type Baseline struct {
+	Mean    float64
+	StdDev  float64
+	Samples int
+}
+
+// Deviation is how far a day's value sits from its baseline, in standard deviations.
+type Deviation struct {
+	Metric         Metric
+	CycleID        int64
+	Start          time.Time
+	TimezoneOffset string
+	Value          float64
+	Baseline       Baseline
+	Z              float64
+}
+
+// Date is when the day started, in the timezone it was recorded in.
+func (d Deviation) Date() time.Time {
+	return d.Start.In(whoop.Location(d.TimezoneOffset))
+}
+
+type Analyzer struct {
+	window     time.Duration
+	minSamples int
+	threshold  float64
+}
+
+type Option func(*Analyzer)
+
+func WithWindow(d time.Duration) Option {
+	return func(a *Analyzer) {
+		a.window = d
+	}
+}
+
+func WithMinSamples(n int) Option {
+	return func(a *Analyzer) {
+		a.minSamples = n
+	}
+}
+
+func WithThreshold(z float64) Option {
+	return func(a *Analyzer) {
+		a.threshold = z
+	}
+}
+
+func New(opts ...Option) *Analyzer {
+	a := &Analyzer{
+		window:     DefaultWindow,
+		minSamples: DefaultMinSamples,
+		threshold:  DefaultThreshold,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Window is how far before the first day of interest points must be loaded
+// for its baseline to be complete.
+func (a *Analyzer) Window() time.Duration {
+	return a.window
+}
+
+// Deviations scores every metric of every point against the baseline of the
+// points in the window before it, oldest first. Points whose baseline has too
+// few samples or no spread are skipped.
+func (a *Analyzer) Deviations(points []Point) []Deviation {
+	var deviations []Deviation
+	for i, p := range points {
+		for _, m := range Metrics {
+			v, ok := p.Values[m]
+			if !ok {
+				continue
+			}
+			b := a.baseline(points[:i], m, p.Start)
+			if b.Samples < a.minSamples || b.StdDev == 0 {
+				continue
+			}
+			deviations = append(deviations, Deviation{
+				Metric:         m,
+				CycleID:        p.CycleID,
+				Start:          p.Start,
+				TimezoneOffset: p.TimezoneOffset,
+				Value:          v,
+				Baseline:       b,
+				Z:              (v - b.Mean) / b.StdDev,
+			})
+		}
+	}
+	return deviations
+}
+
+// Anomalies returns the deviations at or beyond the threshold.
+func (a *Analyzer) Anomalies(points []Point) []Deviation {
+	return slices.DeleteFunc(a.Deviations(points), func(d Deviation) bool {
+		return !a.Anomalous(d)
+	})
+}
+
+func (a *Analyzer) Anomalous(d Deviation) bool {
+	return math.Abs(d.Z) >= a.threshold
+}
+
+// baseline summarises m over the points before at that fall within the window.
+// prior must be sorted oldest first.
+func (a *Analyzer) baseline(prior []Point, m Metric, at time.Time) Baseline {
+	from := at.Add(-a.window)
+
+	var values []float64
+	for i := len(prior) - 1; i >= 0 && !prior[i].Start.Before(from); i-- {
+		if v, ok := prior[i].Values[m]; ok {
+			values = append(values, v)
+		}
+	}
+	if len(values) < 2 {
+		return Baseline{Samples: len(values)}
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+
+	return Baseline{
+		Mean:    mean,
+		StdDev:  math.Sqrt(squares / float64(len(values)-1)),
+		Samples: len(values),
+	}
+}
+
+// Baselines summarises each metric over the points in the window before at.
+// Metrics without enough samples are left out.
+func (a *Analyzer) Baselines(points []Point, at time.Time) map[Metric]Baseline {
+	i, _ := slices.BinarySearchFunc(points, at, func(p Point, t time.Time) int { return p.Start.Compare(t) })
+
+	baselines := make(map[Metric]Baseline, len(Metrics))
+	for _, m := range Metrics {
+		if b := a.baseline(points[:i], m, at); b.Samples >= a.minSamples {
+			baselines[m] = b
+		}
+	}
+	return baselines
+}
+
+// String describes the deviation, such as "HRV 38ms, 2.4σ below your 55ms baseline".
+func (d Deviation) String() string {
+	direction := "above"
+	if d.Z < 0 {
+		direction = "below"
+	}
+	return fmt.Sprintf("%s %s, %.1fσ %s your %s baseline",
+		d.Metric.Label(), d.Metric.Format(d.Value), math.Abs(d.Z), direction, d.Metric.Format(d.Baseline.Mean))
+}
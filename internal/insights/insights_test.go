@@ -0,0 +1,130 @@
+package insights
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+)
+
+var day0 = time.Date(2025, 3, 1, 11, 0, 0, 0, time.UTC)
+
+// hrvPoints returns a point per day with the given HRVs.
+func hrvPoints(hrvs ...float64) []Point {
+	points := make([]Point, len(hrvs))
+	for i, v := range hrvs {
+		points[i] = Point{
+			CycleID: int64(i + 1),
+			Start:   day0.AddDate(0, 0, i),
+			Values:  map[Metric]float64{MetricHRV: v},
+		}
+	}
+	return points
+}
+
+func TestPoints(t *testing.T) {
+	t.Parallel()
+
+	cycles := []whoop.Cycle{
+		{ID: 2, Start: day0.AddDate(0, 0, 1)},
+		{ID: 1, Start: day0},
+	}
+	recoveries := []whoop.Recovery{
+		{CycleID: 1, Score: &whoop.RecoveryScore{UserCalibrating: true, HRVRmssdMilli: 90, RestingHeartRate: 40}},
+		{CycleID: 2, Score: &whoop.RecoveryScore{HRVRmssdMilli: 55, RestingHeartRate: 50, SpO2Percentage: 96}},
+	}
+	sleeps := []whoop.Sleep{
+		{CycleID: 1, Score: &whoop.SleepScore{RespiratoryRate: 17}},
+		{CycleID: 2, Nap: true, Score: &whoop.SleepScore{RespiratoryRate: 20}},
+		{CycleID: 2, Score: &whoop.SleepScore{RespiratoryRate: 15}},
+	}
+
+	points := Points(cycles, recoveries, sleeps)
+	if len(points) != 2 || points[0].CycleID != 1 || points[1].CycleID != 2 {
+		t.Fatalf("Points() = %+v, want cycles 1 and 2 oldest first", points)
+	}
+	if len(points[0].Values) != 0 {
+		t.Errorf("calibrating point values = %v, want none", points[0].Values)
+	}
+
+	want := map[Metric]float64{MetricHRV: 55, MetricRHR: 50, MetricSpO2: 96, MetricRespiratoryRate: 15}
+	if len(points[1].Values) != len(want) {
+		t.Errorf("point values = %v, want %v", points[1].Values, want)
+	}
+	for m, v := range want {
+		if got := points[1].Values[m]; got != v {
+			t.Errorf("point %s = %v, want %v", m, got, v)
+		}
+	}
+}
+
+func TestDeviations(t *testing.T) {
+	t.Parallel()
+
+	// a baseline of 50 ± 2 (sample stddev of 48, 52, 48, 52, 48, 52, 48, 52
+	// is 2.138), then a day at 40
+	points := hrvPoints(48, 52, 48, 52, 48, 52, 48, 52, 40)
+	a := New()
+
+	deviations := a.Deviations(points)
+	// the first 7 days are building the baseline
+	if len(deviations) != 2 {
+		t.Fatalf("Deviations() = %d, want 2", len(deviations))
+	}
+
+	last := deviations[1]
+	if last.CycleID != 9 || last.Baseline.Samples != 8 || last.Baseline.Mean != 50 {
+		t.Errorf("last deviation = %+v, want cycle 9 against 8 samples averaging 50", last)
+	}
+	wantZ := -10 / math.Sqrt(32.0/7)
+	if math.Abs(last.Z-wantZ) > 1e-9 {
+		t.Errorf("Z = %v, want %v", last.Z, wantZ)
+	}
+
+	anomalies := a.Anomalies(points)
+	if len(anomalies) != 1 || anomalies[0].CycleID != 9 {
+		t.Errorf("Anomalies() = %+v, want only cycle 9", anomalies)
+	}
+	if got, want := anomalies[0].String(), "HRV 40ms, 4.7σ below your 50ms baseline"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestDeviations_Window(t *testing.T) {
+	t.Parallel()
+
+	points := hrvPoints(48, 52, 48, 52, 48, 52, 48, 52, 40)
+	// a 5 day window never has 7 samples
+	if got := New(WithWindow(5 * 24 * time.Hour)).Deviations(points); len(got) != 0 {
+		t.Errorf("Deviations() = %+v, want none", got)
+	}
+	if got := New(WithWindow(5*24*time.Hour), WithMinSamples(5)).Deviations(points); len(got) != 4 {
+		t.Errorf("Deviations() = %d, want 4", len(got))
+	}
+}
+
+func TestDeviations_FlatBaseline(t *testing.T) {
+	t.Parallel()
+
+	points := hrvPoints(50, 50, 50, 50, 50, 50, 50, 60)
+	if got := New().Deviations(points); len(got) != 0 {
+		t.Errorf("Deviations() = %+v, want none against a baseline without spread", got)
+	}
+}
+
+func TestBaselines(t *testing.T) {
+	t.Parallel()
+
+	points := hrvPoints(48, 52, 48, 52, 48, 52, 48, 52, 40)
+	at := day0.AddDate(0, 0, 8)
+
+	baselines := New().Baselines(points, at)
+	b, ok := baselines[MetricHRV]
+	if !ok || b.Samples != 8 || b.Mean != 50 {
+		t.Errorf("Baselines()[hrv] = %+v, want 8 samples averaging 50", b)
+	}
+	if _, ok := baselines[MetricRHR]; ok {
+		t.Error("Baselines() has rhr without any samples")
+	}
+}
@@ -0,0 +1,80 @@
+package insights
+
+import (
+	"fmt"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+)
+
+// Metric is a recovery measurement tracked against its baseline.
+type Metric string
+
+const (
+	MetricHRV             Metric = "hrv"
+	MetricRHR             Metric = "rhr"
+	MetricSpO2            Metric = "spo2"
+	MetricSkinTemp        Metric = "skin_temp"
+	MetricRespiratoryRate Metric = "respiratory_rate"
+)
+
+// Metrics lists every tracked metric, in display order.
+var Metrics = []Metric{MetricHRV, MetricRHR, MetricSpO2, MetricSkinTemp, MetricRespiratoryRate}
+
+func (m Metric) Label() string {
+	switch m {
+	case MetricHRV:
+		return "HRV"
+	case MetricRHR:
+		return "Resting HR"
+	case MetricSpO2:
+		return "SpO2"
+	case MetricSkinTemp:
+		return "Skin temp"
+	case MetricRespiratoryRate:
+		return "Resp. rate"
+	default:
+		return string(m)
+	}
+}
+
+// Format renders v with the metric's unit.
+func (m Metric) Format(v float64) string {
+	switch m {
+	case MetricHRV:
+		return fmt.Sprintf("%.0fms", v)
+	case MetricRHR:
+		return fmt.Sprintf("%.0fbpm", v)
+	case MetricSpO2:
+		return fmt.Sprintf("%.1f%%", v)
+	case MetricSkinTemp:
+		return fmt.Sprintf("%.1f°C", v)
+	case MetricRespiratoryRate:
+		return fmt.Sprintf("%.1frpm", v)
+	default:
+		return fmt.Sprintf("%.1f", v)
+	}
+}
+
+// recoveryValues reads the recovery metrics. A recovery scored while WHOOP is
+// still calibrating isn't representative, so it contributes nothing.
+func recoveryValues(r *whoop.Recovery, values map[Metric]float64) {
+	if r == nil || r.Score == nil || r.Score.UserCalibrating {
+		return
+	}
+	values[MetricHRV] = r.Score.HRVRmssdMilli
+	values[MetricRHR] = r.Score.RestingHeartRate
+	// older recoveries and some straps don't report these
+	if r.Score.SpO2Percentage > 0 {
+		values[MetricSpO2] = r.Score.SpO2Percentage
+	}
+	if r.Score.SkinTempCelsius > 0 {
+		values[MetricSkinTemp] = r.Score.SkinTempCelsius
+	}
+}
+
+func sleepValues(s *whoop.Sleep, values map[Metric]float64) {
+	if s == nil || s.Score == nil || s.Score.RespiratoryRate <= 0 {
+		return
+	}
+	values[MetricRespiratoryRate] = s.Score.RespiratoryRate
+}
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS backfill_progress (
+    entity_type TEXT PRIMARY KEY,
+    range_end DATETIME NOT NULL,
+    watermark DATETIME,
+    records INTEGER NOT NULL DEFAULT 0,
+    exhausted INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT OR IGNORE INTO backfill_progress (entity_type, range_end, watermark)
+SELECT 'cycle', COALESCE(last_full_sync, updated_at, CURRENT_TIMESTAMP), backfill_watermark
+FROM sync_state WHERE backfill_watermark IS NOT NULL;
+
+INSERT OR IGNORE INTO backfill_progress (entity_type, range_end, watermark)
+SELECT 'sleep', COALESCE(last_full_sync, updated_at, CURRENT_TIMESTAMP), backfill_watermark
+FROM sync_state WHERE backfill_watermark IS NOT NULL AND backfill_complete = 1;
+
+INSERT OR IGNORE INTO backfill_progress (entity_type, range_end, watermark)
+SELECT 'workout', COALESCE(last_full_sync, updated_at, CURRENT_TIMESTAMP), backfill_watermark
+FROM sync_state WHERE backfill_watermark IS NOT NULL AND backfill_complete = 1;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS goals (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    metric TEXT NOT NULL,
+    comparison TEXT NOT NULL,
+    value REAL NOT NULL,
+    count INTEGER,
+    period TEXT NOT NULL DEFAULT 'week',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -10,6 +10,9 @@ const (
 	dotConfig = ".config"
 	dbName    = "thoop.db"
 	logsDir   = "logs"
+	keymap    = "keys.json"
+	config    = "config.toml"
+	themesDir = "themes"
 )
 
 func Dir() (string, error) {
@@ -39,6 +42,33 @@ func DB() (string, error) {
 	return filepath.Join(dir, dbName), nil
 }
 
+// Keymap returns the path of the TUI key overrides file.
+func Keymap() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, keymap), nil
+}
+
+// ConfigFile returns the path of the optional config file.
+func ConfigFile() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, config), nil
+}
+
+// ThemesDir returns the directory custom theme palettes are read from.
+func ThemesDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, themesDir), nil
+}
+
 func LogsDir() (string, error) {
 	dir, err := Dir()
 	if err != nil {
@@ -0,0 +1,310 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// expr is a parsed --where expression.
+type expr interface {
+	kind() Kind
+	eval(Row) Value
+}
+
+type literal struct{ v Value }
+
+func (e literal) kind() Kind { return e.v.Kind }
+
+func (e literal) eval(Row) Value { return e.v }
+
+type fieldRef struct{ f field }
+
+func (e fieldRef) kind() Kind { return e.f.kind }
+
+func (e fieldRef) eval(r Row) Value { return e.f.value(r) }
+
+// comparison is null when either side is, so a filter on a missing score
+// drops the row rather than matching it.
+type comparison struct {
+	op          string
+	left, right expr
+}
+
+func (e comparison) kind() Kind { return KindBool }
+
+func (e comparison) eval(r Row) Value {
+	l, rv := e.left.eval(r), e.right.eval(r)
+	if l.IsNull() || rv.IsNull() {
+		return null()
+	}
+	c := compare(l, rv)
+	switch e.op {
+	case "=", "==":
+		return boolean(c == 0)
+	case "!=", "<>":
+		return boolean(c != 0)
+	case "<":
+		return boolean(c < 0)
+	case "<=":
+		return boolean(c <= 0)
+	case ">":
+		return boolean(c > 0)
+	case ">=":
+		return boolean(c >= 0)
+	default:
+		return null()
+	}
+}
+
+type isNull struct {
+	operand expr
+	negate  bool
+}
+
+func (e isNull) kind() Kind { return KindBool }
+
+func (e isNull) eval(r Row) Value {
+	return boolean(e.operand.eval(r).IsNull() != e.negate)
+}
+
+// logical follows SQL's three-valued logic, so "a or b" still matches when a
+// is null and b is true.
+type logical struct {
+	and         bool
+	left, right expr
+}
+
+func (e logical) kind() Kind { return KindBool }
+
+func (e logical) eval(r Row) Value {
+	l, rv := e.left.eval(r), e.right.eval(r)
+	if e.and {
+		switch {
+		case l.Kind == KindBool && !l.Bool, rv.Kind == KindBool && !rv.Bool:
+			return boolean(false)
+		case l.IsNull() || rv.IsNull():
+			return null()
+		default:
+			return boolean(true)
+		}
+	}
+	switch {
+	case l.truthy(), rv.truthy():
+		return boolean(true)
+	case l.IsNull() || rv.IsNull():
+		return null()
+	default:
+		return boolean(false)
+	}
+}
+
+type not struct{ operand expr }
+
+func (e not) kind() Kind { return KindBool }
+
+func (e not) eval(r Row) Value {
+	v := e.operand.eval(r)
+	if v.IsNull() {
+		return v
+	}
+	return boolean(!v.Bool)
+}
+
+// parser is a recursive descent parser over the grammar
+//
+//	or         = and { "or" and }
+//	and        = unary { "and" unary }
+//	unary      = "not" unary | comparison
+//	comparison = operand [ op operand | "is" [ "not" ] "null" ]
+//	operand    = number | string | field | "true" | "false" | "null" | "(" or ")"
+type parser struct {
+	src    Source
+	tokens []token
+	pos    int
+}
+
+func newParser(src Source, s string) (*parser, error) {
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{src: src, tokens: tokens}, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expectEOF() error {
+	if t := p.peek(); t.kind != tokenEOF {
+		return fmt.Errorf("unexpected %s at %d", t, t.pos)
+	}
+	return nil
+}
+
+// parseWhere parses a filter, which must be a condition.
+func parseWhere(src Source, s string) (expr, error) {
+	p, err := newParser(src, s)
+	if err != nil {
+		return nil, err
+	}
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectEOF(); err != nil {
+		return nil, err
+	}
+	if k := e.kind(); k != KindBool {
+		return nil, fmt.Errorf("where must be a condition, not a %s", k)
+	}
+	return e, nil
+}
+
+func (p *parser) or() (expr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("or") {
+		t := p.next()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		if err := checkLogical(t, left, right); err != nil {
+			return nil, err
+		}
+		left = logical{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (expr, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("and") {
+		t := p.next()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		if err := checkLogical(t, left, right); err != nil {
+			return nil, err
+		}
+		left = logical{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+// checkLogical rejects and/or operands that aren't conditions. A null literal
+// is let through, since three-valued logic gives it a meaning.
+func checkLogical(t token, left, right expr) error {
+	for _, e := range []expr{left, right} {
+		if k := e.kind(); k != KindBool && k != KindNull {
+			return fmt.Errorf("%s at %d needs conditions, not a %s", t.text, t.pos, k)
+		}
+	}
+	return nil
+}
+
+func (p *parser) unary() (expr, error) {
+	if !p.peek().is("not") {
+		return p.comparison()
+	}
+	t := p.next()
+	operand, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	if k := operand.kind(); k != KindBool {
+		return nil, fmt.Errorf("not at %d needs a condition, not a %s", t.pos, k)
+	}
+	return not{operand: operand}, nil
+}
+
+func (p *parser) comparison() (expr, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	switch {
+	case t.kind == tokenOp:
+		p.next()
+		right, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		lk, rk := left.kind(), right.kind()
+		if lk != KindNull && rk != KindNull && lk != rk {
+			return nil, fmt.Errorf("cannot compare %s with %s at %d", lk, rk, t.pos)
+		}
+		return comparison{op: t.text, left: left, right: right}, nil
+	case t.is("is"):
+		p.next()
+		negate := p.peek().is("not")
+		if negate {
+			p.next()
+		}
+		if n := p.next(); !n.is("null") {
+			return nil, fmt.Errorf("expected null after is, got %s at %d", n, n.pos)
+		}
+		return isNull{operand: left, negate: negate}, nil
+	default:
+		return left, nil
+	}
+}
+
+func (p *parser) operand() (expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at %d", t.text, t.pos)
+		}
+		return literal{number(n)}, nil
+	case tokenString:
+		return literal{text(t.text)}, nil
+	case tokenIdent:
+		switch strings.ToLower(t.text) {
+		case "true":
+			return literal{boolean(true)}, nil
+		case "false":
+			return literal{boolean(false)}, nil
+		case "null":
+			return literal{null()}, nil
+		}
+		f, err := lookup(p.src, t.text)
+		if err != nil {
+			return nil, err
+		}
+		return fieldRef{f}, nil
+	case tokenLParen:
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if r := p.next(); r.kind != tokenRParen {
+			return nil, fmt.Errorf("expected ) at %d, got %s", r.pos, r)
+		}
+		return e, nil
+	case tokenEOF, tokenOp, tokenRParen, tokenComma:
+		return nil, fmt.Errorf("expected a value at %d, got %s", t.pos, t)
+	default:
+		return nil, fmt.Errorf("expected a value at %d, got %s", t.pos, t)
+	}
+}
@@ -0,0 +1,184 @@
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/xtime"
+)
+
+// field is a named value read from a Row. workout fields only exist for
+// SourceWorkouts.
+type field struct {
+	name    string
+	kind    Kind
+	help    string
+	workout bool
+	value   func(Row) Value
+}
+
+// prevPrefix reads a field from the previous cycle's row instead.
+const prevPrefix = "prev."
+
+func cycleField(name string, kind Kind, help string, value func(whoop.Cycle) Value) field {
+	return field{name: name, kind: kind, help: help, value: func(r Row) Value { return value(r.Cycle) }}
+}
+
+func cycleScore(name, help string, value func(*whoop.CycleScore) float64) field {
+	return field{name: name, kind: KindNumber, help: help, value: func(r Row) Value {
+		if r.Cycle.Score == nil {
+			return null()
+		}
+		return number(value(r.Cycle.Score))
+	}}
+}
+
+func recoveryScore(name, help string, value func(*whoop.RecoveryScore) float64) field {
+	return field{name: name, kind: KindNumber, help: help, value: func(r Row) Value {
+		if r.Recovery == nil || r.Recovery.Score == nil {
+			return null()
+		}
+		return number(value(r.Recovery.Score))
+	}}
+}
+
+func sleepScore(name, help string, value func(*whoop.SleepScore) float64) field {
+	return field{name: name, kind: KindNumber, help: help, value: func(r Row) Value {
+		if r.Sleep == nil || r.Sleep.Score == nil {
+			return null()
+		}
+		return number(value(r.Sleep.Score))
+	}}
+}
+
+func workoutField(name string, kind Kind, help string, value func(*whoop.Workout) Value) field {
+	return field{name: name, kind: kind, help: help, workout: true, value: func(r Row) Value {
+		if r.Workout == nil {
+			return null()
+		}
+		return value(r.Workout)
+	}}
+}
+
+func workoutScore(name, help string, value func(*whoop.WorkoutScore) Value) field {
+	return workoutField(name, KindNumber, help, func(w *whoop.Workout) Value {
+		if w.Score == nil {
+			return null()
+		}
+		return value(w.Score)
+	})
+}
+
+// localStart is when the cycle started in the timezone it was recorded in.
+func localStart(c whoop.Cycle) time.Time {
+	return c.Start.In(whoop.Location(c.TimezoneOffset))
+}
+
+var fields = []field{
+	cycleField("date", KindString, "day the cycle started, YYYY-MM-DD", func(c whoop.Cycle) Value {
+		return text(localStart(c).Format(time.DateOnly))
+	}),
+	cycleField("weekday", KindString, "day of the week the cycle started, e.g. Mon", func(c whoop.Cycle) Value {
+		return text(localStart(c).Format("Mon"))
+	}),
+	cycleField("cycle.id", KindNumber, "cycle id", func(c whoop.Cycle) Value {
+		return number(float64(c.ID))
+	}),
+	cycleScore("strain", "day strain, 0-21", func(s *whoop.CycleScore) float64 { return s.Strain }),
+	cycleScore("kilojoule", "energy burned over the day", func(s *whoop.CycleScore) float64 { return s.Kilojoule }),
+	cycleScore("avg_hr", "average heart rate over the day", func(s *whoop.CycleScore) float64 { return float64(s.AverageHeartRate) }),
+	cycleScore("max_hr", "max heart rate over the day", func(s *whoop.CycleScore) float64 { return float64(s.MaxHeartRate) }),
+
+	recoveryScore("recovery", "recovery score, 0-100", func(s *whoop.RecoveryScore) float64 { return s.RecoveryScore }),
+	recoveryScore("hrv", "heart rate variability (RMSSD) in ms", func(s *whoop.RecoveryScore) float64 { return s.HRVRmssdMilli }),
+	recoveryScore("rhr", "resting heart rate", func(s *whoop.RecoveryScore) float64 { return s.RestingHeartRate }),
+	recoveryScore("spo2", "blood oxygen percentage", func(s *whoop.RecoveryScore) float64 { return s.SpO2Percentage }),
+	recoveryScore("skin_temp", "skin temperature in celsius", func(s *whoop.RecoveryScore) float64 { return s.SkinTempCelsius }),
+
+	sleepScore("sleep", "sleep performance, 0-100", func(s *whoop.SleepScore) float64 { return s.SleepPerformancePercentage }),
+	sleepScore("sleep.efficiency", "sleep efficiency, 0-100", func(s *whoop.SleepScore) float64 { return s.SleepEfficiencyPercentage }),
+	sleepScore("sleep.consistency", "sleep consistency, 0-100", func(s *whoop.SleepScore) float64 { return s.SleepConsistencyPercentage }),
+	sleepScore("sleep.hours", "hours asleep", func(s *whoop.SleepScore) float64 {
+		return xtime.Millis(s.StageSummary.TotalSleepTimeMilli()).Hours()
+	}),
+	sleepScore("sleep.rem_hours", "hours of REM sleep", func(s *whoop.SleepScore) float64 {
+		return xtime.Millis(s.StageSummary.TotalREMSleepTimeMilli).Hours()
+	}),
+	sleepScore("sleep.sws_hours", "hours of slow wave sleep", func(s *whoop.SleepScore) float64 {
+		return xtime.Millis(s.StageSummary.TotalSlowWaveSleepTimeMilli).Hours()
+	}),
+	sleepScore("sleep.disturbances", "times woken during the night", func(s *whoop.SleepScore) float64 { return float64(s.StageSummary.DisturbanceCount) }),
+	sleepScore("sleep.respiratory_rate", "breaths per minute asleep", func(s *whoop.SleepScore) float64 { return s.RespiratoryRate }),
+
+	workoutField("workout.sport", KindString, "sport name", func(w *whoop.Workout) Value {
+		return text(w.SportName)
+	}),
+	workoutField("workout.minutes", KindNumber, "workout duration in minutes", func(w *whoop.Workout) Value {
+		return number(w.End.Sub(w.Start).Minutes())
+	}),
+	workoutScore("workout.strain", "workout strain, 0-21", func(s *whoop.WorkoutScore) Value { return number(s.Strain) }),
+	workoutScore("workout.avg_hr", "average heart rate during the workout", func(s *whoop.WorkoutScore) Value {
+		return number(float64(s.AverageHeartRate))
+	}),
+	workoutScore("workout.max_hr", "max heart rate during the workout", func(s *whoop.WorkoutScore) Value {
+		return number(float64(s.MaxHeartRate))
+	}),
+	workoutScore("workout.kilojoule", "energy burned during the workout", func(s *whoop.WorkoutScore) Value { return number(s.Kilojoule) }),
+	workoutScore("workout.distance_km", "distance covered in km", func(s *whoop.WorkoutScore) Value {
+		if s.DistanceMeter == nil {
+			return null()
+		}
+		return number(*s.DistanceMeter / 1000)
+	}),
+}
+
+// defaultColumns are selected when the query doesn't say.
+var defaultColumns = map[Source][]string{
+	SourceCycles:   {"date", "recovery", "hrv", "rhr", "strain", "sleep", "sleep.hours"},
+	SourceWorkouts: {"date", "workout.sport", "workout.minutes", "workout.strain", "workout.avg_hr", "recovery"},
+}
+
+// lookup resolves name, with an optional prev. prefix, to a field for src.
+func lookup(src Source, name string) (field, error) {
+	base, prev := strings.CutPrefix(strings.ToLower(name), prevPrefix)
+
+	i := slices.IndexFunc(fields, func(f field) bool { return f.name == base })
+	if i < 0 {
+		return field{}, fmt.Errorf("unknown field %q", name)
+	}
+	f := fields[i]
+	if f.workout && src != SourceWorkouts {
+		return field{}, fmt.Errorf("field %q needs the workouts source", name)
+	}
+	if !prev {
+		return f, nil
+	}
+	if f.workout {
+		return field{}, fmt.Errorf("field %q has no previous day", name)
+	}
+
+	value := f.value
+	f.name = prevPrefix + f.name
+	f.value = func(r Row) Value {
+		if r.Prev == nil {
+			return null()
+		}
+		return value(*r.Prev)
+	}
+	return f, nil
+}
+
+// Fields describes every field available for src, one per line, for help text.
+func Fields(src Source) string {
+	var b strings.Builder
+	for _, f := range fields {
+		if f.workout && src != SourceWorkouts {
+			continue
+		}
+		_, _ = fmt.Fprintf(&b, "  %-24s %s\n", f.name, f.help)
+	}
+	return b.String()
+}
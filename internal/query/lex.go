@@ -0,0 +1,105 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind uint8
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of input"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// is reports whether t is the keyword kw, ignoring case.
+func (t token) is(kw string) bool {
+	return t.kind == tokenIdent && strings.EqualFold(t.text, kw)
+}
+
+// lex splits s into tokens. Identifiers may contain dots so fields like
+// sleep.hours and prev.strain are single tokens.
+func lex(s string) ([]token, error) {
+	var (
+		tokens []token
+		runes  = []rune(s)
+	)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenLParen, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenRParen, ")", i})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokenComma, ",", i})
+			i++
+		case r == '\'' || r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			tokens = append(tokens, token{tokenString, string(runes[i+1 : end]), i})
+			i = end + 1
+		case strings.ContainsRune("=!<>", r):
+			end := i + 1
+			if end < len(runes) && strings.ContainsRune("=>", runes[end]) {
+				end++
+			}
+			op := string(runes[i:end])
+			switch op {
+			case "=", "==", "!=", "<>", "<", "<=", ">", ">=":
+			default:
+				return nil, fmt.Errorf("unknown operator %q at %d", op, i)
+			}
+			tokens = append(tokens, token{tokenOp, op, i})
+			i = end
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			end := i + 1
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, token{tokenNumber, string(runes[i:end]), i})
+			i = end
+		case unicode.IsLetter(r) || r == '_':
+			end := i + 1
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_' || runes[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, token{tokenIdent, string(runes[i:end]), i})
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected %q at %d", r, i)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
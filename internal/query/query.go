@@ -0,0 +1,163 @@
+// Package query filters, projects and sorts cached WHOOP data joined by cycle,
+// using a small SQL-like expression language.
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Spec is a query as the user wrote it. Empty fields mean no filter, the
+// source's default columns, and oldest first.
+type Spec struct {
+	Where   string
+	Select  string
+	OrderBy string
+	Limit   int
+}
+
+// Query is a parsed Spec, ready to run over rows.
+type Query struct {
+	where   expr
+	columns []field
+	order   []order
+	limit   int
+}
+
+type order struct {
+	f    field
+	desc bool
+}
+
+// New parses spec against the fields available for src.
+func New(src Source, spec Spec) (*Query, error) {
+	q := &Query{limit: spec.Limit}
+
+	if strings.TrimSpace(spec.Where) != "" {
+		where, err := parseWhere(src, spec.Where)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --where: %w", err)
+		}
+		q.where = where
+	}
+
+	names := defaultColumns[src]
+	if strings.TrimSpace(spec.Select) != "" {
+		names = splitList(spec.Select)
+	}
+	for _, name := range names {
+		f, err := lookup(src, name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --select: %w", err)
+		}
+		q.columns = append(q.columns, f)
+	}
+
+	for _, term := range splitList(spec.OrderBy) {
+		o, err := parseOrder(src, term)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --order-by: %w", err)
+		}
+		q.order = append(q.order, o)
+	}
+
+	return q, nil
+}
+
+// splitList splits a comma separated list, dropping empty entries.
+func splitList(s string) []string {
+	var items []string
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseOrder parses "field [asc|desc]".
+func parseOrder(src Source, term string) (order, error) {
+	parts := strings.Fields(term)
+	if len(parts) > 2 {
+		return order{}, fmt.Errorf("expected field [asc|desc], got %q", term)
+	}
+
+	f, err := lookup(src, parts[0])
+	if err != nil {
+		return order{}, err
+	}
+	o := order{f: f}
+	if len(parts) == 2 {
+		switch strings.ToLower(parts[1]) {
+		case "asc":
+		case "desc":
+			o.desc = true
+		default:
+			return order{}, fmt.Errorf("expected asc or desc, got %q", parts[1])
+		}
+	}
+	return o, nil
+}
+
+// Result is the selected columns of every matching row.
+type Result struct {
+	Columns []string
+	Rows    [][]Value
+}
+
+// Run filters, sorts and projects rows. Rows where the filter is null, such
+// as a comparison against an unscored day, don't match. Nulls sort last in
+// either direction.
+func (q *Query) Run(rows []Row) Result {
+	matched := make([]Row, 0, len(rows))
+	for _, r := range rows {
+		if q.where == nil || q.where.eval(r).truthy() {
+			matched = append(matched, r)
+		}
+	}
+
+	if len(q.order) > 0 {
+		slices.SortStableFunc(matched, func(a, b Row) int {
+			for _, o := range q.order {
+				av, bv := o.f.value(a), o.f.value(b)
+				switch {
+				case av.IsNull() && bv.IsNull():
+					continue
+				case av.IsNull():
+					return 1
+				case bv.IsNull():
+					return -1
+				}
+				c := compare(av, bv)
+				if o.desc {
+					c = -c
+				}
+				if c != 0 {
+					return c
+				}
+			}
+			return 0
+		})
+	}
+
+	if q.limit > 0 && len(matched) > q.limit {
+		matched = matched[:q.limit]
+	}
+
+	result := Result{
+		Columns: make([]string, len(q.columns)),
+		Rows:    make([][]Value, len(matched)),
+	}
+	for i, f := range q.columns {
+		result.Columns[i] = f.name
+	}
+	for i, r := range matched {
+		values := make([]Value, len(q.columns))
+		for j, f := range q.columns {
+			values[j] = f.value(r)
+		}
+		result.Rows[i] = values
+	}
+	return result
+}
@@ -0,0 +1,184 @@
+package query
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+)
+
+// testRows builds four days: strain 16, 8, 18 and an unscored day.
+func testRows() []Row {
+	start := time.Date(2025, 3, 10, 11, 0, 0, 0, time.UTC)
+	day := func(i int, strain, hrv *float64) whoop.Cycle {
+		c := whoop.Cycle{
+			ID:             int64(i + 1),
+			Start:          start.AddDate(0, 0, i),
+			TimezoneOffset: "-05:00",
+		}
+		end := c.Start.AddDate(0, 0, 1)
+		c.End = &end
+		if strain != nil {
+			c.Score = &whoop.CycleScore{Strain: *strain}
+		}
+		return c
+	}
+	f := func(v float64) *float64 { return &v }
+
+	cycles := []whoop.Cycle{
+		day(0, f(16), nil),
+		day(1, f(8), nil),
+		day(2, f(18), nil),
+		day(3, nil, nil),
+	}
+	recoveries := []whoop.Recovery{
+		{CycleID: 1, Score: &whoop.RecoveryScore{RecoveryScore: 70, HRVRmssdMilli: 60}},
+		{CycleID: 2, Score: &whoop.RecoveryScore{RecoveryScore: 40, HRVRmssdMilli: 42.5}},
+		{CycleID: 3, Score: &whoop.RecoveryScore{RecoveryScore: 80, HRVRmssdMilli: 71}},
+		{CycleID: 4, Score: &whoop.RecoveryScore{RecoveryScore: 35, HRVRmssdMilli: 38.123}},
+	}
+	sleeps := []whoop.Sleep{
+		{CycleID: 2, Nap: true, Score: &whoop.SleepScore{SleepPerformancePercentage: 10}},
+		{CycleID: 2, Score: &whoop.SleepScore{SleepPerformancePercentage: 91}},
+	}
+	return join(cycles, recoveries, sleeps)
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		spec Spec
+		want string
+	}{
+		{
+			name: "days after high strain",
+			spec: Spec{Where: "prev.strain > 15", Select: "date, hrv"},
+			want: "date,hrv\n2025-03-11,42.5\n2025-03-13,38.123\n",
+		},
+		{
+			name: "unscored never matches",
+			spec: Spec{Where: "strain < 100 or strain >= 100", Select: "cycle.id"},
+			want: "cycle.id\n1\n2\n3\n",
+		},
+		{
+			name: "is null",
+			spec: Spec{Where: "strain is null or sleep is not null", Select: "cycle.id,sleep"},
+			want: "cycle.id,sleep\n2,91\n4,\n",
+		},
+		{
+			name: "order with nulls last and limit",
+			spec: Spec{OrderBy: "strain desc", Select: "cycle.id,strain", Limit: 3},
+			want: "cycle.id,strain\n3,18\n1,16\n2,8\n",
+		},
+		{
+			name: "not and strings",
+			spec: Spec{Where: "not (weekday = 'Mon' or date >= \"2025-03-12\")", Select: "weekday"},
+			want: "weekday\nTue\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := New(SourceCycles, tt.spec)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			var buf bytes.Buffer
+			if err := q.Run(testRows()).Write(&buf, FormatCSV); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, buf.String()); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  Source
+		spec Spec
+	}{
+		{"unknown field", SourceCycles, Spec{Where: "hrvv > 50"}},
+		{"type mismatch", SourceCycles, Spec{Where: "date > 5"}},
+		{"not a condition", SourceCycles, Spec{Where: "strain"}},
+		{"and with a number", SourceCycles, Spec{Where: "hrv and strain > 15"}},
+		{"or of numbers", SourceCycles, Spec{Where: "1 or 2"}},
+		{"or with a string", SourceCycles, Spec{Where: "strain > 15 or weekday"}},
+		{"trailing tokens", SourceCycles, Spec{Where: "strain > 5 10"}},
+		{"unterminated string", SourceCycles, Spec{Where: "weekday = 'Mon"}},
+		{"unbalanced paren", SourceCycles, Spec{Where: "(strain > 5"}},
+		{"bad operator", SourceCycles, Spec{Where: "strain => 5"}},
+		{"workout field on cycles", SourceCycles, Spec{Select: "workout.strain"}},
+		{"previous workout", SourceWorkouts, Spec{Select: "prev.workout.strain"}},
+		{"bad direction", SourceCycles, Spec{OrderBy: "strain down"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := New(tt.src, tt.spec); err == nil {
+				t.Errorf("New(%+v) error = nil, want an error", tt.spec)
+			}
+		})
+	}
+}
+
+func TestJoinWorkouts(t *testing.T) {
+	t.Parallel()
+
+	days := testRows()
+	at := func(d time.Duration) time.Time { return days[0].Cycle.Start.Add(d) }
+	workouts := []whoop.Workout{
+		{ID: "before", Start: at(-time.Hour), End: at(0)},
+		{ID: "first", Start: at(2 * time.Hour), End: at(3 * time.Hour), SportName: "running"},
+		{ID: "second", Start: at(26 * time.Hour), End: at(27 * time.Hour), SportName: "cycling"},
+	}
+
+	q, err := New(SourceWorkouts, Spec{Select: "workout.sport,workout.minutes,cycle.id,recovery"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := q.Run(joinWorkouts(days, workouts)).Write(&buf, FormatJSON); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	want := `[{"workout.sport":"running","workout.minutes":60,"cycle.id":1,"recovery":70},` +
+		`{"workout.sport":"cycling","workout.minutes":60,"cycle.id":2,"recovery":40}]` + "\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("joinWorkouts() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	t.Parallel()
+
+	q, err := New(SourceCycles, Spec{Select: "date,hrv,strain"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := q.Run(testRows()).Write(&buf, FormatTable); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	want := "date        hrv    strain\n" +
+		"2025-03-10  60     16\n" +
+		"2025-03-11  42.5   8\n" +
+		"2025-03-12  71     18\n" +
+		"2025-03-13  38.12  --\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("table mismatch (-want +got):\n%s", diff)
+	}
+}
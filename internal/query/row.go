@@ -0,0 +1,146 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+// Source is what each result row is: a day's cycle, or a single workout
+// joined to the cycle it happened in.
+type Source string
+
+const (
+	SourceCycles   Source = "cycles"
+	SourceWorkouts Source = "workouts"
+)
+
+// Row is a cycle joined with its recovery and main sleep. Workout is only set
+// for SourceWorkouts. Prev is the cycle before, so a query can ask about the
+// day after something happened; it is nil for the oldest cycle loaded.
+type Row struct {
+	Cycle    whoop.Cycle
+	Recovery *whoop.Recovery
+	Sleep    *whoop.Sleep
+	Workout  *whoop.Workout
+	Prev     *Row
+}
+
+// lookback is how far before the range Load reads, so the first cycle in it
+// still has a Prev.
+const lookback = 2 * 24 * time.Hour
+
+// Load joins the cached records that start within [start, end] into rows for
+// src, oldest first.
+func Load(ctx context.Context, repo *repository.Repository, src Source, start, end time.Time) ([]Row, error) {
+	cycles, err := repository.Collect(ctx, func(ctx context.Context, cursor *repository.CursorParams) (*repository.CursorResult[whoop.Cycle], error) {
+		return repo.Cycles.GetByDateRange(ctx, start.Add(-lookback), end, cursor)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cycles: %w", err)
+	}
+	// the repository pages newest first
+	slices.Reverse(cycles)
+
+	ids := make([]int64, len(cycles))
+	for i, c := range cycles {
+		ids[i] = c.ID
+	}
+	recoveries, err := repo.Recoveries.GetByCycleIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recoveries: %w", err)
+	}
+
+	// a cycle's sleep starts before the cycle does, so read a day further back
+	sleeps, err := repository.Collect(ctx, func(ctx context.Context, cursor *repository.CursorParams) (*repository.CursorResult[whoop.Sleep], error) {
+		return repo.Sleeps.GetByDateRange(ctx, start.Add(-lookback-24*time.Hour), end, cursor)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sleeps: %w", err)
+	}
+
+	days := join(cycles, recoveries, sleeps)
+
+	switch src {
+	case SourceCycles:
+		return inRange(days, start), nil
+	case SourceWorkouts:
+		workouts, err := repository.Collect(ctx, func(ctx context.Context, cursor *repository.CursorParams) (*repository.CursorResult[whoop.Workout], error) {
+			return repo.Workouts.GetByDateRange(ctx, start, end, cursor)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workouts: %w", err)
+		}
+		slices.Reverse(workouts)
+		return joinWorkouts(days, workouts), nil
+	default:
+		return nil, fmt.Errorf("unknown source %q", src)
+	}
+}
+
+// join builds a row per cycle, oldest first, linked to the one before it.
+func join(cycles []whoop.Cycle, recoveries []whoop.Recovery, sleeps []whoop.Sleep) []Row {
+	recoveryByCycle := make(map[int64]*whoop.Recovery, len(recoveries))
+	for i := range recoveries {
+		recoveryByCycle[recoveries[i].CycleID] = &recoveries[i]
+	}
+	sleepByCycle := make(map[int64]*whoop.Sleep, len(sleeps))
+	for i := range sleeps {
+		if !sleeps[i].Nap {
+			sleepByCycle[sleeps[i].CycleID] = &sleeps[i]
+		}
+	}
+
+	rows := make([]Row, len(cycles))
+	for i, c := range cycles {
+		rows[i] = Row{
+			Cycle:    c,
+			Recovery: recoveryByCycle[c.ID],
+			Sleep:    sleepByCycle[c.ID],
+		}
+		if i > 0 {
+			rows[i].Prev = &rows[i-1]
+		}
+	}
+	return rows
+}
+
+// inRange drops the lookback cycles, keeping those starting at or after start.
+func inRange(rows []Row, start time.Time) []Row {
+	i := slices.IndexFunc(rows, func(r Row) bool { return !r.Cycle.Start.Before(start) })
+	if i < 0 {
+		return nil
+	}
+	return rows[i:]
+}
+
+// joinWorkouts gives each workout the row of the cycle it started in.
+// Workouts outside every loaded cycle are dropped.
+func joinWorkouts(days []Row, workouts []whoop.Workout) []Row {
+	rows := make([]Row, 0, len(workouts))
+	for i := range workouts {
+		w := &workouts[i]
+		// the last cycle starting at or before the workout
+		j, found := slices.BinarySearchFunc(days, w.Start, func(r Row, t time.Time) int {
+			return r.Cycle.Start.Compare(t)
+		})
+		if !found {
+			j--
+		}
+		if j < 0 {
+			continue
+		}
+		if end := days[j].Cycle.End; end != nil && !w.Start.Before(*end) {
+			continue
+		}
+
+		row := days[j]
+		row.Workout = w
+		rows = append(rows, row)
+	}
+	return rows
+}
@@ -0,0 +1,127 @@
+package query
+
+import (
+	"cmp"
+	"strconv"
+)
+
+// Kind is the type of a field or expression.
+type Kind uint8
+
+const (
+	KindNull Kind = iota
+	KindNumber
+	KindString
+	KindBool
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindNull:
+		return "null"
+	case KindNumber:
+		return "number"
+	case KindString:
+		return "string"
+	case KindBool:
+		return "bool"
+	default:
+		return "unknown"
+	}
+}
+
+// Value is a single field value. The zero Value is null, which is what
+// unscored or missing records read as.
+type Value struct {
+	Kind Kind
+	Num  float64
+	Str  string
+	Bool bool
+}
+
+func null() Value {
+	return Value{}
+}
+
+func number(n float64) Value {
+	return Value{Kind: KindNumber, Num: n}
+}
+
+func text(s string) Value {
+	return Value{Kind: KindString, Str: s}
+}
+
+func boolean(b bool) Value {
+	return Value{Kind: KindBool, Bool: b}
+}
+
+// optional reads a nullable number.
+func optional(n *float64) Value {
+	if n == nil {
+		return null()
+	}
+	return number(*n)
+}
+
+func (v Value) IsNull() bool {
+	return v.Kind == KindNull
+}
+
+func (v Value) truthy() bool {
+	return v.Kind == KindBool && v.Bool
+}
+
+// compare orders a and b, which must be the same non-null kind.
+func compare(a, b Value) int {
+	switch a.Kind {
+	case KindNumber:
+		return cmp.Compare(a.Num, b.Num)
+	case KindString:
+		return cmp.Compare(a.Str, b.Str)
+	case KindBool:
+		switch {
+		case a.Bool == b.Bool:
+			return 0
+		case a.Bool:
+			return 1
+		default:
+			return -1
+		}
+	case KindNull:
+		return 0
+	default:
+		return 0
+	}
+}
+
+// String formats v for CSV and table cells. Null is empty.
+func (v Value) String() string {
+	switch v.Kind {
+	case KindNumber:
+		return strconv.FormatFloat(v.Num, 'f', -1, 64)
+	case KindString:
+		return v.Str
+	case KindBool:
+		return strconv.FormatBool(v.Bool)
+	case KindNull:
+		return ""
+	default:
+		return ""
+	}
+}
+
+// any returns v as the Go value it marshals to in JSON.
+func (v Value) any() any {
+	switch v.Kind {
+	case KindNumber:
+		return v.Num
+	case KindString:
+		return v.Str
+	case KindBool:
+		return v.Bool
+	case KindNull:
+		return nil
+	default:
+		return nil
+	}
+}
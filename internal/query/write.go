@@ -0,0 +1,136 @@
+package query
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	go_json "github.com/goccy/go-json"
+)
+
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatCSV   Format = "csv"
+	FormatJSON  Format = "json"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatTable, FormatCSV, FormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown format %q: must be one of table, csv, json", s)
+	}
+}
+
+// Write writes result to w in format.
+func (r Result) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatTable:
+		return r.writeTable(w)
+	case FormatCSV:
+		return r.writeCSV(w)
+	case FormatJSON:
+		return r.writeJSON(w)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// writeTable aligns the columns for reading in a terminal. Numbers are
+// rounded to two places and nulls shown as "--".
+func (r Result) writeTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, strings.Join(r.Columns, "\t"))
+	cells := make([]string, len(r.Columns))
+	for _, row := range r.Rows {
+		for i, v := range row {
+			switch v.Kind {
+			case KindNull:
+				cells[i] = "--"
+			case KindNumber:
+				cells[i] = strconv.FormatFloat(v.Num, 'f', -1, 64)
+				if rounded := strconv.FormatFloat(v.Num, 'f', 2, 64); len(rounded) < len(cells[i]) {
+					cells[i] = rounded
+				}
+			case KindString, KindBool:
+				cells[i] = v.String()
+			default:
+				cells[i] = v.String()
+			}
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write table: %w", err)
+	}
+	return nil
+}
+
+// writeCSV writes a header row followed by one row per result. Nulls are
+// written as empty cells.
+func (r Result) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(r.Columns); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	cells := make([]string, len(r.Columns))
+	for _, row := range r.Rows {
+		for i, v := range row {
+			cells[i] = v.String()
+		}
+		if err := cw.Write(cells); err != nil {
+			return fmt.Errorf("failed to write row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to flush csv: %w", err)
+	}
+	return nil
+}
+
+// writeJSON writes a JSON array of objects with keys in column order.
+func (r Result) writeJSON(w io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, row := range r.Rows {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('{')
+		for j, v := range row {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := go_json.Marshal(r.Columns[j])
+			if err != nil {
+				return fmt.Errorf("failed to marshal key: %w", err)
+			}
+			value, err := go_json.Marshal(v.any())
+			if err != nil {
+				return fmt.Errorf("failed to marshal %s: %w", r.Columns[j], err)
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteString("]\n")
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write json: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package report
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"strings"
+	"text/template"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatMarkdown, FormatHTML:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown format %q: must be one of markdown, html", s)
+	}
+}
+
+//go:embed templates
+var templates embed.FS
+
+// funcs formats report values the same way in both templates. Missing
+// values print as "--". sparkline is only used by the HTML template, since
+// Markdown viewers don't render inline SVG.
+var funcs = map[string]any{
+	"title":     title,
+	"date":      func(t time.Time) string { return t.Format("Mon, Jan 2") },
+	"percent":   func(v *float64) string { return format(v, "%.0f%%") },
+	"ms":        func(v *float64) string { return format(v, "%.0fms") },
+	"bpm":       func(v *float64) string { return format(v, "%.0fbpm") },
+	"strain":    func(v *float64) string { return format(v, "%.1f") },
+	"hours":     formatHours,
+	"duration":  formatDuration,
+	"share":     func(v float64) string { return fmt.Sprintf("%.0f%%", v*100) },
+	"debt":      debtTrend,
+	"blocks":    blocks,
+	"series":    series,
+	"sparkline": sparkline,
+}
+
+// Render writes the report to w as a self-contained document.
+func Render(w io.Writer, f Format, r Report) error {
+	switch f {
+	case FormatMarkdown:
+		tmpl, err := template.New("report.md.tmpl").Funcs(funcs).ParseFS(templates, "templates/report.md.tmpl")
+		if err != nil {
+			return fmt.Errorf("failed to parse markdown template: %w", err)
+		}
+		if err := tmpl.Execute(w, r); err != nil {
+			return fmt.Errorf("failed to render markdown report: %w", err)
+		}
+		return nil
+	case FormatHTML:
+		tmpl, err := htmltemplate.New("report.html.tmpl").Funcs(funcs).ParseFS(templates, "templates/report.html.tmpl")
+		if err != nil {
+			return fmt.Errorf("failed to parse html template: %w", err)
+		}
+		if err := tmpl.Execute(w, r); err != nil {
+			return fmt.Errorf("failed to render html report: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q", f)
+	}
+}
+
+// title names the report after its period and range, such as
+// "Weekly report: Mar 8 – Mar 14, 2025".
+func title(r Report) string {
+	name := "Weekly"
+	if r.Period == PeriodMonth {
+		name = "Monthly"
+	}
+	return fmt.Sprintf("%s report: %s – %s", name, r.Start.Format("Jan 2"), r.End.Format("Jan 2, 2006"))
+}
+
+func format(v *float64, layout string) string {
+	if v == nil {
+		return "--"
+	}
+	return fmt.Sprintf(layout, *v)
+}
+
+func formatHours(v *float64) string {
+	if v == nil {
+		return "--"
+	}
+	return formatDuration(time.Duration(*v * float64(time.Hour)))
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// series picks a value from every day for a sparkline.
+func series(days []Day, name string) []*float64 {
+	values := make([]*float64, len(days))
+	for i, d := range days {
+		switch name {
+		case "recovery":
+			values[i] = d.Recovery
+		case "strain":
+			values[i] = d.Strain
+		case "sleep":
+			values[i] = d.Sleep
+		case "debt":
+			values[i] = d.SleepDebtHours
+		}
+	}
+	return values
+}
+
+// debtTrend describes how sleep debt moved over the period, such as
+// "1h 20m → 0h 35m, down 45m".
+func debtTrend(days []Day) string {
+	var first, last *float64
+	for _, d := range days {
+		if d.SleepDebtHours == nil {
+			continue
+		}
+		if first == nil {
+			first = d.SleepDebtHours
+		}
+		last = d.SleepDebtHours
+	}
+	if first == nil {
+		return "no scored sleep"
+	}
+
+	change := *last - *first
+	trend := "unchanged"
+	switch {
+	case change >= 1.0/60:
+		trend = "up " + formatDuration(time.Duration(change*float64(time.Hour)))
+	case change <= -1.0/60:
+		trend = "down " + formatDuration(time.Duration(-change*float64(time.Hour)))
+	}
+	return fmt.Sprintf("%s → %s, %s", formatHours(first), formatHours(last), trend)
+}
+
+// bounds returns the smallest and largest set value, and false when none are.
+func bounds(values []*float64) (float64, float64, bool) {
+	var (
+		lo, hi = math.Inf(1), math.Inf(-1)
+		ok     bool
+	)
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		lo, hi, ok = min(lo, *v), max(hi, *v), true
+	}
+	return lo, hi, ok
+}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// blocks draws values as a text sparkline for Markdown. Missing values are
+// left as spaces.
+func blocks(values []*float64) string {
+	lo, hi, ok := bounds(values)
+	if !ok {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		if v == nil {
+			b.WriteRune(' ')
+			continue
+		}
+		i := len(sparkBlocks) - 1
+		if hi > lo {
+			i = int((*v - lo) / (hi - lo) * float64(len(sparkBlocks)-1))
+		}
+		b.WriteRune(sparkBlocks[i])
+	}
+	return b.String()
+}
+
+const (
+	sparklineWidth  = 120
+	sparklineHeight = 24
+)
+
+// sparkline draws values as an inline SVG polyline for HTML. Missing values
+// break the line.
+func sparkline(values []*float64) htmltemplate.HTML {
+	lo, hi, ok := bounds(values)
+	if !ok || len(values) < 2 {
+		return ""
+	}
+
+	var (
+		step  = float64(sparklineWidth) / float64(len(values)-1)
+		lines []string
+		line  []string
+	)
+	for i, v := range values {
+		if v == nil {
+			if len(line) > 0 {
+				lines = append(lines, strings.Join(line, " "))
+			}
+			line = nil
+			continue
+		}
+		y := float64(sparklineHeight) / 2
+		if hi > lo {
+			// leave a pixel top and bottom so the stroke isn't clipped
+			y = 1 + (hi-*v)/(hi-lo)*float64(sparklineHeight-2)
+		}
+		line = append(line, fmt.Sprintf("%.1f,%.1f", float64(i)*step, y))
+	}
+	if len(line) > 0 {
+		lines = append(lines, strings.Join(line, " "))
+	}
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, `<svg class="spark" width="%d" height="%d" viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg">`,
+		sparklineWidth, sparklineHeight, sparklineWidth, sparklineHeight)
+	for _, points := range lines {
+		_, _ = fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="currentColor" stroke-width="1.5"/>`, points)
+	}
+	b.WriteString(`</svg>`)
+	return htmltemplate.HTML(b.String()) //nolint:gosec // built from formatted numbers only
+}
@@ -0,0 +1,255 @@
+// Package report aggregates cached WHOOP data over a week or month into a
+// recap rendered as Markdown or HTML.
+package report
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+	"github.com/garrettladley/thoop/internal/xtime"
+)
+
+type Period string
+
+const (
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+)
+
+func ParsePeriod(s string) (Period, error) {
+	switch p := Period(s); p {
+	case PeriodWeek, PeriodMonth:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown period %q: must be one of week, month", s)
+	}
+}
+
+// Range returns the days the period covers, ending with the day of end: the
+// 7 days for a week, or the calendar month so far for a month.
+func (p Period) Range(end time.Time) (time.Time, time.Time) {
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+	start := end.AddDate(0, 0, -6)
+	if p == PeriodMonth {
+		start = time.Date(end.Year(), end.Month(), 1, 0, 0, 0, 0, end.Location())
+	}
+	return start, end.AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
+// Data is the cached records a report is built from.
+type Data = repository.Records
+
+// Day is a cycle's scores. A score is nil until WHOOP has scored it.
+type Day struct {
+	Date       time.Time
+	Recovery   *float64
+	HRV        *float64
+	RHR        *float64
+	Strain     *float64
+	Sleep      *float64
+	SleepHours *float64
+	// SleepDebtHours is the sleep WHOOP added to the night's need to pay
+	// back earlier short nights.
+	SleepDebtHours *float64
+}
+
+type Averages struct {
+	Recovery   *float64
+	HRV        *float64
+	RHR        *float64
+	Strain     *float64
+	Sleep      *float64
+	SleepHours *float64
+}
+
+type Sport struct {
+	Name     string
+	Count    int
+	Duration time.Duration
+	Strain   float64
+}
+
+type Zone struct {
+	Zone     int
+	Duration time.Duration
+	// Share is the zone's fraction of all the time spent in zones.
+	Share float64
+}
+
+type Report struct {
+	Period Period
+	Start  time.Time
+	End    time.Time
+
+	Days     []Day
+	Averages Averages
+	// Best and Worst are the days with the highest and lowest recovery.
+	Best        *Day
+	Worst       *Day
+	TotalStrain float64
+
+	Workouts int
+	Sports   []Sport
+	Zones    []Zone
+}
+
+// Build aggregates data over the period from start to end, oldest day first.
+func Build(period Period, start, end time.Time, data *Data) Report {
+	r := Report{
+		Period:   period,
+		Start:    start,
+		End:      end,
+		Days:     days(data),
+		Workouts: len(data.Workouts),
+		Sports:   sports(data.Workouts),
+		Zones:    zones(data.Workouts),
+	}
+
+	r.Averages = Averages{
+		Recovery:   average(r.Days, func(d Day) *float64 { return d.Recovery }),
+		HRV:        average(r.Days, func(d Day) *float64 { return d.HRV }),
+		RHR:        average(r.Days, func(d Day) *float64 { return d.RHR }),
+		Strain:     average(r.Days, func(d Day) *float64 { return d.Strain }),
+		Sleep:      average(r.Days, func(d Day) *float64 { return d.Sleep }),
+		SleepHours: average(r.Days, func(d Day) *float64 { return d.SleepHours }),
+	}
+
+	for i := range r.Days {
+		d := &r.Days[i]
+		if d.Strain != nil {
+			r.TotalStrain += *d.Strain
+		}
+		if d.Recovery == nil {
+			continue
+		}
+		if r.Best == nil || *d.Recovery > *r.Best.Recovery {
+			r.Best = d
+		}
+		if r.Worst == nil || *d.Recovery < *r.Worst.Recovery {
+			r.Worst = d
+		}
+	}
+
+	return r
+}
+
+func days(data *Data) []Day {
+	recoveryByCycle := make(map[int64]*whoop.Recovery, len(data.Recoveries))
+	for i := range data.Recoveries {
+		recoveryByCycle[data.Recoveries[i].CycleID] = &data.Recoveries[i]
+	}
+	sleepByCycle := make(map[int64]*whoop.Sleep, len(data.Sleeps))
+	for i := range data.Sleeps {
+		if !data.Sleeps[i].Nap {
+			sleepByCycle[data.Sleeps[i].CycleID] = &data.Sleeps[i]
+		}
+	}
+
+	days := make([]Day, 0, len(data.Cycles))
+	for _, c := range data.Cycles {
+		d := Day{Date: c.Start.In(whoop.Location(c.TimezoneOffset))}
+		if c.Score != nil {
+			d.Strain = &c.Score.Strain
+		}
+		if r := recoveryByCycle[c.ID]; r != nil && r.Score != nil {
+			d.Recovery = &r.Score.RecoveryScore
+			d.HRV = &r.Score.HRVRmssdMilli
+			d.RHR = &r.Score.RestingHeartRate
+		}
+		if s := sleepByCycle[c.ID]; s != nil && s.Score != nil {
+			stages := s.Score.StageSummary
+			var (
+				asleep = xtime.Millis(stages.TotalSleepTimeMilli()).Hours()
+				debt   = xtime.Millis(s.Score.SleepNeeded.NeedFromSleepDebtMilli).Hours()
+			)
+			d.Sleep = &s.Score.SleepPerformancePercentage
+			d.SleepHours = &asleep
+			d.SleepDebtHours = &debt
+		}
+		days = append(days, d)
+	}
+
+	slices.SortFunc(days, func(a, b Day) int { return a.Date.Compare(b.Date) })
+	return days
+}
+
+// average is the mean of the days value is set for, or nil when it is never set.
+func average(days []Day, value func(Day) *float64) *float64 {
+	var (
+		sum float64
+		n   int
+	)
+	for _, d := range days {
+		if v := value(d); v != nil {
+			sum += *v
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	avg := sum / float64(n)
+	return &avg
+}
+
+// sports groups workouts by sport, most frequent first.
+func sports(workouts []whoop.Workout) []Sport {
+	bySport := make(map[string]*Sport)
+	for _, w := range workouts {
+		s, ok := bySport[w.SportName]
+		if !ok {
+			s = &Sport{Name: w.SportName}
+			bySport[w.SportName] = s
+		}
+		s.Count++
+		s.Duration += w.End.Sub(w.Start)
+		if w.Score != nil {
+			s.Strain += w.Score.Strain
+		}
+	}
+
+	sports := make([]Sport, 0, len(bySport))
+	for _, s := range bySport {
+		sports = append(sports, *s)
+	}
+	slices.SortFunc(sports, func(a, b Sport) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Name, b.Name))
+	})
+	return sports
+}
+
+// zones totals the time every workout spent in each heart rate zone.
+func zones(workouts []whoop.Workout) []Zone {
+	var millis [6]int
+	for _, w := range workouts {
+		if w.Score == nil {
+			continue
+		}
+		z := w.Score.ZoneDurations
+		for i, ms := range []int{z.ZoneZeroMilli, z.ZoneOneMilli, z.ZoneTwoMilli, z.ZoneThreeMilli, z.ZoneFourMilli, z.ZoneFiveMilli} {
+			millis[i] += ms
+		}
+	}
+
+	var total int
+	for _, ms := range millis {
+		total += ms
+	}
+	if total == 0 {
+		return nil
+	}
+
+	zones := make([]Zone, len(millis))
+	for i, ms := range millis {
+		zones[i] = Zone{
+			Zone:     i,
+			Duration: time.Duration(ms) * time.Millisecond,
+			Share:    float64(ms) / float64(total),
+		}
+	}
+	return zones
+}
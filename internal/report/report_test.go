@@ -0,0 +1,194 @@
+package report
+
+import (
+	"bytes"
+	"embed"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+)
+
+//go:embed testdata/*.golden
+var goldenFiles embed.FS
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+const hour = 3600_000
+
+func testData() *Data {
+	start := time.Date(2025, 3, 10, 11, 0, 0, 0, time.UTC)
+	cycle := func(id int64, day int, strain float64) whoop.Cycle {
+		return whoop.Cycle{
+			ID:             id,
+			Start:          start.AddDate(0, 0, day),
+			TimezoneOffset: "-05:00",
+			Score:          &whoop.CycleScore{Strain: strain},
+		}
+	}
+	recovery := func(id int64, score, hrv, rhr float64) whoop.Recovery {
+		return whoop.Recovery{CycleID: id, Score: &whoop.RecoveryScore{RecoveryScore: score, HRVRmssdMilli: hrv, RestingHeartRate: rhr}}
+	}
+	sleep := func(id int64, performance float64, asleep, debt int) whoop.Sleep {
+		return whoop.Sleep{CycleID: id, Score: &whoop.SleepScore{
+			SleepPerformancePercentage: performance,
+			StageSummary:               whoop.SleepStages{TotalLightSleepTimeMilli: asleep},
+			SleepNeeded:                whoop.SleepNeeded{NeedFromSleepDebtMilli: debt},
+		}}
+	}
+	workout := func(sport string, day int, minutes int, strain float64, zones whoop.WorkoutZones) whoop.Workout {
+		s := start.AddDate(0, 0, day).Add(8 * time.Hour)
+		return whoop.Workout{
+			SportName: sport,
+			Start:     s,
+			End:       s.Add(time.Duration(minutes) * time.Minute),
+			Score:     &whoop.WorkoutScore{Strain: strain, ZoneDurations: zones},
+		}
+	}
+
+	return &Data{
+		// newest first, as the repository returns them
+		Cycles: []whoop.Cycle{
+			cycle(3, 2, 9.5),
+			cycle(2, 1, 15.25),
+			cycle(1, 0, 10),
+			{ID: 4, Start: start.AddDate(0, 0, 3), TimezoneOffset: "-05:00"},
+		},
+		Recoveries: []whoop.Recovery{
+			recovery(1, 72, 58, 51),
+			recovery(2, 33, 41, 56),
+			recovery(3, 88, 66, 49),
+		},
+		Sleeps: []whoop.Sleep{
+			sleep(1, 90, 7*hour+30*60_000, hour),
+			sleep(2, 65, 6*hour, 2*hour),
+			{CycleID: 2, Nap: true, Score: &whoop.SleepScore{SleepPerformancePercentage: 10}},
+			sleep(3, 95, 8*hour, 30*60_000),
+		},
+		Workouts: []whoop.Workout{
+			workout("running", 0, 45, 11.2, whoop.WorkoutZones{ZoneTwoMilli: 20 * 60_000, ZoneThreeMilli: 25 * 60_000}),
+			workout("cycling", 1, 90, 14.5, whoop.WorkoutZones{ZoneOneMilli: 30 * 60_000, ZoneTwoMilli: 60 * 60_000}),
+			workout("running", 2, 30, 8.1, whoop.WorkoutZones{ZoneFourMilli: 15 * 60_000, ZoneFiveMilli: 15 * 60_000}),
+		},
+	}
+}
+
+func testReport() Report {
+	start, end := PeriodWeek.Range(time.Date(2025, 3, 16, 9, 0, 0, 0, time.UTC))
+	return Build(PeriodWeek, start, end, testData())
+}
+
+func TestPeriodRange(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2025, 3, 31, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		period    Period
+		wantStart time.Time
+	}{
+		{PeriodWeek, time.Date(2025, 3, 25, 0, 0, 0, 0, time.UTC)},
+		{PeriodMonth, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	wantEnd := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)
+	for _, tt := range tests {
+		start, end := tt.period.Range(at)
+		if !start.Equal(tt.wantStart) || !end.Equal(wantEnd) {
+			t.Errorf("%s Range() = %v, %v, want %v, %v", tt.period, start, end, tt.wantStart, wantEnd)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	r := testReport()
+
+	if len(r.Days) != 4 || r.Days[0].Date.Day() != 10 || r.Days[3].Date.Day() != 13 {
+		t.Fatalf("Days = %+v, want Mar 10 to 13 oldest first", r.Days)
+	}
+	if r.TotalStrain != 34.75 {
+		t.Errorf("TotalStrain = %v, want 34.75", r.TotalStrain)
+	}
+	if got := *r.Averages.Recovery; got != 64.33333333333333 {
+		t.Errorf("average recovery = %v, want 64.33", got)
+	}
+	if got := *r.Averages.Sleep; got != 250.0/3 {
+		t.Errorf("average sleep = %v, want 83.33 without the nap", got)
+	}
+	if r.Best.Date.Day() != 12 || r.Worst.Date.Day() != 11 {
+		t.Errorf("Best, Worst = %v, %v, want Mar 12 and Mar 11", r.Best.Date, r.Worst.Date)
+	}
+
+	wantSports := []Sport{
+		{Name: "running", Count: 2, Duration: 75 * time.Minute, Strain: 11.2 + 8.1},
+		{Name: "cycling", Count: 1, Duration: 90 * time.Minute, Strain: 14.5},
+	}
+	if diff := cmp.Diff(wantSports, r.Sports, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Errorf("Sports mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(r.Zones) != 6 || r.Zones[2].Duration != 80*time.Minute || r.Zones[2].Share != 80.0/165 {
+		t.Errorf("Zones = %+v, want 80 minutes of 165 in zone 2", r.Zones)
+	}
+}
+
+func TestBuild_Empty(t *testing.T) {
+	t.Parallel()
+
+	r := Build(PeriodMonth, time.Time{}, time.Time{}, &Data{})
+	if r.Best != nil || r.Averages.Recovery != nil || r.Zones != nil || len(r.Sports) != 0 {
+		t.Errorf("Build() = %+v, want an empty report", r)
+	}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, FormatHTML, r); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+}
+
+func TestRender_Golden(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		format Format
+		golden string
+	}{
+		{FormatMarkdown, "week.md.golden"},
+		{FormatHTML, "week.html.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			if err := Render(&buf, tt.format, testReport()); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			got := buf.String()
+
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(path, []byte(got), 0o600); err != nil {
+					t.Fatalf("failed to write golden file: %v", err)
+				}
+				return
+			}
+
+			want, err := goldenFiles.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+			if diff := cmp.Diff(string(want), got); diff != "" {
+				t.Errorf("Render() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
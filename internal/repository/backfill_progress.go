@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sqlitec "github.com/garrettladley/thoop/internal/sqlc/sqlite"
+)
+
+type backfillProgressRepo struct {
+	q sqlitec.Querier
+}
+
+func (r *backfillProgressRepo) Get(ctx context.Context, entity BackfillEntity) (*BackfillProgress, error) {
+	row, err := r.q.GetBackfillProgress(ctx, string(entity))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *backfillProgressRepo) Upsert(ctx context.Context, progress *BackfillProgress) error {
+	var exhausted int64
+	if progress.Exhausted {
+		exhausted = 1
+	}
+
+	err := r.q.UpsertBackfillProgress(ctx, sqlitec.UpsertBackfillProgressParams{
+		EntityType: string(progress.Entity),
+		RangeEnd:   progress.RangeEnd,
+		Watermark:  progress.Watermark,
+		Records:    int64(progress.Records),
+		Exhausted:  exhausted,
+	})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+func (r *backfillProgressRepo) toDomain(row sqlitec.BackfillProgress) *BackfillProgress {
+	return &BackfillProgress{
+		Entity:    BackfillEntity(row.EntityType),
+		RangeEnd:  row.RangeEnd,
+		Watermark: row.Watermark,
+		Records:   int(row.Records),
+		Exhausted: row.Exhausted == 1,
+	}
+}
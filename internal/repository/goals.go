@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	sqlitec "github.com/garrettladley/thoop/internal/sqlc/sqlite"
+)
+
+type goalRepo struct {
+	q sqlitec.Querier
+}
+
+func (r *goalRepo) Create(ctx context.Context, goal *Goal) error {
+	var count *int64
+	if goal.Count != nil {
+		c := int64(*goal.Count)
+		count = &c
+	}
+
+	row, err := r.q.CreateGoal(ctx, sqlitec.CreateGoalParams{
+		Metric:     goal.Metric,
+		Comparison: goal.Comparison,
+		Value:      goal.Value,
+		Count:      count,
+		Period:     goal.Period,
+	})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	*goal = *r.toDomain(row)
+	return nil
+}
+
+func (r *goalRepo) List(ctx context.Context) ([]Goal, error) {
+	rows, err := r.q.ListGoals(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	goals := make([]Goal, len(rows))
+	for i, row := range rows {
+		goals[i] = *r.toDomain(row)
+	}
+	return goals, nil
+}
+
+func (r *goalRepo) Delete(ctx context.Context, id int64) (bool, error) {
+	n, err := r.q.DeleteGoal(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("%w", err)
+	}
+	return n > 0, nil
+}
+
+func (r *goalRepo) toDomain(row sqlitec.Goal) *Goal {
+	goal := &Goal{
+		ID:         row.ID,
+		Metric:     row.Metric,
+		Comparison: row.Comparison,
+		Value:      row.Value,
+		Period:     row.Period,
+		CreatedAt:  row.CreatedAt,
+	}
+	if row.Count != nil {
+		c := int(*row.Count)
+		goal.Count = &c
+	}
+	return goal
+}
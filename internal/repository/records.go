@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+)
+
+// Records are the cached records around a range of cycles.
+type Records struct {
+	Cycles     []whoop.Cycle
+	Recoveries []whoop.Recovery
+	Sleeps     []whoop.Sleep
+	Workouts   []whoop.Workout
+}
+
+// LoadRecords reads the cycles and workouts starting within [start, end], the
+// cycles' recoveries, and the sleeps that may belong to them.
+func LoadRecords(ctx context.Context, repo *Repository, start, end time.Time) (*Records, error) {
+	var (
+		records = &Records{}
+		err     error
+	)
+
+	records.Cycles, err = Collect(ctx, func(ctx context.Context, cursor *CursorParams) (*CursorResult[whoop.Cycle], error) {
+		return repo.Cycles.GetByDateRange(ctx, start, end, cursor)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cycles: %w", err)
+	}
+
+	ids := make([]int64, len(records.Cycles))
+	for i, c := range records.Cycles {
+		ids[i] = c.ID
+	}
+	if records.Recoveries, err = repo.Recoveries.GetByCycleIDs(ctx, ids); err != nil {
+		return nil, fmt.Errorf("failed to get recoveries: %w", err)
+	}
+
+	// a cycle's sleep can start up to a day before the cycle itself
+	records.Sleeps, err = Collect(ctx, func(ctx context.Context, cursor *CursorParams) (*CursorResult[whoop.Sleep], error) {
+		return repo.Sleeps.GetByDateRange(ctx, start.Add(-24*time.Hour), end, cursor)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sleeps: %w", err)
+	}
+
+	records.Workouts, err = Collect(ctx, func(ctx context.Context, cursor *CursorParams) (*CursorResult[whoop.Workout], error) {
+		return repo.Workouts.GetByDateRange(ctx, start, end, cursor)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workouts: %w", err)
+	}
+
+	return records, nil
+}
@@ -73,6 +73,14 @@ func (r *recoveryRepo) GetByCycleIDs(ctx context.Context, cycleIDs []int64) ([]w
 	return r.toDomainSlice(rows)
 }
 
+func (r *recoveryRepo) GetPending(ctx context.Context) ([]whoop.Recovery, error) {
+	rows, err := r.q.GetPendingRecoveries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return r.toDomainSlice(rows)
+}
+
 func (r *recoveryRepo) toDomain(row sqlitec.Recovery) (*whoop.Recovery, error) {
 	recovery := &whoop.Recovery{
 		CycleID:    row.CycleID,
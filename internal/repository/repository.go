@@ -9,20 +9,24 @@ import (
 )
 
 type Repository struct {
-	SyncState  SyncStateRepository
-	Cycles     CycleRepository
-	Recoveries RecoveryRepository
-	Sleeps     SleepRepository
-	Workouts   WorkoutRepository
+	SyncState        SyncStateRepository
+	BackfillProgress BackfillProgressRepository
+	Cycles           CycleRepository
+	Recoveries       RecoveryRepository
+	Sleeps           SleepRepository
+	Workouts         WorkoutRepository
+	Goals            GoalRepository
 }
 
 func New(q sqlitec.Querier) *Repository {
 	return &Repository{
-		SyncState:  &syncStateRepo{q: q},
-		Cycles:     &cycleRepo{q: q},
-		Recoveries: &recoveryRepo{q: q},
-		Sleeps:     &sleepRepo{q: q},
-		Workouts:   &workoutRepo{q: q},
+		SyncState:        &syncStateRepo{q: q},
+		BackfillProgress: &backfillProgressRepo{q: q},
+		Cycles:           &cycleRepo{q: q},
+		Recoveries:       &recoveryRepo{q: q},
+		Sleeps:           &sleepRepo{q: q},
+		Workouts:         &workoutRepo{q: q},
+		Goals:            &goalRepo{q: q},
 	}
 }
 
@@ -55,6 +59,39 @@ type SyncStateRepository interface {
 	UpdateLastNotificationPoll(ctx context.Context, pollTime time.Time) error
 }
 
+// BackfillEntity identifies an entity type that is backfilled with its own watermark.
+type BackfillEntity string
+
+const (
+	BackfillEntityCycle    BackfillEntity = "cycle"
+	BackfillEntityRecovery BackfillEntity = "recovery"
+	BackfillEntitySleep    BackfillEntity = "sleep"
+	BackfillEntityWorkout  BackfillEntity = "workout"
+)
+
+// BackfillEntities lists every backfilled entity type.
+var BackfillEntities = []BackfillEntity{
+	BackfillEntityCycle,
+	BackfillEntityRecovery,
+	BackfillEntitySleep,
+	BackfillEntityWorkout,
+}
+
+// BackfillProgress records how far back an entity type has been backfilled.
+// The fetched range [Watermark, RangeEnd] is contiguous.
+type BackfillProgress struct {
+	Entity    BackfillEntity
+	RangeEnd  time.Time  // newest start covered, set when the backfill first ran
+	Watermark *time.Time // oldest start covered, nil until the first page lands
+	Records   int        // records fetched so far
+	Exhausted bool       // the API has no records older than Watermark
+}
+
+type BackfillProgressRepository interface {
+	Get(ctx context.Context, entity BackfillEntity) (*BackfillProgress, error)
+	Upsert(ctx context.Context, progress *BackfillProgress) error
+}
+
 type CycleRepository interface {
 	Upsert(ctx context.Context, cycle *whoop.Cycle) error
 	UpsertBatch(ctx context.Context, cycles []whoop.Cycle) error
@@ -69,6 +106,7 @@ type RecoveryRepository interface {
 	UpsertBatch(ctx context.Context, recoveries []whoop.Recovery) error
 	Get(ctx context.Context, cycleID int64) (*whoop.Recovery, error)
 	GetByCycleIDs(ctx context.Context, cycleIDs []int64) ([]whoop.Recovery, error)
+	GetPending(ctx context.Context) ([]whoop.Recovery, error)
 	Delete(ctx context.Context, cycleID int64) error
 }
 
@@ -78,6 +116,7 @@ type SleepRepository interface {
 	Get(ctx context.Context, id string) (*whoop.Sleep, error)
 	GetByCycleID(ctx context.Context, cycleID int64) (*whoop.Sleep, error)
 	GetByDateRange(ctx context.Context, start, end time.Time, cursor *CursorParams) (*CursorResult[whoop.Sleep], error)
+	GetPending(ctx context.Context) ([]whoop.Sleep, error)
 	Delete(ctx context.Context, id string) error
 }
 
@@ -86,5 +125,52 @@ type WorkoutRepository interface {
 	UpsertBatch(ctx context.Context, workouts []whoop.Workout) error
 	Get(ctx context.Context, id string) (*whoop.Workout, error)
 	GetByDateRange(ctx context.Context, start, end time.Time, cursor *CursorParams) (*CursorResult[whoop.Workout], error)
+	GetPending(ctx context.Context) ([]whoop.Workout, error)
 	Delete(ctx context.Context, id string) error
 }
+
+// Goal is a target the user has set, stored as written; the goals package
+// gives the fields their meaning. Count is nil for a goal on the period's
+// average rather than on how many days meet it.
+type Goal struct {
+	ID         int64
+	Metric     string
+	Comparison string
+	Value      float64
+	Count      *int
+	Period     string
+	CreatedAt  *time.Time
+}
+
+type GoalRepository interface {
+	// Create stores goal, filling in its ID and CreatedAt.
+	Create(ctx context.Context, goal *Goal) error
+	List(ctx context.Context) ([]Goal, error)
+	// Delete removes the goal with id, reporting whether there was one.
+	Delete(ctx context.Context, id int64) (bool, error)
+}
+
+// PageFunc fetches a single page of a cursor-paginated query.
+type PageFunc[T any] func(ctx context.Context, cursor *CursorParams) (*CursorResult[T], error)
+
+// Collect pages through a cursor-paginated query and returns every record.
+func Collect[T any](ctx context.Context, page PageFunc[T]) ([]T, error) {
+	var (
+		records []T
+		cursor  = &CursorParams{Limit: DefaultPageSize}
+	)
+
+	for {
+		result, err := page(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, result.Records...)
+
+		if result.NextCursor == nil {
+			return records, nil
+		}
+		cursor = &CursorParams{Limit: DefaultPageSize, Cursor: result.NextCursor}
+	}
+}
@@ -135,6 +135,14 @@ func (r *sleepRepo) GetByDateRange(ctx context.Context, start, end time.Time, cu
 	return result, nil
 }
 
+func (r *sleepRepo) GetPending(ctx context.Context) ([]whoop.Sleep, error) {
+	rows, err := r.q.GetPendingSleeps(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return r.toDomainSlice(rows)
+}
+
 func (r *sleepRepo) toDomain(row sqlitec.Sleep) (*whoop.Sleep, error) {
 	sleep := &whoop.Sleep{
 		ID:             row.ID,
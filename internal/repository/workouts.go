@@ -117,6 +117,14 @@ func (r *workoutRepo) GetByDateRange(ctx context.Context, start, end time.Time,
 	return result, nil
 }
 
+func (r *workoutRepo) GetPending(ctx context.Context) ([]whoop.Workout, error) {
+	rows, err := r.q.GetPendingWorkouts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return r.toDomainSlice(rows)
+}
+
 func (r *workoutRepo) toDomain(row sqlitec.Workout) (*whoop.Workout, error) {
 	workout := &whoop.Workout{
 		ID:             row.ID,
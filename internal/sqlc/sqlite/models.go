@@ -8,6 +8,15 @@ import (
 	"time"
 )
 
+type BackfillProgress struct {
+	EntityType string     `json:"entity_type"`
+	RangeEnd   time.Time  `json:"range_end"`
+	Watermark  *time.Time `json:"watermark"`
+	Records    int64      `json:"records"`
+	Exhausted  int64      `json:"exhausted"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
+
 type Cycle struct {
 	ID             int64      `json:"id"`
 	UserID         int64      `json:"user_id"`
@@ -21,6 +30,16 @@ type Cycle struct {
 	FetchedAt      time.Time  `json:"fetched_at"`
 }
 
+type Goal struct {
+	ID         int64      `json:"id"`
+	Metric     string     `json:"metric"`
+	Comparison string     `json:"comparison"`
+	Value      float64    `json:"value"`
+	Count      *int64     `json:"count"`
+	Period     string     `json:"period"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
+
 type Recovery struct {
 	CycleID    int64     `json:"cycle_id"`
 	SleepID    string    `json:"sleep_id"`
@@ -10,12 +10,15 @@ import (
 )
 
 type Querier interface {
+	CreateGoal(ctx context.Context, arg CreateGoalParams) (Goal, error)
 	DeleteCycle(ctx context.Context, id int64) error
+	DeleteGoal(ctx context.Context, id int64) (int64, error)
 	DeleteRecovery(ctx context.Context, cycleID int64) error
 	DeleteSleep(ctx context.Context, id string) error
 	DeleteToken(ctx context.Context) error
 	DeleteWorkout(ctx context.Context, id string) error
 	GetAPIKey(ctx context.Context) (*string, error)
+	GetBackfillProgress(ctx context.Context, entityType string) (BackfillProgress, error)
 	GetCycle(ctx context.Context, id int64) (Cycle, error)
 	GetCyclesByDateRange(ctx context.Context, arg GetCyclesByDateRangeParams) ([]Cycle, error)
 	GetCyclesByDateRangeCursor(ctx context.Context, arg GetCyclesByDateRangeCursorParams) ([]Cycle, error)
@@ -23,6 +26,9 @@ type Querier interface {
 	GetLatestCycles(ctx context.Context, limit int64) ([]Cycle, error)
 	GetNapsByCycleID(ctx context.Context, cycleID int64) ([]Sleep, error)
 	GetPendingCycles(ctx context.Context) ([]Cycle, error)
+	GetPendingRecoveries(ctx context.Context) ([]Recovery, error)
+	GetPendingSleeps(ctx context.Context) ([]Sleep, error)
+	GetPendingWorkouts(ctx context.Context) ([]Workout, error)
 	GetRecoveriesByCycleIDs(ctx context.Context, cycleIds []int64) ([]Recovery, error)
 	GetRecovery(ctx context.Context, cycleID int64) (Recovery, error)
 	GetSleep(ctx context.Context, id string) (Sleep, error)
@@ -35,11 +41,13 @@ type Querier interface {
 	GetWorkoutsByCycleID(ctx context.Context, cycleID int64) ([]Workout, error)
 	GetWorkoutsByDateRange(ctx context.Context, arg GetWorkoutsByDateRangeParams) ([]Workout, error)
 	GetWorkoutsByDateRangeCursor(ctx context.Context, arg GetWorkoutsByDateRangeCursorParams) ([]Workout, error)
+	ListGoals(ctx context.Context) ([]Goal, error)
 	MarkBackfillComplete(ctx context.Context) error
 	SetAPIKey(ctx context.Context, apiKey *string) error
 	UpdateBackfillWatermark(ctx context.Context, backfillWatermark *time.Time) error
 	UpdateLastFullSync(ctx context.Context, lastFullSync *time.Time) error
 	UpdateLastNotificationPoll(ctx context.Context, lastNotificationPoll *time.Time) error
+	UpsertBackfillProgress(ctx context.Context, arg UpsertBackfillProgressParams) error
 	UpsertCycle(ctx context.Context, arg UpsertCycleParams) error
 	UpsertRecovery(ctx context.Context, arg UpsertRecoveryParams) error
 	UpsertSleep(ctx context.Context, arg UpsertSleepParams) error
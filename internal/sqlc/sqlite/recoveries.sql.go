@@ -20,6 +20,42 @@ func (q *Queries) DeleteRecovery(ctx context.Context, cycleID int64) error {
 	return err
 }
 
+const getPendingRecoveries = `-- name: GetPendingRecoveries :many
+SELECT cycle_id, sleep_id, user_id, created_at, updated_at, score_state, score_json, fetched_at FROM recoveries WHERE score_state = 'PENDING_SCORE' ORDER BY created_at DESC
+`
+
+func (q *Queries) GetPendingRecoveries(ctx context.Context) ([]Recovery, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingRecoveries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Recovery{}
+	for rows.Next() {
+		var i Recovery
+		if err := rows.Scan(
+			&i.CycleID,
+			&i.SleepID,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ScoreState,
+			&i.ScoreJson,
+			&i.FetchedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getRecoveriesByCycleIDs = `-- name: GetRecoveriesByCycleIDs :many
 SELECT cycle_id, sleep_id, user_id, created_at, updated_at, score_state, score_json, fetched_at FROM recoveries WHERE cycle_id IN (/*SLICE:cycle_ids*/?)
 `
@@ -60,6 +60,47 @@ func (q *Queries) GetNapsByCycleID(ctx context.Context, cycleID int64) ([]Sleep,
 	return items, nil
 }
 
+const getPendingSleeps = `-- name: GetPendingSleeps :many
+SELECT id, cycle_id, v1_id, user_id, created_at, updated_at, start, "end", timezone_offset, nap, score_state, score_json, fetched_at FROM sleeps WHERE score_state = 'PENDING_SCORE' ORDER BY start DESC
+`
+
+func (q *Queries) GetPendingSleeps(ctx context.Context) ([]Sleep, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingSleeps)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Sleep{}
+	for rows.Next() {
+		var i Sleep
+		if err := rows.Scan(
+			&i.ID,
+			&i.CycleID,
+			&i.V1ID,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Start,
+			&i.End,
+			&i.TimezoneOffset,
+			&i.Nap,
+			&i.ScoreState,
+			&i.ScoreJson,
+			&i.FetchedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getSleep = `-- name: GetSleep :one
 SELECT id, cycle_id, v1_id, user_id, created_at, updated_at, start, "end", timezone_offset, nap, score_state, score_json, fetched_at FROM sleeps WHERE id = ?
 `
@@ -19,6 +19,46 @@ func (q *Queries) DeleteWorkout(ctx context.Context, id string) error {
 	return err
 }
 
+const getPendingWorkouts = `-- name: GetPendingWorkouts :many
+SELECT id, v1_id, user_id, created_at, updated_at, start, "end", timezone_offset, sport_name, score_state, score_json, fetched_at FROM workouts WHERE score_state = 'PENDING_SCORE' ORDER BY start DESC
+`
+
+func (q *Queries) GetPendingWorkouts(ctx context.Context) ([]Workout, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingWorkouts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Workout{}
+	for rows.Next() {
+		var i Workout
+		if err := rows.Scan(
+			&i.ID,
+			&i.V1ID,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Start,
+			&i.End,
+			&i.TimezoneOffset,
+			&i.SportName,
+			&i.ScoreState,
+			&i.ScoreJson,
+			&i.FetchedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getWorkout = `-- name: GetWorkout :one
 SELECT id, v1_id, user_id, created_at, updated_at, start, "end", timezone_offset, sport_name, score_state, score_json, fetched_at FROM workouts WHERE id = ?
 `
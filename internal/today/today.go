@@ -0,0 +1,149 @@
+// Package today summarises the current cycle for scripts, prompts and status bars.
+package today
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	go_json "github.com/goccy/go-json"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/xsync"
+	"github.com/garrettladley/thoop/internal/xtime"
+)
+
+// Summary is the current cycle's scores. A score is nil until WHOOP has
+// scored it, and printed as "--".
+type Summary struct {
+	CycleID          int64     `json:"cycle_id"`
+	Start            time.Time `json:"start"`
+	TimezoneOffset   string    `json:"timezone_offset"`
+	Recovery         *float64  `json:"recovery"`
+	HRV              *float64  `json:"hrv_rmssd_milli"`
+	RestingHeartRate *float64  `json:"resting_heart_rate"`
+	Strain           *float64  `json:"strain"`
+	Sleep            *float64  `json:"sleep_performance"`
+	SleepHours       *float64  `json:"sleep_hours"`
+}
+
+// Load reads the current cycle and its recovery and sleep through fetcher. It
+// returns nil when there is no cycle yet.
+func Load(ctx context.Context, fetcher xsync.DataFetcher) (*Summary, error) {
+	cycle, err := fetcher.GetCurrentCycle(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current cycle: %w", err)
+	}
+	if cycle == nil {
+		return nil, nil
+	}
+
+	// recovery and sleep may legitimately be missing for a fresh cycle, so
+	// their errors leave the scores unset rather than failing the summary
+	var (
+		recovery *whoop.Recovery
+		sleep    *whoop.Sleep
+		g        errgroup.Group
+	)
+	g.Go(func() error {
+		recovery, _ = fetcher.GetRecovery(ctx, cycle.ID)
+		return nil
+	})
+	g.Go(func() error {
+		sleep, _ = fetcher.GetSleep(ctx, cycle.ID)
+		return nil
+	})
+	_ = g.Wait()
+
+	return newSummary(cycle, recovery, sleep), nil
+}
+
+func newSummary(cycle *whoop.Cycle, recovery *whoop.Recovery, sleep *whoop.Sleep) *Summary {
+	s := &Summary{
+		CycleID:        cycle.ID,
+		Start:          cycle.Start,
+		TimezoneOffset: cycle.TimezoneOffset,
+	}
+	if cycle.Score != nil {
+		s.Strain = &cycle.Score.Strain
+	}
+	if recovery != nil && recovery.Score != nil {
+		s.Recovery = &recovery.Score.RecoveryScore
+		s.HRV = &recovery.Score.HRVRmssdMilli
+		s.RestingHeartRate = &recovery.Score.RestingHeartRate
+	}
+	if sleep != nil && sleep.Score != nil {
+		s.Sleep = &sleep.Score.SleepPerformancePercentage
+
+		hours := xtime.Millis(sleep.Score.StageSummary.TotalSleepTimeMilli()).Hours()
+		s.SleepHours = &hours
+	}
+	return s
+}
+
+// Line renders the summary on one line, such as
+// "recovery 64% · strain 12.4 · sleep 87%".
+func (s Summary) Line() string {
+	return strings.Join([]string{
+		"recovery " + format(s.Recovery, "%.0f%%"),
+		"strain " + format(s.Strain, "%.1f"),
+		"sleep " + format(s.Sleep, "%.0f%%"),
+	}, " · ")
+}
+
+// Long renders the summary over several lines, with the cycle's date first.
+func (s Summary) Long() string {
+	start := s.Start.In(whoop.Location(s.TimezoneOffset))
+
+	var b strings.Builder
+	_, _ = fmt.Fprintln(&b, start.Format("Mon, Jan 2"))
+	_, _ = fmt.Fprintf(&b, "recovery  %-5s  hrv %s  rhr %s\n",
+		format(s.Recovery, "%.0f%%"), format(s.HRV, "%.0fms"), format(s.RestingHeartRate, "%.0fbpm"))
+	_, _ = fmt.Fprintf(&b, "strain    %s\n", format(s.Strain, "%.1f"))
+	_, _ = fmt.Fprintf(&b, "sleep     %-5s  %s asleep", format(s.Sleep, "%.0f%%"), formatHours(s.SleepHours))
+	return b.String()
+}
+
+func format(v *float64, layout string) string {
+	if v == nil {
+		return "--"
+	}
+	return fmt.Sprintf(layout, *v)
+}
+
+func formatHours(hours *float64) string {
+	if hours == nil {
+		return "--"
+	}
+	d := time.Duration(*hours * float64(time.Hour)).Round(time.Minute)
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// WriteJSON writes the summary as a single JSON object.
+func (s Summary) WriteJSON(w io.Writer) error {
+	data, err := go_json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal summary: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, string(data)); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+	return nil
+}
+
+// ParseTemplate parses a text/template executed against a Summary. Scores
+// are pointers, so the template gets a score function that formats one the
+// way Line does: {{score .Recovery "%.0f%%"}} prints "64%", or "--" unscored.
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("today").Funcs(template.FuncMap{
+		"score": func(v *float64, layout string) string { return format(v, layout) },
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse format template: %w", err)
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,180 @@
+package today
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/xsync"
+)
+
+type stubFetcher struct {
+	xsync.DataFetcher
+
+	cycle    *whoop.Cycle
+	recovery *whoop.Recovery
+	sleep    *whoop.Sleep
+}
+
+func (f stubFetcher) GetCurrentCycle(context.Context) (*whoop.Cycle, error) {
+	return f.cycle, nil
+}
+
+func (f stubFetcher) GetRecovery(context.Context, int64) (*whoop.Recovery, error) {
+	if f.recovery == nil {
+		return nil, errors.New("not found")
+	}
+	return f.recovery, nil
+}
+
+func (f stubFetcher) GetSleep(context.Context, int64) (*whoop.Sleep, error) {
+	if f.sleep == nil {
+		return nil, errors.New("not found")
+	}
+	return f.sleep, nil
+}
+
+func scoredFetcher() stubFetcher {
+	return stubFetcher{
+		cycle: &whoop.Cycle{
+			ID:             1,
+			Start:          time.Date(2025, 3, 14, 11, 30, 0, 0, time.UTC),
+			TimezoneOffset: "-05:00",
+			Score:          &whoop.CycleScore{Strain: 12.43},
+		},
+		recovery: &whoop.Recovery{
+			CycleID: 1,
+			Score:   &whoop.RecoveryScore{RecoveryScore: 64, HRVRmssdMilli: 45.2, RestingHeartRate: 52},
+		},
+		sleep: &whoop.Sleep{
+			CycleID: 1,
+			Score: &whoop.SleepScore{
+				SleepPerformancePercentage: 87,
+				StageSummary: whoop.SleepStages{
+					TotalLightSleepTimeMilli:    4 * 3600_000,
+					TotalSlowWaveSleepTimeMilli: 90 * 60_000,
+					TotalREMSleepTimeMilli:      2*3600_000 + 2*60_000,
+				},
+			},
+		},
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		fetcher  stubFetcher
+		wantLine string
+		wantLong string
+	}{
+		{
+			name:     "scored",
+			fetcher:  scoredFetcher(),
+			wantLine: "recovery 64% · strain 12.4 · sleep 87%",
+			wantLong: "Fri, Mar 14\n" +
+				"recovery  64%    hrv 45ms  rhr 52bpm\n" +
+				"strain    12.4\n" +
+				"sleep     87%    7h 32m asleep",
+		},
+		{
+			name: "unscored",
+			fetcher: stubFetcher{cycle: &whoop.Cycle{
+				ID:    2,
+				Start: time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC),
+			}},
+			wantLine: "recovery -- · strain -- · sleep --",
+			wantLong: "Sat, Mar 15\n" +
+				"recovery  --     hrv --  rhr --\n" +
+				"strain    --\n" +
+				"sleep     --     -- asleep",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := Load(context.Background(), tt.fetcher)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := s.Line(); got != tt.wantLine {
+				t.Errorf("Line() = %q, want %q", got, tt.wantLine)
+			}
+			if got := s.Long(); got != tt.wantLong {
+				t.Errorf("Long() = %q, want %q", got, tt.wantLong)
+			}
+		})
+	}
+}
+
+func TestLoad_NoCycle(t *testing.T) {
+	t.Parallel()
+
+	s, err := Load(context.Background(), stubFetcher{})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if s != nil {
+		t.Errorf("Load() = %+v, want nil", s)
+	}
+}
+
+func TestParseTemplate(t *testing.T) {
+	t.Parallel()
+
+	s, err := Load(context.Background(), scoredFetcher())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{`{{score .Recovery "%.0f%%"}} {{score .Strain "%.1f"}}`, "64% 12.4"},
+		{`{{with .Sleep}}{{.}}{{end}}`, "87"},
+		{`{{.CycleID}}`, "1"},
+	}
+
+	for _, tt := range tests {
+		tmpl, err := ParseTemplate(tt.text)
+		if err != nil {
+			t.Fatalf("ParseTemplate(%q) error = %v", tt.text, err)
+		}
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, s); err != nil {
+			t.Fatalf("Execute(%q) error = %v", tt.text, err)
+		}
+		if got := b.String(); got != tt.want {
+			t.Errorf("Execute(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	s, err := Load(context.Background(), stubFetcher{cycle: &whoop.Cycle{
+		ID:             3,
+		Start:          time.Date(2025, 3, 16, 12, 0, 0, 0, time.UTC),
+		TimezoneOffset: "+01:00",
+	}})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var b bytes.Buffer
+	if err := s.WriteJSON(&b); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	want := `{"cycle_id":3,"start":"2025-03-16T12:00:00Z","timezone_offset":"+01:00","recovery":null,"hrv_rmssd_milli":null,"resting_heart_rate":null,"strain":null,"sleep_performance":null,"sleep_hours":null}` + "\n"
+	if got := b.String(); got != want {
+		t.Errorf("WriteJSON() = %s, want %s", got, want)
+	}
+}
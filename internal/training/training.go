@@ -0,0 +1,270 @@
+// Package training models training load from workouts: acute and chronic
+// load, their ratio, monotony and strain, overall and per sport.
+package training
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+const (
+	// AcuteDays and ChronicDays are the windows acute and chronic load
+	// average daily load over.
+	AcuteDays   = 7
+	ChronicDays = 28
+)
+
+// Measure is what a workout's load is counted in.
+type Measure string
+
+const (
+	// MeasureStrain counts WHOOP's workout strain.
+	MeasureStrain Measure = "strain"
+	// MeasureKilojoule counts the energy spent.
+	MeasureKilojoule Measure = "kilojoule"
+	// MeasureTRIMP counts minutes in each heart rate zone weighted by the
+	// zone, after Edwards' training impulse.
+	MeasureTRIMP Measure = "trimp"
+)
+
+// Measures lists every measure.
+var Measures = []Measure{MeasureStrain, MeasureKilojoule, MeasureTRIMP}
+
+// ParseMeasure parses a measure name.
+func ParseMeasure(s string) (Measure, error) {
+	m := Measure(s)
+	if !slices.Contains(Measures, m) {
+		return "", fmt.Errorf("unknown measure %q: want strain, kilojoule or trimp", s)
+	}
+	return m, nil
+}
+
+// Of returns the load of w, and false while w is unscored.
+func (m Measure) Of(w whoop.Workout) (float64, bool) {
+	if w.Score == nil {
+		return 0, false
+	}
+	switch m {
+	case MeasureKilojoule:
+		return w.Score.Kilojoule, true
+	case MeasureTRIMP:
+		z := w.Score.ZoneDurations
+		milli := z.ZoneOneMilli + 2*z.ZoneTwoMilli + 3*z.ZoneThreeMilli + 4*z.ZoneFourMilli + 5*z.ZoneFiveMilli
+		return (time.Duration(milli) * time.Millisecond).Minutes(), true
+	default:
+		return w.Score.Strain, true
+	}
+}
+
+// Zone is how the acute:chronic workload ratio relates to injury risk. It is
+// unknown without any chronic load to compare against.
+type Zone string
+
+const (
+	ZoneUnknown Zone = "unknown"
+	ZoneLow     Zone = "low"
+	ZoneOptimal Zone = "optimal"
+	ZoneCaution Zone = "caution"
+	ZoneDanger  Zone = "danger"
+)
+
+const (
+	lowRatio     = 0.8
+	cautionRatio = 1.3
+	dangerRatio  = 1.5
+)
+
+// ZoneFor places an acute:chronic workload ratio in Gabbett's zones: below
+// 0.8 is undertraining, up to 1.3 is the sweet spot, and injury risk climbs
+// from there, steeply past 1.5.
+func ZoneFor(acwr float64) Zone {
+	switch {
+	case acwr < lowRatio:
+		return ZoneLow
+	case acwr <= cautionRatio:
+		return ZoneOptimal
+	case acwr <= dangerRatio:
+		return ZoneCaution
+	default:
+		return ZoneDanger
+	}
+}
+
+// highMonotony is the monotony above which Foster found illness and injury
+// more likely.
+const highMonotony = 2.0
+
+// Workload is the load of a sport, or of every sport when Sport is empty.
+type Workload struct {
+	Sport string
+	// Workouts counts the workouts in the chronic window.
+	Workouts int
+	// Acute and Chronic are the average daily load over the last AcuteDays
+	// and ChronicDays, and ACWR is their ratio.
+	Acute   float64
+	Chronic float64
+	ACWR    float64
+	Zone    Zone
+	// Monotony is the mean daily load of the last week over its standard
+	// deviation, and Strain is the week's load times its monotony. Both are
+	// zero when the week's load didn't vary.
+	Monotony float64
+	Strain   float64
+	// Balance is chronic less acute load: negative while training harder
+	// than usual, positive while tapering.
+	Balance float64
+}
+
+// Warning flags a workload in a risky zone.
+type Warning struct {
+	Sport   string
+	Message string
+}
+
+func (w Warning) String() string {
+	if w.Sport == "" {
+		return w.Message
+	}
+	return w.Sport + ": " + w.Message
+}
+
+// Report is the workload as of a day.
+type Report struct {
+	Date    time.Time
+	Measure Measure
+	Total   Workload
+	// Sports are ordered by chronic load, heaviest first.
+	Sports   []Workload
+	Warnings []Warning
+}
+
+// Compute works out the workload over the ChronicDays ending on the local
+// date of at. Each workout counts on its own local date, so a workout away
+// from home lands on the day it was done there.
+func Compute(workouts []whoop.Workout, m Measure, at time.Time) Report {
+	end := date(at)
+	start := end.AddDate(0, 0, -(ChronicDays - 1))
+
+	total := make([]float64, ChronicDays)
+	var count int
+	bySport := make(map[string][]float64)
+	counts := make(map[string]int)
+	for _, w := range workouts {
+		load, ok := m.Of(w)
+		if !ok {
+			continue
+		}
+		day := date(w.Start.In(whoop.Location(w.TimezoneOffset)))
+		if day.Before(start) || day.After(end) {
+			continue
+		}
+
+		i := int(day.Sub(start).Hours() / 24)
+		total[i] += load
+		count++
+
+		if bySport[w.SportName] == nil {
+			bySport[w.SportName] = make([]float64, ChronicDays)
+		}
+		bySport[w.SportName][i] += load
+		counts[w.SportName]++
+	}
+
+	r := Report{
+		Date:    end,
+		Measure: m,
+		Total:   workload("", total, count),
+	}
+	for sport, daily := range bySport {
+		r.Sports = append(r.Sports, workload(sport, daily, counts[sport]))
+	}
+	slices.SortFunc(r.Sports, func(a, b Workload) int {
+		return cmp.Or(cmp.Compare(b.Chronic, a.Chronic), cmp.Compare(a.Sport, b.Sport))
+	})
+
+	r.Warnings = warnings(r.Total)
+	for _, s := range r.Sports {
+		r.Warnings = append(r.Warnings, warnings(s)...)
+	}
+	return r
+}
+
+// workload summarizes ChronicDays of daily loads, oldest first.
+func workload(sport string, daily []float64, workouts int) Workload {
+	week := daily[len(daily)-AcuteDays:]
+	w := Workload{
+		Sport:    sport,
+		Workouts: workouts,
+		Acute:    sum(week) / AcuteDays,
+		Chronic:  sum(daily) / ChronicDays,
+	}
+	w.Zone = ZoneUnknown
+	if w.Chronic > 0 {
+		w.ACWR = w.Acute / w.Chronic
+		w.Zone = ZoneFor(w.ACWR)
+	}
+	w.Balance = w.Chronic - w.Acute
+
+	var ss float64
+	for _, load := range week {
+		ss += (load - w.Acute) * (load - w.Acute)
+	}
+	if sd := math.Sqrt(ss / AcuteDays); sd > 0 {
+		w.Monotony = w.Acute / sd
+		w.Strain = sum(week) * w.Monotony
+	}
+	return w
+}
+
+func warnings(w Workload) []Warning {
+	var warnings []Warning
+	switch w.Zone {
+	case ZoneDanger:
+		warnings = append(warnings, Warning{Sport: w.Sport, Message: fmt.Sprintf(
+			"acute load is %.2f× chronic, past %.1f where injury risk climbs steeply", w.ACWR, dangerRatio)})
+	case ZoneCaution:
+		warnings = append(warnings, Warning{Sport: w.Sport, Message: fmt.Sprintf(
+			"acute load is %.2f× chronic, above the %.1f sweet spot", w.ACWR, cautionRatio)})
+	default:
+	}
+	if w.Monotony > highMonotony {
+		warnings = append(warnings, Warning{Sport: w.Sport, Message: fmt.Sprintf(
+			"monotony is %.1f, past %.1f; mix in harder and easier days", w.Monotony, highMonotony)})
+	}
+	return warnings
+}
+
+// Load reads the cached workouts Compute needs for a report as of at.
+func Load(ctx context.Context, repo *repository.Repository, at time.Time) ([]whoop.Workout, error) {
+	// a day of slack either side covers workouts whose local date differs
+	// from their UTC one
+	start := date(at).AddDate(0, 0, -ChronicDays)
+	workouts, err := repository.Collect(ctx, func(ctx context.Context, cursor *repository.CursorParams) (*repository.CursorResult[whoop.Workout], error) {
+		return repo.Workouts.GetByDateRange(ctx, start, at.Add(24*time.Hour), cursor)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workouts: %w", err)
+	}
+	return workouts, nil
+}
+
+// date returns the local calendar date of t as midnight UTC, so dates from
+// different timezones compare.
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func sum(values []float64) float64 {
+	var s float64
+	for _, v := range values {
+		s += v
+	}
+	return s
+}
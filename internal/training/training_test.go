@@ -0,0 +1,148 @@
+package training
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+)
+
+var at = time.Date(2025, 3, 28, 20, 0, 0, 0, time.UTC)
+
+// workout returns a scored workout daysAgo days before at.
+func workout(sport string, daysAgo int, strain float64) whoop.Workout {
+	start := at.AddDate(0, 0, -daysAgo).Add(-2 * time.Hour)
+	return whoop.Workout{
+		SportName:      sport,
+		Start:          start,
+		End:            start.Add(time.Hour),
+		TimezoneOffset: "+00:00",
+		Score:          &whoop.WorkoutScore{Strain: strain},
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	t.Parallel()
+
+	w := whoop.Workout{Score: &whoop.WorkoutScore{
+		Strain:    12,
+		Kilojoule: 2000,
+		ZoneDurations: whoop.WorkoutZones{
+			ZoneZeroMilli:  600_000,
+			ZoneOneMilli:   600_000,
+			ZoneThreeMilli: 1_200_000,
+			ZoneFiveMilli:  60_000,
+		},
+	}}
+
+	tests := []struct {
+		m    Measure
+		want float64
+	}{
+		{MeasureStrain, 12},
+		{MeasureKilojoule, 2000},
+		// 10 minutes in zone 1, 20 in zone 3 and 1 in zone 5
+		{MeasureTRIMP, 10 + 60 + 5},
+	}
+	for _, tt := range tests {
+		if got, ok := tt.m.Of(w); !ok || got != tt.want {
+			t.Errorf("%s.Of() = %v, %v, want %v", tt.m, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := MeasureStrain.Of(whoop.Workout{}); ok {
+		t.Error("Of() counted an unscored workout")
+	}
+}
+
+func TestZoneFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		acwr float64
+		want Zone
+	}{
+		{0, ZoneLow},
+		{0.5, ZoneLow},
+		{1, ZoneOptimal},
+		{1.3, ZoneOptimal},
+		{1.4, ZoneCaution},
+		{1.8, ZoneDanger},
+	}
+	for _, tt := range tests {
+		if got := ZoneFor(tt.acwr); got != tt.want {
+			t.Errorf("ZoneFor(%v) = %s, want %s", tt.acwr, got, tt.want)
+		}
+	}
+}
+
+func TestCompute(t *testing.T) {
+	t.Parallel()
+
+	var workouts []whoop.Workout
+	// three weeks of a 7 strain run every other day...
+	for d := 7; d < ChronicDays; d += 2 {
+		workouts = append(workouts, workout("running", d, 7))
+	}
+	// ...then a week of a 14 strain ride every day
+	for d := range AcuteDays {
+		workouts = append(workouts, workout("cycling", d, 14))
+	}
+	// outside the window and unscored
+	workouts = append(workouts, workout("running", ChronicDays, 20), whoop.Workout{SportName: "yoga", Start: at})
+
+	r := Compute(workouts, MeasureStrain, at)
+
+	// 11 runs and 7 rides: 77 + 98 over 28 days, 98 of it in the last week
+	want := Workload{
+		Workouts: 18,
+		Acute:    14,
+		Chronic:  (77 + 98) / 28.0,
+		ACWR:     14 / ((77 + 98) / 28.0),
+		Zone:     ZoneDanger,
+		Balance:  (77+98)/28.0 - 14,
+	}
+	if diff := cmp.Diff(want, r.Total, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Errorf("Total mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(r.Sports) != 2 || r.Sports[0].Sport != "cycling" || r.Sports[1].Sport != "running" {
+		t.Fatalf("Sports = %+v, want cycling then running", r.Sports)
+	}
+	if r.Sports[1].Acute != 0 || r.Sports[1].Zone != ZoneLow {
+		t.Errorf("running = %+v, want no acute load and a low zone", r.Sports[1])
+	}
+
+	if len(r.Warnings) != 2 || r.Warnings[0].Sport != "" || r.Warnings[1].Sport != "cycling" {
+		t.Errorf("Warnings = %v, want the total and cycling in the danger zone", r.Warnings)
+	}
+}
+
+func TestCompute_Monotony(t *testing.T) {
+	t.Parallel()
+
+	// alternating 10 and 12 strain days: mean 11, sd about 0.99
+	var workouts []whoop.Workout
+	for d := range ChronicDays {
+		workouts = append(workouts, workout("running", d, 10+2*float64(d%2)))
+	}
+
+	r := Compute(workouts, MeasureStrain, at)
+
+	week := 4*10.0 + 3*12.0
+	mean := week / 7
+	sd := math.Sqrt((4*(10-mean)*(10-mean) + 3*(12-mean)*(12-mean)) / 7)
+	if math.Abs(r.Total.Monotony-mean/sd) > 1e-9 || math.Abs(r.Total.Strain-week*mean/sd) > 1e-9 {
+		t.Errorf("monotony, strain = %v, %v, want %v, %v", r.Total.Monotony, r.Total.Strain, mean/sd, week*mean/sd)
+	}
+	if r.Total.Zone != ZoneOptimal {
+		t.Errorf("Zone = %s, want optimal", r.Total.Zone)
+	}
+	if len(r.Warnings) != 2 {
+		t.Errorf("Warnings = %v, want monotony for the total and running", r.Warnings)
+	}
+}
@@ -13,20 +13,20 @@ type Indicator struct {
 	Authenticated bool
 }
 
-func (a Indicator) Render() string {
+func (a Indicator) Render(p theme.Palette) string {
 	if !a.Checked {
 		return lipgloss.NewStyle().
-			Foreground(theme.ColorBgLight).
+			Foreground(p.Surface).
 			Render(statusDot + " checking...")
 	}
 
 	if a.Authenticated {
 		return lipgloss.NewStyle().
-			Foreground(theme.ColorHighRecovery).
+			Foreground(p.HighRecovery).
 			Render(statusDot + " connected")
 	}
 
 	return lipgloss.NewStyle().
-		Foreground(theme.ColorLowRecovery).
+		Foreground(p.LowRecovery).
 		Render(statusDot + " not connected")
 }
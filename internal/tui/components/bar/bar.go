@@ -0,0 +1,118 @@
+package bar
+
+import (
+	"image/color"
+	"math"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+const (
+	fullBlock  = "█"
+	emptyBlock = "░"
+)
+
+// Segment is one coloured part of a stacked bar.
+type Segment struct {
+	Value float64
+	Color color.Color
+}
+
+// Stacked renders segments side by side, scaled to fill width. Every segment
+// with a positive value gets at least one cell so short stages stay visible.
+// With nothing to show it renders an empty track.
+func Stacked(segments []Segment, width int, track color.Color) string {
+	if width <= 0 {
+		return ""
+	}
+
+	var total float64
+	for _, s := range segments {
+		total += max(s.Value, 0)
+	}
+	if total == 0 {
+		return empty(width, track)
+	}
+
+	cells := allocate(segments, total, width)
+
+	var b strings.Builder
+	for i, s := range segments {
+		if cells[i] == 0 {
+			continue
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(s.Color).Render(strings.Repeat(fullBlock, cells[i])))
+	}
+	return b.String()
+}
+
+// Horizontal renders value as a bar out of maxValue, padded to width with an
+// empty track.
+func Horizontal(value, maxValue float64, width int, c, track color.Color) string {
+	if width <= 0 {
+		return ""
+	}
+	if maxValue <= 0 || value <= 0 {
+		return empty(width, track)
+	}
+
+	filled := min(int(math.Round(value/maxValue*float64(width))), width)
+	if filled == 0 {
+		filled = 1
+	}
+	return lipgloss.NewStyle().Foreground(c).Render(strings.Repeat(fullBlock, filled)) +
+		empty(width-filled, track)
+}
+
+func empty(width int, track color.Color) string {
+	if width <= 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(track).Render(strings.Repeat(emptyBlock, width))
+}
+
+// allocate splits width cells between segments by the largest remainder
+// method, so the cells always add up to width.
+func allocate(segments []Segment, total float64, width int) []int {
+	var (
+		cells      = make([]int, len(segments))
+		remainders = make([]float64, len(segments))
+		used       int
+	)
+	for i, s := range segments {
+		if s.Value <= 0 {
+			continue
+		}
+		exact := s.Value / total * float64(width)
+		cells[i] = max(int(exact), 1)
+		remainders[i] = exact - float64(int(exact))
+		used += cells[i]
+	}
+
+	for used < width {
+		best := -1
+		for i, s := range segments {
+			if s.Value > 0 && (best == -1 || remainders[i] > remainders[best]) {
+				best = i
+			}
+		}
+		cells[best]++
+		remainders[best] = -1
+		used++
+	}
+
+	// the one-cell minimum can overshoot when there are many tiny segments
+	for used > width {
+		largest := 0
+		for i := range cells {
+			if cells[i] > cells[largest] {
+				largest = i
+			}
+		}
+		cells[largest]--
+		used--
+	}
+
+	return cells
+}
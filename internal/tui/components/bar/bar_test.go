@@ -0,0 +1,98 @@
+package bar
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/garrettladley/thoop/internal/tui/theme"
+)
+
+func TestStacked_Width(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		segments []Segment
+		width    int
+	}{
+		{"even split", []Segment{{1, theme.ColorSleep}, {1, theme.ColorStrain}}, 20},
+		{"uneven split", []Segment{{3, theme.ColorSleep}, {5, theme.ColorStrain}, {7, theme.ColorTeal}}, 33},
+		{"tiny segment", []Segment{{1000, theme.ColorSleep}, {1, theme.ColorStrain}}, 10},
+		{"many tiny segments", []Segment{{100, nil}, {1, nil}, {1, nil}, {1, nil}}, 3},
+		{"zero total", []Segment{{0, theme.ColorSleep}}, 12},
+		{"no segments", nil, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := lipgloss.Width(Stacked(tt.segments, tt.width, theme.ColorDim)); got != tt.width {
+				t.Errorf("Stacked() width = %d, want %d", got, tt.width)
+			}
+		})
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		segments []Segment
+		width    int
+		want     []int
+	}{
+		{"proportional", []Segment{{1, nil}, {3, nil}}, 8, []int{2, 6}},
+		{"largest remainder", []Segment{{1, nil}, {1, nil}, {1, nil}}, 10, []int{4, 3, 3}},
+		{"minimum one cell", []Segment{{1000, nil}, {1, nil}}, 10, []int{9, 1}},
+		{"skips empty segments", []Segment{{0, nil}, {2, nil}}, 5, []int{0, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var total float64
+			for _, s := range tt.segments {
+				total += s.Value
+			}
+
+			got := allocate(tt.segments, total, tt.width)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("allocate() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestHorizontal_Width(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value float64
+		max   float64
+		width int
+	}{
+		{"half", 5, 10, 20},
+		{"full", 10, 10, 20},
+		{"over max", 15, 10, 20},
+		{"tiny", 0.01, 10, 20},
+		{"zero", 0, 10, 20},
+		{"zero max", 5, 0, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := lipgloss.Width(Horizontal(tt.value, tt.max, tt.width, theme.ColorTeal, theme.ColorDim)); got != tt.width {
+				t.Errorf("Horizontal() width = %d, want %d", got, tt.width)
+			}
+		})
+	}
+}
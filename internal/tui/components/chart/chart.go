@@ -0,0 +1,228 @@
+package chart
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	drawille "github.com/exrook/drawille-go"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/garrettladley/thoop/internal/tui/theme"
+)
+
+const emptyBraille rune = '\u2800'
+
+// Chart represents a braille line chart of a series of values.
+type Chart struct {
+	Values    []*float64 // Oldest first (nil = no data, breaks the line)
+	Label     string
+	Width     int // Total width in chars, including the axis
+	Height    int // Plot height in chars, excluding the label row
+	Color     color.Color
+	AxisColor color.Color
+	TextColor color.Color
+	Format    func(float64) string
+}
+
+type Option func(*Chart)
+
+func WithAxisColor(c color.Color) Option {
+	return func(ch *Chart) {
+		ch.AxisColor = c
+	}
+}
+
+func WithTextColor(c color.Color) Option {
+	return func(ch *Chart) {
+		ch.TextColor = c
+	}
+}
+
+func WithFormat(format func(float64) string) Option {
+	return func(ch *Chart) {
+		ch.Format = format
+	}
+}
+
+// WithPalette draws the axis and the text in the palette's colors.
+func WithPalette(p theme.Palette) Option {
+	return func(ch *Chart) {
+		ch.AxisColor = p.Dim
+		ch.TextColor = p.Foreground
+	}
+}
+
+func New(values []*float64, label string, c color.Color, width, height int, opts ...Option) Chart {
+	ch := Chart{
+		Values:    values,
+		Label:     label,
+		Width:     width,
+		Height:    height,
+		Color:     c,
+		AxisColor: theme.ColorDim,
+		TextColor: theme.ColorWhite,
+		Format:    func(v float64) string { return fmt.Sprintf("%.0f", v) },
+	}
+	for _, opt := range opts {
+		opt(&ch)
+	}
+	return ch
+}
+
+func (ch Chart) Render() string {
+	lo, hi, ok := ch.bounds()
+
+	header := ch.header()
+	if !ok {
+		empty := lipgloss.NewStyle().
+			Foreground(ch.AxisColor).
+			Width(ch.Width).
+			Height(ch.Height).
+			Align(lipgloss.Center, lipgloss.Center).
+			Render("no data")
+		return lipgloss.JoinVertical(lipgloss.Left, header, empty)
+	}
+
+	var (
+		hiLabel   = ch.Format(hi)
+		loLabel   = ch.Format(lo)
+		axisWidth = max(lipgloss.Width(hiLabel), lipgloss.Width(loLabel)) + 1
+		plotWidth = max(ch.Width-axisWidth, 1)
+		dotsW     = plotWidth * 2
+		dotsH     = ch.Height * 4
+	)
+
+	canvas := drawille.NewCanvas()
+	ch.plot(&canvas, lo, hi, dotsW, dotsH)
+
+	var (
+		rows       = canvas.Rows(0, 0, dotsW-1, dotsH-1)
+		axisStyle  = lipgloss.NewStyle().Foreground(ch.AxisColor)
+		lineStyle  = lipgloss.NewStyle().Foreground(ch.Color)
+		lines      = make([]string, 0, ch.Height+1)
+		axisLabel  = func(s string) string { return axisStyle.Render(fmt.Sprintf("%*s ", axisWidth-1, s)) }
+		blankLabel = axisLabel("")
+	)
+	lines = append(lines, header)
+
+	for i := range ch.Height {
+		var row string
+		if i < len(rows) {
+			row = strings.ReplaceAll(rows[i], string(emptyBraille), " ")
+		}
+
+		label := blankLabel
+		switch i {
+		case 0:
+			label = axisLabel(hiLabel)
+		case ch.Height - 1:
+			label = axisLabel(loLabel)
+		}
+
+		lines = append(lines, label+lineStyle.Render(row))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// header renders the label on the left and the latest and average values on the right.
+func (ch Chart) header() string {
+	labelStyle := lipgloss.NewStyle().
+		Foreground(ch.TextColor).
+		Bold(true)
+
+	statsStyle := lipgloss.NewStyle().
+		Foreground(ch.AxisColor)
+
+	left := labelStyle.Render(ch.Label)
+
+	var right string
+	if latest, avg, ok := ch.stats(); ok {
+		right = statsStyle.Render(fmt.Sprintf("now %s  avg %s", ch.Format(latest), ch.Format(avg)))
+	}
+
+	spacer := max(ch.Width-lipgloss.Width(left)-lipgloss.Width(right), 1)
+	return left + strings.Repeat(" ", spacer) + right
+}
+
+// plot draws the series onto the canvas, connecting adjacent values and
+// leaving gaps where a value is missing.
+func (ch Chart) plot(canvas *drawille.Canvas, lo, hi float64, dotsW, dotsH int) {
+	n := len(ch.Values)
+
+	toX := func(i int) int {
+		if n <= 1 {
+			return (dotsW - 1) / 2
+		}
+		return int(math.Round(float64(i) * float64(dotsW-1) / float64(n-1)))
+	}
+
+	toY := func(v float64) int {
+		ratio := (hi - v) / (hi - lo)
+		return int(math.Round(ratio * float64(dotsH-1)))
+	}
+
+	for i, v := range ch.Values {
+		if v == nil {
+			continue
+		}
+
+		x, y := toX(i), toY(*v)
+		if i+1 < n && ch.Values[i+1] != nil {
+			drawLine(canvas, x, y, toX(i+1), toY(*ch.Values[i+1]))
+			continue
+		}
+		canvas.Set(x, y)
+	}
+}
+
+// bounds returns the min and max of the series, widened so a flat series still
+// has a visible range. ok is false when the series has no values.
+func (ch Chart) bounds() (float64, float64, bool) {
+	var (
+		lo    = math.Inf(1)
+		hi    = math.Inf(-1)
+		found bool
+	)
+	for _, v := range ch.Values {
+		if v == nil {
+			continue
+		}
+		lo = math.Min(lo, *v)
+		hi = math.Max(hi, *v)
+		found = true
+	}
+
+	if !found {
+		return 0, 0, false
+	}
+	if hi == lo {
+		lo--
+		hi++
+	}
+	return lo, hi, true
+}
+
+func (ch Chart) stats() (float64, float64, bool) {
+	var (
+		latest float64
+		sum    float64
+		count  int
+	)
+	for _, v := range ch.Values {
+		if v == nil {
+			continue
+		}
+		latest = *v
+		sum += *v
+		count++
+	}
+
+	if count == 0 {
+		return 0, 0, false
+	}
+	return latest, sum / float64(count), true
+}
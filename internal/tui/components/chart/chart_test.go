@@ -0,0 +1,81 @@
+package chart
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	drawille "github.com/exrook/drawille-go"
+)
+
+func ptr(v float64) *float64 { return &v }
+
+func TestChartRender_Dimensions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		values []*float64
+		width  int
+		height int
+	}{
+		{"single value", []*float64{ptr(50)}, 40, 4},
+		{"rising series", []*float64{ptr(10), ptr(20), ptr(30), ptr(40)}, 60, 6},
+		{"flat series", []*float64{ptr(7), ptr(7), ptr(7)}, 30, 3},
+		{"series with gaps", []*float64{ptr(60), nil, nil, ptr(80), ptr(70)}, 50, 5},
+		{"no data", []*float64{nil, nil}, 40, 4},
+		{"empty", nil, 40, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := New(tt.values, "LABEL", nil, tt.width, tt.height).Render()
+			lines := strings.Split(result, "\n")
+
+			if len(lines) != tt.height+1 {
+				t.Fatalf("Render() returned %d lines, want %d", len(lines), tt.height+1)
+			}
+			for i, line := range lines {
+				if w := lipgloss.Width(line); w != tt.width {
+					t.Errorf("line %d has width %d, want %d", i, w, tt.width)
+				}
+			}
+		})
+	}
+}
+
+func TestPlot_GapsBreakLine(t *testing.T) {
+	t.Parallel()
+
+	const (
+		dotsW = 40
+		dotsH = 16
+	)
+
+	ch := New([]*float64{ptr(0), nil, ptr(10)}, "LABEL", nil, dotsW/2, dotsH/4)
+	canvas := drawille.NewCanvas()
+	ch.plot(&canvas, 0, 10, dotsW, dotsH)
+
+	// the middle of the canvas sits between the two points and must stay empty
+	for y := range dotsH {
+		if canvas.Get(dotsW/2, y) {
+			t.Fatalf("expected no dots at x=%d, found one at y=%d", dotsW/2, y)
+		}
+	}
+}
+
+func TestDrawLine_Endpoints(t *testing.T) {
+	t.Parallel()
+
+	canvas := drawille.NewCanvas()
+	drawLine(&canvas, 0, 0, 7, 3)
+	drawLine(&canvas, 7, 3, 9, 0)
+
+	for _, p := range [][2]int{{0, 0}, {7, 3}, {9, 0}} {
+		if !canvas.Get(p[0], p[1]) {
+			t.Errorf("expected dot at (%d, %d)", p[0], p[1])
+		}
+	}
+}
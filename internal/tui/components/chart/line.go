@@ -0,0 +1,46 @@
+package chart
+
+import drawille "github.com/exrook/drawille-go"
+
+// drawLine draws a line between two dot coordinates using Bresenham's algorithm.
+// drawille's DrawLine toggles pixels, which erases the shared endpoint of
+// consecutive segments, so we set every pixel explicitly instead.
+// see: https://en.wikipedia.org/wiki/Bresenham%27s_line_algorithm
+func drawLine(canvas *drawille.Canvas, x0, y0, x1, y1 int) {
+	var (
+		dx  = abs(x1 - x0)
+		dy  = -abs(y1 - y0)
+		sx  = 1
+		sy  = 1
+		err = dx + dy
+	)
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+
+	for {
+		canvas.Set(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -1,23 +1,42 @@
 package footer
 
-import "charm.land/lipgloss/v2"
+import (
+	"charm.land/lipgloss/v2"
+
+	"github.com/garrettladley/thoop/internal/tui/theme"
+)
 
 type Footer struct {
+	hints        string
 	rightContent string
 	width        int
 	padding      int
+	dimStyle     lipgloss.Style
 }
 
-func New(rightContent string, width int) Footer {
+func New(t theme.Theme, rightContent string, width int) Footer {
 	return Footer{
 		rightContent: rightContent,
 		width:        width,
 		padding:      2,
+		dimStyle:     lipgloss.NewStyle().Foreground(t.Palette().Dim),
 	}
 }
 
+// WithHints shows key hints after the left content.
+func (f Footer) WithHints(hints string) Footer {
+	f.hints = hints
+	return f
+}
+
 func (f Footer) Render() string {
 	leftContent := f.leftContent()
+	if f.hints != "" {
+		if leftContent != "" {
+			leftContent += "  "
+		}
+		leftContent += f.dimStyle.Render(f.hints)
+	}
 
 	leftWidth := lipgloss.Width(leftContent)
 	rightWidth := lipgloss.Width(f.rightContent)
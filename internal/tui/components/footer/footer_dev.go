@@ -2,15 +2,8 @@
 
 package footer
 
-import (
-	"charm.land/lipgloss/v2"
-
-	"github.com/garrettladley/thoop/internal/tui/theme"
-	"github.com/garrettladley/thoop/internal/version"
-)
-
-var devVersionStyle = lipgloss.NewStyle().Foreground(theme.ColorDim)
+import "github.com/garrettladley/thoop/internal/version"
 
 func (f Footer) leftContent() string {
-	return devVersionStyle.Render(version.Get())
+	return f.dimStyle.Render(version.Get())
 }
@@ -12,17 +12,27 @@ const (
 	// start at top (12 o'clock = 270°), fill clockwise (increasing angle)
 	arcStartAngle = 270.0 // top of circle (12 o'clock)
 	arcSweep      = 360.0
-	arcThickness  = 5
+
+	// the arc is a fifth of the radius thick, within these bounds in dots
+	minArcThickness = 3
+	maxArcThickness = 5
 )
 
+func arcThickness(radius float64) int {
+	return min(max(int(radius)/5, minArcThickness), maxArcThickness)
+}
+
 // drawArc draws a thick arc on the canvas from startAngle sweeping through sweepAngle degrees.
 // uses the midpoint circle algorithm for clean, gap-free rendering.
 // see: https://en.wikipedia.org/wiki/Midpoint_circle_algorithm
 func drawArc(canvas *drawille.Canvas, centerX, centerY, radius float64, startAngle, sweepAngle float64) {
-	endAngle := startAngle + sweepAngle
+	var (
+		endAngle  = startAngle + sweepAngle
+		thickness = arcThickness(radius)
+	)
 
 	// draw the arc at multiple radii for thickness
-	for t := range arcThickness {
+	for t := range thickness {
 		r := int(radius) - t
 		if r <= 0 {
 			continue
@@ -31,7 +41,7 @@ func drawArc(canvas *drawille.Canvas, centerX, centerY, radius float64, startAng
 	}
 
 	// fill gaps between radii by drawing radial lines
-	fillArcGaps(canvas, int(centerX), int(centerY), int(radius), int(radius)-arcThickness+1, startAngle, endAngle)
+	fillArcGaps(canvas, int(centerX), int(centerY), int(radius), int(radius)-thickness+1, startAngle, endAngle)
 }
 
 // midpointCircleArc draws an arc using the midpoint circle algorithm.
@@ -14,10 +14,12 @@ import (
 )
 
 const (
-	// gauge dimensions in braille dots (2 dots per char width, 4 dots per char height)
-	// large enough to have hollow center for the value text
-	gaugeDotsWidth  = 52 // 26 chars wide
-	gaugeDotsHeight = 52 // 13 chars tall
+	// DefaultSize is the gauge width in characters; it is half as many rows
+	// tall, since braille characters are 2 dots wide and 4 dots tall.
+	DefaultSize = 26
+	// MinSize is the narrowest gauge that still has a hollow center wide
+	// enough for the value text.
+	MinSize = 14
 )
 
 // Gauge represents a circular progress gauge with a value displayed in the center.
@@ -28,6 +30,7 @@ type Gauge struct {
 	Color     color.Color // Arc fill color
 	BgColor   color.Color // Background arc color (unfilled portion)
 	TextColor color.Color // Value text color
+	Size      int         // Width in characters, always even
 }
 
 type Option func(*Gauge)
@@ -44,6 +47,27 @@ func WithTextColor(c color.Color) Option {
 	}
 }
 
+// WithSize scales the gauge to size characters wide, rounded down to an even
+// width and no smaller than MinSize.
+func WithSize(size int) Option {
+	return func(g *Gauge) {
+		g.Size = max(size, MinSize) &^ 1
+	}
+}
+
+// Height returns how many rows a gauge of size characters takes, label included.
+func Height(size int) int {
+	return size/2 + 1
+}
+
+// WithPalette draws the unfilled arc and the value text in the palette's colors.
+func WithPalette(p theme.Palette) Option {
+	return func(g *Gauge) {
+		g.BgColor = p.Surface
+		g.TextColor = p.Foreground
+	}
+}
+
 func New(value *float64, max float64, label string, c color.Color, opts ...Option) Gauge {
 	g := Gauge{
 		Value:     value,
@@ -52,6 +76,7 @@ func New(value *float64, max float64, label string, c color.Color, opts ...Optio
 		Color:     c,
 		BgColor:   theme.ColorBgLight,
 		TextColor: theme.ColorWhite,
+		Size:      DefaultSize,
 	}
 	for _, opt := range opts {
 		opt(&g)
@@ -62,10 +87,13 @@ func New(value *float64, max float64, label string, c color.Color, opts ...Optio
 func (g Gauge) Render() string {
 	canvas := drawille.NewCanvas()
 
+	// the canvas is square in dots: 2 per character across, 4 per row down
+	dots := g.Size * 2
+
 	var (
-		centerX = float64(gaugeDotsWidth) / 2
-		centerY = float64(gaugeDotsHeight) / 2
-		radius  = float64(gaugeDotsWidth)/2 - 1
+		centerX = float64(dots) / 2
+		centerY = float64(dots) / 2
+		radius  = float64(dots)/2 - 1
 	)
 
 	var percentage float64
@@ -81,14 +109,14 @@ func (g Gauge) Render() string {
 
 	// draw background arc (full arc sweep in dim color)
 	drawFullArc(&canvas, centerX, centerY, radius)
-	bgArcStr := getCanvasString(&canvas, gaugeDotsWidth, gaugeDotsHeight)
+	bgArcStr := getCanvasString(&canvas, dots, dots)
 
 	// clear and draw filled arc (clockwise from start by percentage of sweep)
 	canvas.Clear()
 	if percentage > 0 {
 		drawFilledArc(&canvas, centerX, centerY, radius, percentage)
 	}
-	filledArcStr := getCanvasString(&canvas, gaugeDotsWidth, gaugeDotsHeight)
+	filledArcStr := getCanvasString(&canvas, dots, dots)
 
 	// combine arcs with colors
 	combinedArc := overlayArcsRaw(bgArcStr, filledArcStr, g.BgColor, g.Color)
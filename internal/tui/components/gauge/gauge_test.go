@@ -2,16 +2,23 @@ package gauge
 
 import (
 	"embed"
+	"flag"
 	"image/color"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/garrettladley/thoop/internal/tui/theme"
 )
 
-//go:embed testdata/*.golden
+//go:embed testdata/*.golden testdata/themes
 var goldenFiles embed.FS
 
+var update = flag.Bool("update", false, "rewrite the themed golden files")
+
 func TestExtractStyledSegment(t *testing.T) {
 	t.Parallel()
 
@@ -304,6 +311,63 @@ func TestGaugeRender_Golden(t *testing.T) {
 	}
 }
 
+// TestGaugeRender_ThemeGolden keeps the ANSI colors in the golden files, so
+// every built-in theme's arc, track and text colors are covered. Run with
+// -update to rewrite them after changing a palette.
+func TestGaugeRender_ThemeGolden(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value float64
+		max   float64
+		label string
+		color func(theme.Palette, *float64) color.Color
+	}{
+		{"sleep_75", 75, 100, "SLEEP", func(p theme.Palette, _ *float64) color.Color { return p.Sleep }},
+		{"recovery_20", 20, 100, "RECOVERY", theme.Palette.RecoveryColor},
+		{"recovery_50", 50, 100, "RECOVERY", theme.Palette.RecoveryColor},
+		{"recovery_80", 80, 100, "RECOVERY", theme.Palette.RecoveryColor},
+		{"strain_10.5", 10.5, 21, "STRAIN", func(p theme.Palette, _ *float64) color.Color { return p.Strain }},
+	}
+
+	for _, name := range theme.Names() {
+		th, _ := theme.Builtin(name)
+		p := th.Palette()
+
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				value := tt.value
+				g := New(&value, tt.max, tt.label, tt.color(p, &value), WithPalette(p))
+				result := g.Render()
+
+				goldenFile := filepath.Join("testdata", "themes", name, tt.name+".golden")
+				if *update {
+					if err := os.MkdirAll(filepath.Dir(goldenFile), 0o750); err != nil {
+						t.Fatalf("failed to create golden directory: %v", err)
+					}
+					if err := os.WriteFile(goldenFile, []byte(result+"\n"), 0o600); err != nil {
+						t.Fatalf("failed to write golden file: %v", err)
+					}
+					return
+				}
+
+				golden, err := goldenFiles.ReadFile(filepath.ToSlash(goldenFile))
+				if err != nil {
+					t.Fatalf("failed to read golden file: %v", err)
+				}
+
+				expected := strings.TrimSuffix(string(golden), "\n")
+				if diff := cmp.Diff(expected, result); diff != "" {
+					t.Errorf("output mismatch (-want +got):\n%s", diff)
+				}
+			})
+		}
+	}
+}
+
 // normalizeLines trims trailing whitespace from each line for stable comparison
 func normalizeLines(s string) string {
 	lines := strings.Split(s, "\n")
@@ -0,0 +1,34 @@
+package network
+
+import (
+	"charm.land/lipgloss/v2"
+
+	"github.com/garrettladley/thoop/internal/tui/theme"
+)
+
+const statusDot = "●"
+
+type Indicator struct {
+	Offline bool
+	Forced  bool // offline was requested with --offline
+	Syncing bool // reconciling after connectivity came back
+}
+
+func (n Indicator) Render(p theme.Palette) string {
+	switch {
+	case n.Syncing:
+		return lipgloss.NewStyle().
+			Foreground(p.Recovery).
+			Render(statusDot + " reconnecting...")
+	case n.Offline && n.Forced:
+		return lipgloss.NewStyle().
+			Foreground(p.Dim).
+			Render(statusDot + " offline mode")
+	case n.Offline:
+		return lipgloss.NewStyle().
+			Foreground(p.MediumRecovery).
+			Render(statusDot + " offline")
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,89 @@
+package toast
+
+import (
+	"image/color"
+	"time"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/garrettladley/thoop/internal/tui/theme"
+)
+
+const (
+	// Duration is how long a toast stays up, including its fade.
+	Duration = 5 * time.Second
+	// FadeDuration is the tail of Duration over which a toast fades into the background.
+	FadeDuration = 1500 * time.Millisecond
+
+	// MaxVisible caps how many toasts stack at once; older ones drop off first.
+	MaxVisible = 3
+
+	fadeSteps = 8
+)
+
+type Toast struct {
+	Text string
+	// Accent colors the toast's border, matching the data it's about.
+	Accent color.Color
+	At     time.Time
+}
+
+func New(text string, accent color.Color, at time.Time) Toast {
+	return Toast{Text: text, Accent: accent, At: at}
+}
+
+func (t Toast) Expired(now time.Time) bool {
+	return now.Sub(t.At) >= Duration
+}
+
+// fade returns how far the toast has faded at now, from 0 (fully shown) to
+// fadeSteps-1 (almost gone).
+func (t Toast) fade(now time.Time) int {
+	remaining := Duration - now.Sub(t.At)
+	if remaining >= FadeDuration {
+		return 0
+	}
+	if remaining <= 0 {
+		return fadeSteps - 1
+	}
+	faded := float64(FadeDuration-remaining) / float64(FadeDuration)
+	return min(int(faded*fadeSteps), fadeSteps-1)
+}
+
+// Prune drops expired toasts and keeps at most MaxVisible of the newest.
+func Prune(toasts []Toast, now time.Time) []Toast {
+	live := make([]Toast, 0, len(toasts))
+	for _, t := range toasts {
+		if !t.Expired(now) {
+			live = append(live, t)
+		}
+	}
+	if len(live) > MaxVisible {
+		live = live[len(live)-MaxVisible:]
+	}
+	return live
+}
+
+// Render stacks the live toasts, newest at the bottom, fading them into the
+// palette's background. It returns an empty string when there is nothing to show.
+func Render(toasts []Toast, now time.Time, p theme.Palette) string {
+	rendered := make([]string, 0, len(toasts))
+	for _, t := range Prune(toasts, now) {
+		step := t.fade(now)
+		var (
+			text   = lipgloss.Blend1D(fadeSteps, p.Foreground, p.Background)[step]
+			border = lipgloss.Blend1D(fadeSteps, t.Accent, p.Background)[step]
+		)
+
+		rendered = append(rendered, lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(border).
+			Foreground(text).
+			Padding(0, 1).
+			Render(t.Text))
+	}
+	if len(rendered) == 0 {
+		return ""
+	}
+	return lipgloss.JoinVertical(lipgloss.Right, rendered...)
+}
@@ -0,0 +1,64 @@
+package toast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/tui/theme"
+)
+
+func TestFade(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	toast := New("Sleep scored: 87%", theme.ColorSleep, at)
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		want    int
+	}{
+		{name: "fresh", elapsed: 0, want: 0},
+		{name: "before fade", elapsed: Duration - FadeDuration, want: 0},
+		{name: "halfway through fade", elapsed: Duration - FadeDuration/2, want: fadeSteps / 2},
+		{name: "expired", elapsed: Duration, want: fadeSteps - 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := toast.fade(at.Add(tt.elapsed)); got != tt.want {
+				t.Errorf("fade(%v) = %d, want %d", tt.elapsed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	toasts := []Toast{
+		New("expired", theme.ColorSleep, now.Add(-Duration)),
+		New("one", theme.ColorSleep, now.Add(-4*time.Second)),
+		New("two", theme.ColorSleep, now.Add(-3*time.Second)),
+		New("three", theme.ColorSleep, now.Add(-2*time.Second)),
+		New("four", theme.ColorSleep, now.Add(-time.Second)),
+	}
+
+	got := Prune(toasts, now)
+	want := []string{"two", "three", "four"}
+	if len(got) != len(want) {
+		t.Fatalf("Prune() kept %d toasts, want %d", len(got), len(want))
+	}
+	for i, toast := range got {
+		if toast.Text != want[i] {
+			t.Errorf("Prune()[%d] = %q, want %q", i, toast.Text, want[i])
+		}
+	}
+
+	if Render(toasts[:1], now, theme.New().Palette()) != "" {
+		t.Error("Render() of only expired toasts is not empty")
+	}
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/garrettladley/thoop/internal/client/health"
+	"github.com/garrettladley/thoop/internal/xsync"
+)
+
+type ConnectivityTickMsg struct{}
+
+type ConnectivityMsg struct {
+	Online bool
+	Err    error
+}
+
+type ReconciledMsg struct {
+	Err error
+}
+
+type BackfillResumedMsg struct {
+	Err error
+}
+
+func ConnectivityTickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return ConnectivityTickMsg{}
+	})
+}
+
+// ProbeConnectivityCmd checks whether the thoop server is reachable.
+func ProbeConnectivityCmd(ctx context.Context, client *health.Client) tea.Cmd {
+	return func() tea.Msg {
+		err := client.Check(ctx)
+		return ConnectivityMsg{Online: err == nil, Err: err}
+	}
+}
+
+// ReconcileCmd catches the cache up on the current cycles after connectivity
+// comes back. The SSE stream and the backfill are restarted once it's done.
+func ReconcileCmd(ctx context.Context, syncService xsync.SyncService) tea.Cmd {
+	return func() tea.Msg {
+		if err := syncService.RefreshCurrent(ctx); err != nil {
+			return ReconciledMsg{Err: fmt.Errorf("failed to refresh current data: %w", err)}
+		}
+		return ReconciledMsg{}
+	}
+}
+
+// ResumeBackfillCmd resumes the backfill until it completes or ctx is
+// cancelled. It does nothing when the backfill is complete or already running.
+func ResumeBackfillCmd(ctx context.Context, syncService xsync.SyncService) tea.Cmd {
+	return func() tea.Msg {
+		if err := syncService.StartBackfill(ctx); err != nil {
+			return BackfillResumedMsg{Err: fmt.Errorf("failed to start backfill: %w", err)}
+		}
+		return BackfillResumedMsg{}
+	}
+}
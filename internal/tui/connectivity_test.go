@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/garrettladley/thoop/internal/client/health"
+	"github.com/garrettladley/thoop/internal/tui/page"
+	"github.com/garrettladley/thoop/internal/xsync"
+)
+
+// stubSyncService counts refreshes and remembers the context the backfill was
+// last started with.
+type stubSyncService struct {
+	xsync.SyncService
+
+	refreshErr error
+	refreshes  atomic.Int32
+	backfill   atomic.Pointer[context.Context]
+}
+
+func (s *stubSyncService) RefreshCurrent(context.Context) error {
+	s.refreshes.Add(1)
+	return s.refreshErr
+}
+
+func (s *stubSyncService) StartBackfill(ctx context.Context) error {
+	s.backfill.Store(&ctx)
+	return nil
+}
+
+func TestConnectivity(t *testing.T) {
+	t.Parallel()
+
+	var up atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !up.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	var (
+		healthClient = health.NewClient(srv.URL)
+		syncService  = &stubSyncService{}
+		m            = New(Deps{
+			Ctx:          t.Context(),
+			Logger:       slog.New(slog.DiscardHandler),
+			SyncService:  syncService,
+			HealthClient: healthClient,
+		})
+		indicator = &m.state.dashboard.NetworkIndicator
+	)
+	m.page = page.Dashboard
+
+	probe := func() ConnectivityMsg {
+		t.Helper()
+		msg, ok := ProbeConnectivityCmd(t.Context(), healthClient)().(ConnectivityMsg)
+		if !ok {
+			t.Fatal("ProbeConnectivityCmd() didn't return a ConnectivityMsg")
+		}
+		return msg
+	}
+
+	m.startLive()
+	ResumeBackfillCmd(m.liveCtx, syncService)()
+	backfill := *syncService.backfill.Load()
+
+	// online → offline stops the stream and the backfill
+	m.Update(probe())
+	if !indicator.Offline {
+		t.Fatal("Offline = false after a failed probe")
+	}
+	if backfill.Err() == nil {
+		t.Error("the backfill is still running offline")
+	}
+	if m.cancelLive != nil {
+		t.Error("the SSE stream is still running offline")
+	}
+
+	// offline → online reconciles before anything restarts
+	up.Store(true)
+	_, cmd := m.Update(probe())
+	if !indicator.Syncing || !indicator.Offline {
+		t.Fatalf("Syncing = %v, Offline = %v while reconciling, want true, true", indicator.Syncing, indicator.Offline)
+	}
+	if m.cancelLive != nil {
+		t.Error("the SSE stream restarted before reconciling")
+	}
+	reconciled, ok := cmd().(ReconciledMsg)
+	if !ok {
+		t.Fatal("coming back online didn't reconcile")
+	}
+	if n := syncService.refreshes.Load(); n != 1 {
+		t.Errorf("RefreshCurrent() called %d times, want 1", n)
+	}
+
+	// another probe while reconciling doesn't start a second one
+	if _, cmd := m.Update(probe()); cmd == nil {
+		t.Error("probing while reconciling didn't schedule the next probe")
+	}
+
+	m.Update(reconciled)
+	if indicator.Offline || indicator.Syncing {
+		t.Fatalf("Offline = %v, Syncing = %v after reconciling, want false, false", indicator.Offline, indicator.Syncing)
+	}
+	if m.cancelLive == nil || m.liveCtx.Err() != nil {
+		t.Fatal("the SSE stream didn't restart after reconciling")
+	}
+	ResumeBackfillCmd(m.liveCtx, syncService)()
+	if resumed := *syncService.backfill.Load(); resumed.Err() != nil {
+		t.Error("the backfill resumed with a cancelled context")
+	}
+}
+
+func TestReconcileFailure(t *testing.T) {
+	t.Parallel()
+
+	syncService := &stubSyncService{refreshErr: errors.New("unavailable")}
+	m := New(Deps{
+		Ctx:         t.Context(),
+		Logger:      slog.New(slog.DiscardHandler),
+		SyncService: syncService,
+		Offline:     true,
+	})
+	m.page = page.Dashboard
+	indicator := &m.state.dashboard.NetworkIndicator
+
+	m.Update(ConnectivityMsg{Online: true})
+	msg, ok := ReconcileCmd(t.Context(), syncService)().(ReconciledMsg)
+	if !ok || msg.Err == nil {
+		t.Fatalf("ReconcileCmd() = %v, want a ReconciledMsg with an error", msg)
+	}
+
+	m.Update(msg)
+	if !indicator.Offline || indicator.Syncing {
+		t.Errorf("Offline = %v, Syncing = %v after a failed reconcile, want true, false", indicator.Offline, indicator.Syncing)
+	}
+	if m.cancelLive != nil {
+		t.Error("the SSE stream started after a failed reconcile")
+	}
+}
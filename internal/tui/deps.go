@@ -4,11 +4,13 @@ import (
 	"context"
 	"log/slog"
 
+	"github.com/garrettladley/thoop/internal/client/health"
 	"github.com/garrettladley/thoop/internal/client/sse"
 	"github.com/garrettladley/thoop/internal/client/whoop"
 	"github.com/garrettladley/thoop/internal/oauth"
 	"github.com/garrettladley/thoop/internal/repository"
 	"github.com/garrettladley/thoop/internal/storage"
+	"github.com/garrettladley/thoop/internal/tui/theme"
 	"github.com/garrettladley/thoop/internal/xsync"
 )
 
@@ -26,4 +28,15 @@ type Deps struct {
 	SSEClient        *sse.Client
 	NotifProcessor   *xsync.NotificationProcessor
 	NotificationChan chan storage.Notification
+	HealthClient     *health.Client
+	Reconciler       *xsync.PendingReconciler
+	// Keymap defaults to DefaultKeymap when nil.
+	Keymap *Keymap
+	// Theme defaults to the dark theme when nil.
+	Theme *theme.Theme
+
+	// Offline starts the TUI without network access, rendering from the cache.
+	Offline bool
+	// ForceOffline keeps the TUI offline for the whole session (--offline).
+	ForceOffline bool
 }
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/garrettladley/thoop/internal/tui/page"
+	"github.com/garrettladley/thoop/internal/tui/theme"
+)
+
+// helpView lists every binding active on page id in a box centered in the viewport.
+func helpView(t theme.Theme, keymap *Keymap, id page.ID, width, height int) string {
+	var (
+		p          = t.Palette()
+		titleStyle = lipgloss.NewStyle().Foreground(p.Foreground).Bold(true)
+		keyStyle   = lipgloss.NewStyle().Foreground(p.Accent)
+		helpStyle  = lipgloss.NewStyle().Foreground(p.Dim)
+		boxStyle   = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(p.Dim).
+				Padding(1, 3)
+	)
+
+	bindings := keymap.Bindings(id)
+
+	keys := make([]string, len(bindings))
+	keyWidth := 0
+	for i, b := range bindings {
+		display := make([]string, len(b.Keys))
+		for j, key := range b.Keys {
+			display[j] = displayKey(key)
+		}
+		keys[i] = strings.Join(display, "/")
+		keyWidth = max(keyWidth, lipgloss.Width(keys[i]))
+	}
+
+	rows := make([]string, 0, len(bindings)+2)
+	rows = append(rows, titleStyle.Render("keys"), "")
+	for i, b := range bindings {
+		if len(b.Keys) == 0 {
+			continue
+		}
+		key := keyStyle.Width(keyWidth).Render(keys[i])
+		rows = append(rows, key+"   "+helpStyle.Render(b.Help))
+	}
+	rows = append(rows, "", helpStyle.Render("? or esc to close"))
+
+	return lipgloss.Place(
+		width,
+		height,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...)),
+	)
+}
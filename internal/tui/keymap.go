@@ -0,0 +1,242 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	go_json "github.com/goccy/go-json"
+
+	"github.com/garrettladley/thoop/internal/tui/page"
+)
+
+// Action is what a key does. Actions are named so the overrides file can
+// rebind them without knowing the default keys.
+type Action string
+
+const (
+	ActionQuit     Action = "quit"
+	ActionHelp     Action = "help"
+	ActionNextPage Action = "next_page"
+
+	ActionSignIn Action = "sign_in"
+
+	ActionPreviousDay Action = "previous_day"
+	ActionNextDay     Action = "next_day"
+	ActionToday       Action = "today"
+
+	ActionWeek    Action = "week"
+	ActionMonth   Action = "month"
+	ActionQuarter Action = "quarter"
+
+	ActionOlder Action = "older"
+	ActionNewer Action = "newer"
+
+	ActionMethod Action = "method"
+
+	ActionDown  Action = "down"
+	ActionUp    Action = "up"
+	ActionOpen  Action = "open"
+	ActionClose Action = "close"
+
+	ActionTraining Action = "training"
+)
+
+// Binding maps keys to an action. Hint is the short label shown in the
+// footer; bindings without one only appear in the help overlay.
+type Binding struct {
+	Action Action
+	Keys   []string
+	Help   string
+	Hint   string
+}
+
+// Keymap holds the bindings shared by every page and those each page declares.
+// Page bindings take precedence over global ones.
+type Keymap struct {
+	global []Binding
+	pages  map[page.ID][]Binding
+}
+
+// globalScope and pageScopes name the sections of the overrides file.
+const globalScope = "global"
+
+var pageScopes = map[string]page.ID{
+	"onboarding":    page.Onboarding,
+	"dashboard":     page.Dashboard,
+	"trends":        page.Trends,
+	"sleep":         page.Sleep,
+	"workouts":      page.Workouts,
+	"insights":      page.Insights,
+	"notifications": page.Notifications,
+}
+
+func DefaultKeymap() *Keymap {
+	nextPage := Binding{Action: ActionNextPage, Keys: []string{"tab"}, Help: "next page", Hint: "page"}
+
+	return &Keymap{
+		global: []Binding{
+			{Action: ActionHelp, Keys: []string{"?"}, Help: "toggle this help", Hint: "help"},
+			{Action: ActionQuit, Keys: []string{"q", "ctrl+c"}, Help: "quit", Hint: "quit"},
+		},
+		pages: map[page.ID][]Binding{
+			page.Onboarding: {
+				{Action: ActionSignIn, Keys: []string{"enter"}, Help: "sign in with WHOOP"},
+			},
+			page.Dashboard: {
+				nextPage,
+				{Action: ActionPreviousDay, Keys: []string{"left", "h"}, Help: "previous day", Hint: "prev"},
+				{Action: ActionNextDay, Keys: []string{"right", "l"}, Help: "next day", Hint: "next"},
+				{Action: ActionToday, Keys: []string{"t"}, Help: "jump back to today", Hint: "today"},
+			},
+			page.Trends: {
+				nextPage,
+				{Action: ActionWeek, Keys: []string{"1"}, Help: "last 7 days"},
+				{Action: ActionMonth, Keys: []string{"2"}, Help: "last 30 days"},
+				{Action: ActionQuarter, Keys: []string{"3"}, Help: "last 90 days"},
+			},
+			page.Sleep: {
+				nextPage,
+				{Action: ActionOlder, Keys: []string{"left", "h"}, Help: "previous night", Hint: "older"},
+				{Action: ActionNewer, Keys: []string{"right", "l"}, Help: "next night", Hint: "newer"},
+			},
+			page.Workouts: {
+				nextPage,
+				{Action: ActionDown, Keys: []string{"down", "j"}, Help: "next workout", Hint: "down"},
+				{Action: ActionUp, Keys: []string{"up", "k"}, Help: "previous workout", Hint: "up"},
+				{Action: ActionOpen, Keys: []string{"enter"}, Help: "show workout detail", Hint: "open"},
+				{Action: ActionClose, Keys: []string{"esc", "backspace"}, Help: "hide workout detail", Hint: "close"},
+				{Action: ActionTraining, Keys: []string{"L"}, Help: "toggle training load by sport", Hint: "load"},
+			},
+			page.Insights: {
+				nextPage,
+				{Action: ActionMethod, Keys: []string{"m"}, Help: "switch between pearson and spearman", Hint: "method"},
+			},
+			page.Notifications: {
+				nextPage,
+				{Action: ActionDown, Keys: []string{"down", "j"}, Help: "older notifications", Hint: "down"},
+				{Action: ActionUp, Keys: []string{"up", "k"}, Help: "newer notifications", Hint: "up"},
+			},
+		},
+	}
+}
+
+// LoadKeymap reads key overrides from path on top of the defaults. A missing
+// file is not an error. The file maps a scope to actions and their new keys:
+//
+//	{"global": {"quit": ["ctrl+q"]}, "dashboard": {"previous_day": ["a"]}}
+func LoadKeymap(path string) (*Keymap, error) {
+	km := DefaultKeymap()
+
+	data, err := os.ReadFile(path) //nolint:gosec // path is from trusted paths package
+	if errors.Is(err, os.ErrNotExist) {
+		return km, nil
+	}
+	if err != nil {
+		return km, fmt.Errorf("failed to read keymap: %w", err)
+	}
+
+	var overrides map[string]map[Action][]string
+	if err := go_json.Unmarshal(data, &overrides); err != nil {
+		return km, fmt.Errorf("failed to parse keymap: %w", err)
+	}
+
+	if err := km.apply(overrides); err != nil {
+		return DefaultKeymap(), err
+	}
+	return km, nil
+}
+
+func (k *Keymap) apply(overrides map[string]map[Action][]string) error {
+	for scope, actions := range overrides {
+		bindings := k.global
+		if scope != globalScope {
+			id, ok := pageScopes[scope]
+			if !ok {
+				return fmt.Errorf("unknown keymap scope %q", scope)
+			}
+			bindings = k.pages[id]
+		}
+
+		for action, keys := range actions {
+			i := slices.IndexFunc(bindings, func(b Binding) bool { return b.Action == action })
+			if i < 0 {
+				return fmt.Errorf("unknown action %q in keymap scope %q", action, scope)
+			}
+			if len(keys) == 0 {
+				return fmt.Errorf("no keys for action %q in keymap scope %q", action, scope)
+			}
+
+			// a rebound key leaves whatever it was bound to before, so two
+			// actions can swap keys
+			for j := range bindings {
+				bindings[j].Keys = slices.DeleteFunc(slices.Clone(bindings[j].Keys), func(key string) bool {
+					return slices.Contains(keys, key)
+				})
+			}
+			bindings[i].Keys = keys
+		}
+	}
+	return nil
+}
+
+// Lookup returns the action key triggers on page p.
+func (k *Keymap) Lookup(p page.ID, key string) (Action, bool) {
+	for _, b := range k.pages[p] {
+		if slices.Contains(b.Keys, key) {
+			return b.Action, true
+		}
+	}
+	for _, b := range k.global {
+		if slices.Contains(b.Keys, key) {
+			return b.Action, true
+		}
+	}
+	return "", false
+}
+
+// Bindings returns the bindings active on page p, page bindings first.
+func (k *Keymap) Bindings(p page.ID) []Binding {
+	return slices.Concat(k.pages[p], k.global)
+}
+
+// Hints renders the short footer hints for page p, such as "← prev  ? help".
+func (k *Keymap) Hints(p page.ID) string {
+	hints := make([]string, 0, len(k.pages[p])+len(k.global))
+	for _, b := range k.Bindings(p) {
+		if b.Hint == "" || len(b.Keys) == 0 {
+			continue
+		}
+		hints = append(hints, displayKey(b.Keys[0])+" "+b.Hint)
+	}
+	return strings.Join(hints, "  ")
+}
+
+// Key returns the first key bound to action on page p for display, or "" when
+// it is unbound.
+func (k *Keymap) Key(p page.ID, action Action) string {
+	for _, b := range k.Bindings(p) {
+		if b.Action == action && len(b.Keys) > 0 {
+			return displayKey(b.Keys[0])
+		}
+	}
+	return ""
+}
+
+// displayKey shortens a key name for display.
+func displayKey(key string) string {
+	switch key {
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	default:
+		return key
+	}
+}
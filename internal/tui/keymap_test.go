@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/garrettladley/thoop/internal/tui/page"
+)
+
+func TestKeymapLookup(t *testing.T) {
+	t.Parallel()
+
+	km := DefaultKeymap()
+
+	tests := []struct {
+		name   string
+		page   page.ID
+		key    string
+		want   Action
+		wantOK bool
+	}{
+		{name: "page binding", page: page.Dashboard, key: "h", want: ActionPreviousDay, wantOK: true},
+		{name: "same key on another page", page: page.Sleep, key: "h", want: ActionOlder, wantOK: true},
+		{name: "global binding", page: page.Trends, key: "?", want: ActionHelp, wantOK: true},
+		{name: "unbound", page: page.Trends, key: "h", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := km.Lookup(tt.page, tt.key)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Lookup(%v, %q) = %q, %v, want %q, %v", tt.page, tt.key, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLoadKeymap(t *testing.T) {
+	t.Parallel()
+
+	write := func(t *testing.T, contents string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "keys.json")
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatalf("failed to write keymap: %v", err)
+		}
+		return path
+	}
+
+	t.Run("missing file uses defaults", func(t *testing.T) {
+		t.Parallel()
+
+		km, err := LoadKeymap(filepath.Join(t.TempDir(), "keys.json"))
+		if err != nil {
+			t.Fatalf("LoadKeymap() error = %v", err)
+		}
+		if got, _ := km.Lookup(page.Dashboard, "q"); got != ActionQuit {
+			t.Errorf("Lookup(q) = %q, want %q", got, ActionQuit)
+		}
+	})
+
+	t.Run("overrides replace keys", func(t *testing.T) {
+		t.Parallel()
+
+		km, err := LoadKeymap(write(t, `{"global": {"quit": ["ctrl+q"]}, "dashboard": {"today": ["g"]}}`))
+		if err != nil {
+			t.Fatalf("LoadKeymap() error = %v", err)
+		}
+		if _, ok := km.Lookup(page.Dashboard, "q"); ok {
+			t.Error("q is still bound after overriding quit")
+		}
+		if got, _ := km.Lookup(page.Dashboard, "ctrl+q"); got != ActionQuit {
+			t.Errorf("Lookup(ctrl+q) = %q, want %q", got, ActionQuit)
+		}
+		if got, _ := km.Lookup(page.Dashboard, "g"); got != ActionToday {
+			t.Errorf("Lookup(g) = %q, want %q", got, ActionToday)
+		}
+	})
+
+	t.Run("overrides can swap keys", func(t *testing.T) {
+		t.Parallel()
+
+		km, err := LoadKeymap(write(t, `{"sleep": {"older": ["l"], "newer": ["h"]}}`))
+		if err != nil {
+			t.Fatalf("LoadKeymap() error = %v", err)
+		}
+		if got, _ := km.Lookup(page.Sleep, "l"); got != ActionOlder {
+			t.Errorf("Lookup(l) = %q, want %q", got, ActionOlder)
+		}
+		if got, _ := km.Lookup(page.Sleep, "h"); got != ActionNewer {
+			t.Errorf("Lookup(h) = %q, want %q", got, ActionNewer)
+		}
+		// defaults for other pages are untouched
+		if got, _ := km.Lookup(page.Dashboard, "h"); got != ActionPreviousDay {
+			t.Errorf("Lookup(dashboard, h) = %q, want %q", got, ActionPreviousDay)
+		}
+	})
+
+	t.Run("overrides insights bindings", func(t *testing.T) {
+		t.Parallel()
+
+		km, err := LoadKeymap(write(t, `{"insights": {"method": ["s"]}}`))
+		if err != nil {
+			t.Fatalf("LoadKeymap() error = %v", err)
+		}
+		if _, ok := km.Lookup(page.Insights, "m"); ok {
+			t.Error("m is still bound after overriding method")
+		}
+		if got, _ := km.Lookup(page.Insights, "s"); got != ActionMethod {
+			t.Errorf("Lookup(s) = %q, want %q", got, ActionMethod)
+		}
+	})
+
+	t.Run("unknown action falls back to defaults", func(t *testing.T) {
+		t.Parallel()
+
+		km, err := LoadKeymap(write(t, `{"dashboard": {"previous_day": ["a"], "launch": ["x"]}}`))
+		if err == nil {
+			t.Fatal("LoadKeymap() error = nil, want error")
+		}
+		if got, _ := km.Lookup(page.Dashboard, "h"); got != ActionPreviousDay {
+			t.Errorf("Lookup(h) = %q, want %q", got, ActionPreviousDay)
+		}
+	})
+}
+
+func TestKeymapHints(t *testing.T) {
+	t.Parallel()
+
+	got := DefaultKeymap().Hints(page.Dashboard)
+	want := "tab page  ← prev  → next  t today  ? help  q quit"
+	if got != want {
+		t.Errorf("Hints() = %q, want %q", got, want)
+	}
+}
+
+func TestKeymapKey(t *testing.T) {
+	t.Parallel()
+
+	km := DefaultKeymap()
+	if err := km.apply(map[string]map[Action][]string{"workouts": {ActionTraining: {"t"}}}); err != nil {
+		t.Fatalf("apply() error = %v", err)
+	}
+	if got := km.Key(page.Workouts, ActionTraining); got != "t" {
+		t.Errorf("Key(training) = %q, want the rebound %q", got, "t")
+	}
+	if got := km.Key(page.Sleep, ActionOlder); got != "←" {
+		t.Errorf("Key(older) = %q, want %q", got, "←")
+	}
+	if got := km.Key(page.Sleep, ActionTraining); got != "" {
+		t.Errorf("Key(training) on sleep = %q, want none", got)
+	}
+}
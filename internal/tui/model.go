@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"sync"
@@ -9,28 +10,50 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
+	"github.com/garrettladley/thoop/internal/client/whoop"
 	"github.com/garrettladley/thoop/internal/oauth"
 	"github.com/garrettladley/thoop/internal/tui/components/footer"
+	"github.com/garrettladley/thoop/internal/tui/components/network"
+	"github.com/garrettladley/thoop/internal/tui/components/toast"
 	"github.com/garrettladley/thoop/internal/tui/page"
 	"github.com/garrettladley/thoop/internal/tui/page/dashboard"
+	"github.com/garrettladley/thoop/internal/tui/page/insights"
+	"github.com/garrettladley/thoop/internal/tui/page/notifications"
 	"github.com/garrettladley/thoop/internal/tui/page/onboarding"
+	"github.com/garrettladley/thoop/internal/tui/page/sleep"
 	"github.com/garrettladley/thoop/internal/tui/page/splash"
+	"github.com/garrettladley/thoop/internal/tui/page/trends"
+	"github.com/garrettladley/thoop/internal/tui/page/workouts"
 	"github.com/garrettladley/thoop/internal/tui/theme"
 	"github.com/garrettladley/thoop/internal/xslog"
+	"github.com/garrettladley/thoop/internal/xsync"
 )
 
 var _ tea.Model = (*Model)(nil)
 
 const (
-	tokenCheckInterval    = 5 * time.Minute
-	tokenRefreshThreshold = 15 * time.Minute
+	tokenCheckInterval        = 5 * time.Minute
+	tokenRefreshThreshold     = 15 * time.Minute
+	connectivityCheckInterval = 30 * time.Second
+	backfillPollInterval      = 5 * time.Second
+	pendingReconcileInterval  = 5 * time.Minute
 )
 
 type state struct {
-	splash      splash.State
-	onboarding  onboarding.State
-	dashboard   dashboard.State
-	authChecked bool
+	splash        splash.State
+	onboarding    onboarding.State
+	dashboard     dashboard.State
+	trends        trends.State
+	sleep         sleep.State
+	workouts      workouts.State
+	insights      insights.State
+	notifications notifications.State
+	authChecked   bool
+
+	toasts       []toast.Toast
+	toastTicking bool
+
+	backfillPolling bool
 }
 
 type Model struct {
@@ -39,31 +62,62 @@ type Model struct {
 	viewportWidth  int
 	viewportHeight int
 	theme          theme.Theme
+	keymap         *Keymap
+	showHelp       bool
 	state          state
 	deps           Deps
-	sseOnce        sync.Once
+	tokenCheckOnce sync.Once
+	pendingOnce    sync.Once
+
+	// liveCtx scopes the SSE stream and the backfill, which only run while
+	// online. cancelLive is nil while they are stopped.
+	liveCtx    context.Context
+	cancelLive context.CancelFunc
 }
 
 func New(deps Deps) Model {
+	keymap := deps.Keymap
+	if keymap == nil {
+		keymap = DefaultKeymap()
+	}
+	t := theme.New()
+	if deps.Theme != nil {
+		t = *deps.Theme
+	}
+
 	return Model{
-		page:  page.Splash,
-		theme: theme.New(),
-		deps:  deps,
+		page:   page.Splash,
+		theme:  t,
+		keymap: keymap,
+		deps:   deps,
 		state: state{
 			splash:     splash.State{},
 			onboarding: onboarding.State{},
-			dashboard:  dashboard.State{},
+			dashboard: dashboard.State{
+				NetworkIndicator: network.Indicator{
+					Offline: deps.Offline,
+					Forced:  deps.ForceOffline,
+				},
+			},
+			trends: trends.State{},
 		},
 	}
 }
 
 func (m *Model) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		tea.Tick(splash.Duration, func(t time.Time) tea.Msg {
 			return splash.TickMsg{}
 		}),
 		onboarding.CheckAuthCmd(m.deps.Ctx, m.deps.TokenChecker),
-	)
+	}
+
+	// forced offline mode never touches the network, so there is nothing to probe
+	if !m.deps.ForceOffline {
+		cmds = append(cmds, ConnectivityTickCmd(connectivityCheckInterval))
+	}
+
+	return tea.Batch(cmds...)
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -91,45 +145,75 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case onboarding.TokenRefreshResultMsg:
 		return m.handleTokenRefreshResult(msg)
 
-	case dashboard.CycleMsg:
-		if msg.Err != nil {
-			return m, nil
-		}
-		if msg.Cycle != nil {
-			m.state.dashboard.CycleID = msg.Cycle.ID
-			if msg.Cycle.Score != nil {
-				m.state.dashboard.StrainScore = &msg.Cycle.Score.Strain
-			}
-			return m, tea.Batch(
-				dashboard.FetchSleepCmd(m.deps.Ctx, m.deps.WhoopClient, msg.Cycle.ID),
-				dashboard.FetchRecoveryCmd(m.deps.Ctx, m.deps.WhoopClient, msg.Cycle.ID),
-			)
-		}
-		return m, nil
+	case ConnectivityTickMsg:
+		return m, ProbeConnectivityCmd(m.deps.Ctx, m.deps.HealthClient)
 
-	case dashboard.SleepMsg:
-		if msg.Err == nil && msg.Sleep != nil && msg.Sleep.Score != nil {
-			m.state.dashboard.SleepScore = &msg.Sleep.Score.SleepPerformancePercentage
-		}
-		return m, nil
+	case ConnectivityMsg:
+		return m.handleConnectivity(msg)
+
+	case ReconciledMsg:
+		return m.handleReconciled(msg)
+
+	case BackfillResumedMsg:
+		return m.handleBackfillResumed(msg)
 
-	case dashboard.RecoveryMsg:
-		if msg.Err == nil && msg.Recovery != nil && msg.Recovery.Score != nil {
-			m.state.dashboard.RecoveryScore = &msg.Recovery.Score.RecoveryScore
+	case PendingTickMsg:
+		// pending records are re-polled once connectivity comes back
+		if m.offline() {
+			return m, PendingTickCmd(pendingReconcileInterval)
 		}
-		return m, nil
+		return m, ReconcilePendingCmd(m.deps.Ctx, m.deps.Reconciler)
+
+	case PendingReconciledMsg:
+		return m.handlePendingReconciled(msg)
+
+	case dashboard.SnapshotMsg:
+		return m.handleDashboardSnapshot(msg)
+
+	case dashboard.StepMsg:
+		return m.handleDashboardStep(msg)
+
+	case dashboard.GoalsMsg:
+		return m.handleDashboardGoals(msg)
+
+	case dashboard.InsightsMsg:
+		return m.handleDashboardInsights(msg)
+
+	case dashboard.BackfillProgressMsg:
+		return m.handleBackfillProgress(msg)
+
+	case trends.DataMsg:
+		return m.handleTrendsData(msg)
+
+	case trends.HistoricalMsg:
+		return m.handleTrendsHistorical(msg)
+
+	case sleep.DataMsg:
+		return m.handleSleepData(msg)
+
+	case workouts.DataMsg:
+		return m.handleWorkoutsData(msg)
+
+	case workouts.TrainingMsg:
+		return m.handleWorkoutsTraining(msg)
+
+	case insights.DataMsg:
+		return m.handleInsightsData(msg)
 
 	case NotificationMsg:
-		if m.page == page.Dashboard {
-			return m, tea.Batch(
-				dashboard.FetchCycleCmd(m.deps.Ctx, m.deps.WhoopClient),
-				ListenNotificationsCmd(m.deps.Ctx, m.deps.NotificationChan, m.deps.NotifProcessor, m.deps.SSEClient),
-			)
+		return m.handleNotification(msg)
+
+	case ToastTickMsg:
+		m.state.toasts = toast.Prune(m.state.toasts, time.Now())
+		if len(m.state.toasts) == 0 {
+			m.state.toastTicking = false
+			return m, nil
 		}
-		return m, ListenNotificationsCmd(m.deps.Ctx, m.deps.NotificationChan, m.deps.NotifProcessor, m.deps.SSEClient)
+		return m, ToastTickCmd()
 
 	case SSEDisconnectedMsg:
-		if msg.Err != nil {
+		// going offline cancels the stream on purpose
+		if msg.Err != nil && !errors.Is(msg.Err, context.Canceled) {
 			m.deps.Logger.WarnContext(m.deps.Ctx, "SSE disconnected", xslog.Error(msg.Err))
 		}
 		return m, nil
@@ -139,32 +223,318 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "ctrl+c":
+	action, _ := m.keymap.Lookup(m.page, msg.String())
+	switch action {
+	case ActionQuit:
 		m.deps.Cancel()
 		return m, tea.Quit
-	case "enter":
-		switch m.page {
-		case page.Onboarding:
+	case ActionHelp:
+		if m.onDataPage() || m.page == page.Onboarding {
+			m.showHelp = !m.showHelp
+			return m, nil
+		}
+	default:
+	}
+
+	// the overlay swallows keys until it's closed
+	if m.showHelp {
+		if msg.String() == "esc" {
+			m.showHelp = false
+		}
+		return m, nil
+	}
+
+	switch m.page {
+	case page.Splash:
+		// skip splash on any keypress (only if auth is checked)
+		if m.state.authChecked {
+			if m.state.dashboard.AuthIndicator.Authenticated {
+				m.page = page.Dashboard
+				return m, m.startDashboard()
+			}
+			m.page = page.Onboarding
+		}
+	case page.Onboarding:
+		if action == ActionSignIn {
 			switch m.state.onboarding.Phase {
 			case onboarding.PhaseWelcome, onboarding.PhaseError:
+				if m.offline() {
+					m.state.onboarding.Phase = onboarding.PhaseError
+					m.state.onboarding.ErrorMsg = "can't sign in while offline"
+					return m, nil
+				}
 				m.state.onboarding.Phase = onboarding.PhaseAuthenticating
 				m.state.onboarding.ErrorMsg = ""
 				return m, onboarding.StartAuthFlowCmd(m.deps.Ctx, m.deps.AuthFlow)
 			default:
 			}
-		default:
 		}
+	case page.Dashboard:
+		return m.handleDashboardKey(action)
+	case page.Trends:
+		return m.handleTrendsKey(action)
+	case page.Sleep:
+		return m.handleSleepKey(action)
+	case page.Workouts:
+		return m.handleWorkoutsKey(action)
+	case page.Insights:
+		return m.handleInsightsKey(action)
+	case page.Notifications:
+		return m.handleNotificationsKey(action)
+	}
+	return m, nil
+}
+
+func (m *Model) handleTrendsKey(action Action) (tea.Model, tea.Cmd) {
+	switch action {
+	case ActionNextPage:
+		m.page = page.Sleep
+		return m, m.loadSleep()
+	case ActionWeek:
+		return m, m.switchTrendsWindow(trends.WindowWeek)
+	case ActionMonth:
+		return m, m.switchTrendsWindow(trends.WindowMonth)
+	case ActionQuarter:
+		return m, m.switchTrendsWindow(trends.WindowQuarter)
 	default:
-		// skip splash on any keypress (only if auth is checked)
-		if m.page == page.Splash && m.state.authChecked {
-			if m.state.dashboard.AuthIndicator.Authenticated {
-				m.page = page.Dashboard
-				return m, m.startDashboard()
-			} else {
-				m.page = page.Onboarding
-			}
+	}
+	return m, nil
+}
+
+func (m *Model) switchTrendsWindow(window trends.Window) tea.Cmd {
+	if window == m.state.trends.Window {
+		return nil
+	}
+	m.state.trends.Window = window
+	return m.loadTrends()
+}
+
+func (m *Model) loadTrends() tea.Cmd {
+	m.state.trends.Loading = true
+	m.state.trends.ErrMsg = ""
+	return trends.LoadCmd(m.deps.Ctx, m.deps.Repository, m.state.trends.Window)
+}
+
+func (m *Model) handleTrendsData(msg trends.DataMsg) (tea.Model, tea.Cmd) {
+	// ignore results for a window the user has already switched away from
+	if msg.Window != m.state.trends.Window {
+		return m, nil
+	}
+
+	m.state.trends.Loading = false
+	if msg.Err != nil {
+		m.deps.Logger.WarnContext(m.deps.Ctx, "failed to load trends", xslog.Error(msg.Err))
+		m.state.trends.ErrMsg = "failed to load trends"
+		return m, nil
+	}
+	m.state.trends.Days = msg.Days
+
+	start, end, ok := m.state.trends.NeedsHistorical(time.Now(), msg.Watermark)
+	if !ok || m.offline() {
+		return m, nil
+	}
+
+	m.state.trends.Syncing = true
+	m.state.trends.HistoricalFrom = &start
+	return m, trends.FetchHistoricalCmd(m.deps.Ctx, m.deps.SyncService, msg.Window, start, end)
+}
+
+func (m *Model) handleTrendsHistorical(msg trends.HistoricalMsg) (tea.Model, tea.Cmd) {
+	m.state.trends.Syncing = false
+	if msg.Err != nil {
+		m.deps.Logger.WarnContext(m.deps.Ctx, "failed to fetch historical data",
+			xslog.Start(msg.Start),
+			xslog.Error(msg.Err))
+		m.state.trends.ErrMsg = "failed to fetch history"
+		return m, nil
+	}
+
+	if m.page != page.Trends {
+		return m, nil
+	}
+	return m, m.loadTrends()
+}
+
+func (m *Model) handleSleepKey(action Action) (tea.Model, tea.Cmd) {
+	switch action {
+	case ActionNextPage:
+		m.page = page.Workouts
+		return m, m.loadWorkouts()
+	case ActionOlder:
+		if m.state.sleep.Older() {
+			m.state.sleep.Loading = true
+			return m, sleep.LoadCmd(m.deps.Ctx, m.deps.Repository, m.state.sleep.Cursor)
 		}
+	case ActionNewer:
+		m.state.sleep.Newer()
+	default:
+	}
+	return m, nil
+}
+
+// loadSleep reloads the sleep page from the most recent night.
+func (m *Model) loadSleep() tea.Cmd {
+	m.state.sleep = sleep.State{Loading: true}
+	return sleep.LoadCmd(m.deps.Ctx, m.deps.Repository, nil)
+}
+
+func (m *Model) handleSleepData(msg sleep.DataMsg) (tea.Model, tea.Cmd) {
+	s := &m.state.sleep
+	s.Loading = false
+	if msg.Err != nil {
+		m.deps.Logger.WarnContext(m.deps.Ctx, "failed to load sleeps", xslog.Error(msg.Err))
+		s.ErrMsg = "failed to load sleeps"
+		return m, nil
+	}
+
+	// stepping back past the loaded nights triggered this page, so land on
+	// the first night it added
+	stepBack := len(s.Nights) > 0
+	before := len(s.Nights)
+	s.Append(msg.Sleeps, msg.NextCursor)
+	if stepBack && len(s.Nights) > before {
+		s.Index = before
+	}
+
+	// a page of only naps has no night to show, so keep paging
+	if len(s.Nights) == before && !s.Exhausted {
+		s.Loading = true
+		return m, sleep.LoadCmd(m.deps.Ctx, m.deps.Repository, s.Cursor)
+	}
+	return m, nil
+}
+
+func (m *Model) handleWorkoutsKey(action Action) (tea.Model, tea.Cmd) {
+	w := &m.state.workouts
+	switch action {
+	case ActionNextPage:
+		m.page = page.Insights
+		return m, m.loadInsights()
+	case ActionDown:
+		if w.Down() {
+			w.Loading = true
+			return m, workouts.LoadCmd(m.deps.Ctx, m.deps.Repository, w.Cursor)
+		}
+	case ActionUp:
+		w.Up()
+	case ActionOpen:
+		w.Detail = w.Current() != nil
+	case ActionClose:
+		w.Detail = false
+	case ActionTraining:
+		w.ShowTraining = !w.ShowTraining && w.Training != nil
+	default:
+	}
+	return m, nil
+}
+
+func (m *Model) handleInsightsKey(action Action) (tea.Model, tea.Cmd) {
+	switch action {
+	case ActionNextPage:
+		m.page = page.Notifications
+	case ActionMethod:
+		m.state.insights.ToggleMethod()
+	default:
+	}
+	return m, nil
+}
+
+// loadInsights recomputes the insights page from the cache, keeping the
+// method and the last correlations on screen while it loads.
+func (m *Model) loadInsights() tea.Cmd {
+	m.state.insights.Loading = true
+	m.state.insights.ErrMsg = ""
+	return insights.LoadCmd(m.deps.Ctx, m.deps.Repository)
+}
+
+func (m *Model) handleInsightsData(msg insights.DataMsg) (tea.Model, tea.Cmd) {
+	s := &m.state.insights
+	s.Loading = false
+	if msg.Err != nil {
+		m.deps.Logger.WarnContext(m.deps.Ctx, "failed to load insights", xslog.Error(msg.Err))
+		s.ErrMsg = "failed to load insights"
+		return m, nil
+	}
+	s.Correlations = msg.Correlations
+	s.Days = msg.Days
+	s.Circadian = msg.Circadian
+	return m, nil
+}
+
+func (m *Model) handleNotificationsKey(action Action) (tea.Model, tea.Cmd) {
+	switch action {
+	case ActionNextPage:
+		m.page = page.Dashboard
+	case ActionDown:
+		m.state.notifications.Down()
+	case ActionUp:
+		m.state.notifications.Up()
+	default:
+	}
+	return m, nil
+}
+
+func (m *Model) handleNotification(msg NotificationMsg) (tea.Model, tea.Cmd) {
+	var (
+		now          = time.Now()
+		text, accent = describeNotification(m.theme.Palette(), msg.Result)
+	)
+	m.state.notifications.Add(notifications.Entry{At: now, Text: text, Accent: accent})
+	m.state.toasts = toast.Prune(append(m.state.toasts, toast.New(text, accent, now)), now)
+
+	var cmds []tea.Cmd
+	// the listener stops with the SSE stream when going offline
+	if m.cancelLive != nil {
+		cmds = append(cmds, ListenNotificationsCmd(m.liveCtx, m.deps.NotificationChan, m.deps.NotifProcessor, m.deps.SSEClient))
+	}
+	if !m.state.toastTicking {
+		m.state.toastTicking = true
+		cmds = append(cmds, ToastTickCmd())
+	}
+	if m.page == page.Dashboard {
+		// the processor has already written the update to the cache
+		cmds = append(cmds, dashboard.LoadCachedCmd(m.deps.Ctx, m.deps.Repository))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// loadWorkouts reloads the workouts page from the most recent workout.
+func (m *Model) loadWorkouts() tea.Cmd {
+	m.state.workouts = workouts.State{
+		Loading:     true,
+		Training:    m.state.workouts.Training,
+		TrainingKey: m.keymap.Key(page.Workouts, ActionTraining),
+	}
+	return tea.Batch(
+		workouts.LoadCmd(m.deps.Ctx, m.deps.Repository, nil),
+		workouts.TrainingCmd(m.deps.Ctx, m.deps.Repository),
+	)
+}
+
+func (m *Model) handleWorkoutsTraining(msg workouts.TrainingMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.deps.Logger.WarnContext(m.deps.Ctx, "failed to load training load", xslog.Error(msg.Err))
+		return m, nil
+	}
+	m.state.workouts.Training = msg.Report
+	return m, nil
+}
+
+func (m *Model) handleWorkoutsData(msg workouts.DataMsg) (tea.Model, tea.Cmd) {
+	w := &m.state.workouts
+	w.Loading = false
+	if msg.Err != nil {
+		m.deps.Logger.WarnContext(m.deps.Ctx, "failed to load workouts", xslog.Error(msg.Err))
+		w.ErrMsg = "failed to load workouts"
+		return m, nil
+	}
+
+	// moving down past the loaded workouts triggered this page, so select
+	// the first workout it added
+	before := len(w.Workouts)
+	w.Append(msg.Workouts, msg.NextCursor)
+	if before > 0 && len(w.Workouts) > before {
+		w.Selected = before
 	}
 	return m, nil
 }
@@ -216,9 +586,12 @@ func (m *Model) handleAuthFlowResult(msg onboarding.AuthFlowResultMsg) (tea.Mode
 }
 
 func (m *Model) handleTokenCheckTick() (tea.Model, tea.Cmd) {
-	if m.page != page.Dashboard {
+	if !m.onDataPage() {
 		return m, nil
 	}
+	if m.offline() {
+		return m, onboarding.TokenCheckTickCmd(tokenCheckInterval)
+	}
 
 	return m, onboarding.RefreshTokenIfNeededCmd(m.deps.Ctx, m.deps.TokenSource, tokenRefreshThreshold)
 }
@@ -236,30 +609,288 @@ func (m *Model) handleTokenRefreshResult(msg onboarding.TokenRefreshResultMsg) (
 	return m, onboarding.TokenCheckTickCmd(tokenCheckInterval)
 }
 
+func (m *Model) handleDashboardSnapshot(msg dashboard.SnapshotMsg) (tea.Model, tea.Cmd) {
+	if msg.Source == dashboard.SourceAPI {
+		m.state.dashboard.Revalidating = false
+	}
+	m.state.dashboard.Synced(msg.LastSync)
+	if msg.Err != nil {
+		m.deps.Logger.WarnContext(m.deps.Ctx, "failed to load dashboard", xslog.Error(msg.Err))
+		return m, nil
+	}
+	if msg.Cycle == nil {
+		return m, nil
+	}
+
+	// snapshots are always of the latest cycle, so showing one while browsing
+	// would jump away from the cycle being looked at
+	if m.state.dashboard.Browsing {
+		return m, nil
+	}
+	return m, m.showDashboard(msg.Cycle, msg.Recovery, msg.Sleep)
+}
+
+// showDashboard shows cycle on the dashboard, loading its insights when it
+// wasn't already shown.
+func (m *Model) showDashboard(cycle *whoop.Cycle, recovery *whoop.Recovery, sleep *whoop.Sleep) tea.Cmd {
+	d := &m.state.dashboard
+	changed := d.CycleID != cycle.ID
+	d.Show(cycle, recovery, sleep)
+
+	// goals follow the current period rather than the cycle, and any new
+	// data may move them
+	goalsCmd := dashboard.GoalsCmd(m.deps.Ctx, m.deps.Repository)
+	if !changed {
+		return goalsCmd
+	}
+	return tea.Batch(goalsCmd, dashboard.InsightsCmd(m.deps.Ctx, m.deps.Repository, cycle.ID, cycle.Start))
+}
+
+func (m *Model) handleDashboardGoals(msg dashboard.GoalsMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.deps.Logger.WarnContext(m.deps.Ctx, "failed to load goals", xslog.Error(msg.Err))
+		return m, nil
+	}
+	m.state.dashboard.Goals = msg.Progress
+	return m, nil
+}
+
+func (m *Model) handleDashboardInsights(msg dashboard.InsightsMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.deps.Logger.WarnContext(m.deps.Ctx, "failed to load insights", xslog.Error(msg.Err))
+		return m, nil
+	}
+	// a late result for a cycle no longer shown is dropped
+	if msg.CycleID == m.state.dashboard.CycleID {
+		m.state.dashboard.Anomalies = msg.Anomalies
+	}
+	return m, nil
+}
+
+func (m *Model) handleDashboardKey(action Action) (tea.Model, tea.Cmd) {
+	d := &m.state.dashboard
+	switch action {
+	case ActionNextPage:
+		m.page = page.Trends
+		return m, m.loadTrends()
+	case ActionPreviousDay:
+		return m, m.stepDashboard(dashboard.DirectionPrevious)
+	case ActionNextDay:
+		// the latest cycle has nothing after it
+		if d.Browsing {
+			return m, m.stepDashboard(dashboard.DirectionNext)
+		}
+	case ActionToday:
+		if d.Browsing {
+			d.Browsing = false
+			return m, m.startDashboard()
+		}
+	default:
+	}
+	return m, nil
+}
+
+func (m *Model) stepDashboard(dir dashboard.Direction) tea.Cmd {
+	d := &m.state.dashboard
+	if d.Stepping || d.CycleStart.IsZero() {
+		return nil
+	}
+	d.Stepping = true
+	return dashboard.StepCmd(m.deps.Ctx, m.fetcher(), d.CycleStart, dir)
+}
+
+func (m *Model) handleDashboardStep(msg dashboard.StepMsg) (tea.Model, tea.Cmd) {
+	d := &m.state.dashboard
+	d.Stepping = false
+	if msg.Err != nil {
+		m.deps.Logger.WarnContext(m.deps.Ctx, "failed to load cycle", xslog.Error(msg.Err))
+		return m, nil
+	}
+
+	if msg.Cycle == nil {
+		// stepping forward past the newest cached cycle lands back on today
+		if msg.Direction == dashboard.DirectionNext {
+			d.Browsing = false
+			return m, m.startDashboard()
+		}
+		return m, nil
+	}
+
+	d.Browsing = true
+	return m, m.showDashboard(msg.Cycle, msg.Recovery, msg.Sleep)
+}
+
+func (m *Model) offline() bool {
+	return m.state.dashboard.NetworkIndicator.Offline
+}
+
+// fetcher returns the DataFetcher to read through, which only reads the cache
+// while offline so that days missing from it don't go to the API.
+func (m *Model) fetcher() xsync.DataFetcher {
+	if m.offline() {
+		return xsync.NewCacheFetcher(m.deps.Repository)
+	}
+	return m.deps.DataFetcher
+}
+
+func (m *Model) handleConnectivity(msg ConnectivityMsg) (tea.Model, tea.Cmd) {
+	next := ConnectivityTickCmd(connectivityCheckInterval)
+	indicator := &m.state.dashboard.NetworkIndicator
+
+	switch {
+	case !msg.Online && !indicator.Offline:
+		m.deps.Logger.WarnContext(m.deps.Ctx, "lost connectivity, switching to offline mode", xslog.Error(msg.Err))
+		indicator.Offline = true
+		m.state.dashboard.Revalidating = false
+		m.stopLive()
+	case msg.Online && indicator.Offline && !indicator.Syncing:
+		m.deps.Logger.InfoContext(m.deps.Ctx, "connectivity restored, reconciling")
+		indicator.Syncing = true
+		// the next probe is scheduled once reconciliation finishes
+		return m, ReconcileCmd(m.deps.Ctx, m.deps.SyncService)
+	}
+
+	return m, next
+}
+
+func (m *Model) handleReconciled(msg ReconciledMsg) (tea.Model, tea.Cmd) {
+	indicator := &m.state.dashboard.NetworkIndicator
+	indicator.Syncing = false
+
+	cmds := []tea.Cmd{ConnectivityTickCmd(connectivityCheckInterval)}
+	if msg.Err != nil {
+		m.deps.Logger.WarnContext(m.deps.Ctx, "failed to reconcile", xslog.Error(msg.Err))
+		return m, tea.Batch(cmds...)
+	}
+
+	indicator.Offline = false
+	if m.onDataPage() {
+		cmds = append(cmds, m.startDashboard())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m *Model) handleBackfillResumed(msg BackfillResumedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.deps.Logger.WarnContext(m.deps.Ctx, "failed to resume backfill", xslog.Error(msg.Err))
+		return m, nil
+	}
+	return m, m.pollBackfill()
+}
+
+func (m *Model) handlePendingReconciled(msg PendingReconciledMsg) (tea.Model, tea.Cmd) {
+	next := PendingTickCmd(pendingReconcileInterval)
+	if msg.Err != nil {
+		m.deps.Logger.WarnContext(m.deps.Ctx, "failed to reconcile pending scores", xslog.Error(msg.Err))
+		return m, next
+	}
+
+	if msg.Result.Resolved() > 0 && m.page == page.Dashboard {
+		return m, tea.Batch(next, dashboard.LoadCachedCmd(m.deps.Ctx, m.deps.Repository))
+	}
+	return m, next
+}
+
+func (m *Model) handleBackfillProgress(msg dashboard.BackfillProgressMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.deps.Logger.WarnContext(m.deps.Ctx, "failed to get backfill progress", xslog.Error(msg.Err))
+		m.state.backfillPolling = false
+		return m, nil
+	}
+
+	m.state.dashboard.Backfill = msg.Progress
+	if !msg.Progress.Running {
+		m.state.backfillPolling = false
+		return m, nil
+	}
+
+	return m, tea.Tick(backfillPollInterval, func(time.Time) tea.Msg {
+		return dashboard.BackfillProgressCmd(m.deps.Ctx, m.deps.SyncService)()
+	})
+}
+
+func (m *Model) pollBackfill() tea.Cmd {
+	if m.state.backfillPolling {
+		return nil
+	}
+	m.state.backfillPolling = true
+	return dashboard.BackfillProgressCmd(m.deps.Ctx, m.deps.SyncService)
+}
+
+// onDataPage reports whether the user is past the splash and onboarding pages.
+func (m *Model) onDataPage() bool {
+	switch m.page {
+	case page.Splash, page.Onboarding:
+		return false
+	default:
+		return true
+	}
+}
+
 func (m *Model) startDashboard() tea.Cmd {
+	if m.offline() {
+		return dashboard.LoadCachedCmd(m.deps.Ctx, m.deps.Repository)
+	}
+
+	m.state.dashboard.Revalidating = true
+
 	cmds := []tea.Cmd{
-		dashboard.FetchCycleCmd(m.deps.Ctx, m.deps.WhoopClient),
-		onboarding.TokenCheckTickCmd(tokenCheckInterval),
+		// render from the cache first, then revalidate against the API
+		tea.Sequence(
+			dashboard.LoadCachedCmd(m.deps.Ctx, m.deps.Repository),
+			dashboard.RevalidateCmd(m.deps.Ctx, m.deps.DataFetcher),
+		),
+		m.pollBackfill(),
 	}
 
-	m.sseOnce.Do(func() {
-		cmds = append(cmds,
-			StartSSECmd(m.deps.Ctx, m.deps.SSEClient, m.deps.NotificationChan),
-			ListenNotificationsCmd(m.deps.Ctx, m.deps.NotificationChan, m.deps.NotifProcessor, m.deps.SSEClient),
-		)
+	// startDashboard runs again when connectivity comes back,
+	// so the long-lived loops must only be started once
+	m.tokenCheckOnce.Do(func() {
+		cmds = append(cmds, onboarding.TokenCheckTickCmd(tokenCheckInterval))
+	})
+
+	m.pendingOnce.Do(func() {
+		cmds = append(cmds, ReconcilePendingCmd(m.deps.Ctx, m.deps.Reconciler))
 	})
 
+	cmds = append(cmds, m.startLive())
+
 	return tea.Batch(cmds...)
 }
 
+// startLive starts the SSE stream and resumes the backfill unless they are
+// already running.
+func (m *Model) startLive() tea.Cmd {
+	if m.cancelLive != nil {
+		return nil
+	}
+	m.liveCtx, m.cancelLive = context.WithCancel(m.deps.Ctx)
+
+	return tea.Batch(
+		StartSSECmd(m.liveCtx, m.deps.SSEClient, m.deps.NotificationChan),
+		ListenNotificationsCmd(m.liveCtx, m.deps.NotificationChan, m.deps.NotifProcessor, m.deps.SSEClient),
+		ResumeBackfillCmd(m.liveCtx, m.deps.SyncService),
+	)
+}
+
+// stopLive stops the SSE stream and the backfill until startLive runs again.
+// The backfill checkpoints every page, so it picks up where it left off.
+func (m *Model) stopLive() {
+	if m.cancelLive == nil {
+		return
+	}
+	m.cancelLive()
+	m.cancelLive = nil
+}
+
 func (m *Model) View() tea.View {
 	view := tea.NewView("")
 	view.AltScreen = true
 
-	// splash and onboarding use pure black BG, dashboard uses default dark
+	// splash and onboarding sit on the backdrop, the data pages on the background
 	switch m.page {
 	case page.Splash, page.Onboarding:
-		view.BackgroundColor = theme.ColorBlack
+		view.BackgroundColor = m.theme.Backdrop()
 	default:
 		view.BackgroundColor = m.theme.Background()
 	}
@@ -268,6 +899,22 @@ func (m *Model) View() tea.View {
 		return view
 	}
 
+	var content string
+	switch {
+	case m.showHelp && m.page == page.Onboarding:
+		content = helpView(m.theme, m.keymap, m.page, m.viewportWidth, m.viewportHeight)
+	case m.showHelp:
+		help := helpView(m.theme, m.keymap, m.page, m.viewportWidth, m.viewportHeight)
+		content = m.overlayStrings(help, m.footerView())
+	default:
+		content = m.pageView()
+	}
+
+	view.SetContent(content)
+	return view
+}
+
+func (m *Model) pageView() string {
 	var content string
 	switch m.page {
 	case page.Splash:
@@ -275,23 +922,59 @@ func (m *Model) View() tea.View {
 	case page.Onboarding:
 		content = onboarding.View(m.theme, m.state.onboarding, m.viewportWidth, m.viewportHeight)
 	case page.Dashboard:
-		gauges := dashboard.View(m.state.dashboard, m.viewportWidth, m.viewportHeight)
+		gauges := dashboard.View(m.theme, m.state.dashboard, m.viewportWidth, m.viewportHeight)
+		content = m.overlayStrings(gauges, m.footerView())
+	case page.Trends:
+		charts := trends.View(m.theme, m.state.trends, m.viewportWidth, m.viewportHeight)
+		content = m.overlayStrings(charts, m.footerView())
+	case page.Sleep:
+		detail := sleep.View(m.theme, m.state.sleep, m.viewportWidth, m.viewportHeight)
+		content = m.overlayStrings(detail, m.footerView())
+	case page.Workouts:
+		list := workouts.View(m.theme, m.state.workouts, m.viewportWidth, m.viewportHeight)
+		content = m.overlayStrings(list, m.footerView())
+	case page.Insights:
+		grid := insights.View(m.theme, m.state.insights, m.viewportWidth, m.viewportHeight)
+		content = m.overlayStrings(grid, m.footerView())
+	case page.Notifications:
+		log := notifications.View(m.theme, m.state.notifications, m.viewportWidth, m.viewportHeight)
+		content = m.overlayStrings(log, m.footerView())
+	}
 
-		f := footer.New(dashboard.AuthIndicatorView(m.state.dashboard), m.viewportWidth)
+	if m.onDataPage() {
+		content = m.withToasts(content)
+	}
+	return content
+}
 
-		footerOverlay := lipgloss.Place(
-			m.viewportWidth,
-			m.viewportHeight,
-			lipgloss.Left,
-			lipgloss.Bottom,
-			f.Render(),
-		)
+// withToasts layers the live toasts over the top right corner of content.
+func (m *Model) withToasts(content string) string {
+	toasts := toast.Render(m.state.toasts, time.Now(), m.theme.Palette())
+	if toasts == "" {
+		return content
+	}
 
-		content = m.overlayStrings(gauges, footerOverlay)
+	x := max(m.viewportWidth-lipgloss.Width(toasts)-2, 0)
+	return lipgloss.NewCanvas(
+		lipgloss.NewLayer(content),
+		lipgloss.NewLayer(toasts).X(x).Y(1).Z(1),
+	).Render()
+}
+
+func (m *Model) footerView() string {
+	status := dashboard.SyncStatusView(m.theme, m.state.dashboard, time.Now()) + "  " + dashboard.AuthIndicatorView(m.theme, m.state.dashboard)
+	if network := dashboard.NetworkIndicatorView(m.theme, m.state.dashboard); network != "" {
+		status += "  " + network
 	}
+	f := footer.New(m.theme, status, m.viewportWidth).WithHints(m.keymap.Hints(m.page))
 
-	view.SetContent(content)
-	return view
+	return lipgloss.Place(
+		m.viewportWidth,
+		m.viewportHeight,
+		lipgloss.Left,
+		lipgloss.Bottom,
+		f.Render(),
+	)
 }
 
 func (m *Model) overlayStrings(base, overlay string) string {
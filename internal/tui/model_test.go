@@ -0,0 +1,213 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+	"github.com/garrettladley/thoop/internal/tui/page/dashboard"
+	"github.com/garrettladley/thoop/internal/xsync"
+)
+
+// fakeCycleRepo caches a single cycle and nothing else for it.
+type fakeCycleRepo struct {
+	repository.CycleRepository
+
+	cycle whoop.Cycle
+}
+
+func (r fakeCycleRepo) GetByDateRange(_ context.Context, start, end time.Time, _ *repository.CursorParams) (*repository.CursorResult[whoop.Cycle], error) {
+	if r.cycle.Start.Before(start) || r.cycle.Start.After(end) {
+		return &repository.CursorResult[whoop.Cycle]{}, nil
+	}
+	return &repository.CursorResult[whoop.Cycle]{Records: []whoop.Cycle{r.cycle}}, nil
+}
+
+func (r fakeCycleRepo) GetLatest(context.Context, int) ([]whoop.Cycle, error) {
+	return []whoop.Cycle{r.cycle}, nil
+}
+
+type fakeRecoveryRepo struct {
+	repository.RecoveryRepository
+}
+
+func (fakeRecoveryRepo) Get(context.Context, int64) (*whoop.Recovery, error) { return nil, nil }
+
+func (fakeRecoveryRepo) Upsert(context.Context, *whoop.Recovery) error { return nil }
+
+type fakeSleepRepo struct {
+	repository.SleepRepository
+}
+
+func (fakeSleepRepo) GetByCycleID(context.Context, int64) (*whoop.Sleep, error) { return nil, nil }
+
+func (fakeSleepRepo) Upsert(context.Context, *whoop.Sleep) error { return nil }
+
+type fakeSyncStateRepo struct {
+	repository.SyncStateRepository
+
+	lastFullSync *time.Time
+}
+
+func (r fakeSyncStateRepo) Get(context.Context) (*repository.SyncState, error) {
+	return &repository.SyncState{LastFullSync: r.lastFullSync}, nil
+}
+
+// fakeFetcher serves the current cycle, or fails with err.
+type fakeFetcher struct {
+	xsync.DataFetcher
+
+	cycle *whoop.Cycle
+	err   error
+}
+
+func (f fakeFetcher) GetCurrentCycle(context.Context) (*whoop.Cycle, error) { return f.cycle, f.err }
+
+func (fakeFetcher) GetRecovery(context.Context, int64) (*whoop.Recovery, error) { return nil, nil }
+
+func (fakeFetcher) GetSleep(context.Context, int64) (*whoop.Sleep, error) { return nil, nil }
+
+func TestDashboardRevalidate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lastFullSync = time.Now().Add(-time.Hour)
+		cached       = whoop.Cycle{ID: 1, Start: lastFullSync.Add(-12 * time.Hour)}
+		current      = whoop.Cycle{ID: 2, Start: lastFullSync.Add(-time.Minute)}
+		repo         = &repository.Repository{
+			Cycles:     fakeCycleRepo{cycle: cached},
+			Recoveries: fakeRecoveryRepo{},
+			Sleeps:     fakeSleepRepo{},
+			SyncState:  fakeSyncStateRepo{lastFullSync: &lastFullSync},
+		}
+	)
+
+	// start mirrors startDashboard: the cache renders first, then the
+	// revalidate lands
+	start := func(t *testing.T, fetcher xsync.DataFetcher) *Model {
+		t.Helper()
+
+		m := New(Deps{
+			Ctx:         t.Context(),
+			Logger:      slog.New(slog.DiscardHandler),
+			Repository:  repo,
+			DataFetcher: fetcher,
+		})
+		m.state.dashboard.Revalidating = true
+
+		m.Update(dashboard.LoadCachedCmd(t.Context(), repo)())
+		if got := m.state.dashboard.CycleID; got != cached.ID {
+			t.Fatalf("CycleID after the cache = %d, want %d", got, cached.ID)
+		}
+		if !m.state.dashboard.Revalidating {
+			t.Error("Revalidating cleared by the cached snapshot")
+		}
+
+		m.Update(dashboard.RevalidateCmd(t.Context(), fetcher)())
+		if m.state.dashboard.Revalidating {
+			t.Error("Revalidating still set after the revalidate")
+		}
+		return &m
+	}
+
+	t.Run("revalidate replaces the cache", func(t *testing.T) {
+		t.Parallel()
+
+		m := start(t, fakeFetcher{cycle: &current})
+		if got := m.state.dashboard.CycleID; got != current.ID {
+			t.Errorf("CycleID = %d, want the revalidated %d", got, current.ID)
+		}
+		revalidated := m.state.dashboard.LastSync
+		if revalidated == nil || !revalidated.After(lastFullSync) {
+			t.Fatalf("LastSync = %v, want after the last full sync %s", revalidated, lastFullSync)
+		}
+
+		// notifications reload the cache, which must not move LastSync back
+		m.Update(dashboard.LoadCachedCmd(t.Context(), repo)())
+		if got := m.state.dashboard.LastSync; got == nil || !got.Equal(*revalidated) {
+			t.Errorf("LastSync after reloading the cache = %v, want %s", got, *revalidated)
+		}
+	})
+
+	t.Run("revalidate error keeps the cache", func(t *testing.T) {
+		t.Parallel()
+
+		m := start(t, fakeFetcher{err: errors.New("unavailable")})
+		if got := m.state.dashboard.CycleID; got != cached.ID {
+			t.Errorf("CycleID = %d, want the cached %d", got, cached.ID)
+		}
+		if got := m.state.dashboard.LastSync; got == nil || !got.Equal(lastFullSync) {
+			t.Errorf("LastSync = %v, want the last full sync %s", got, lastFullSync)
+		}
+	})
+}
+
+func TestStepDashboard_Offline(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	var (
+		today     = time.Date(2025, 1, 7, 22, 0, 0, 0, time.UTC)
+		yesterday = whoop.Cycle{ID: 1, Start: today.AddDate(0, 0, -1)}
+		repo      = &repository.Repository{
+			Cycles:     fakeCycleRepo{cycle: yesterday},
+			Recoveries: fakeRecoveryRepo{},
+			Sleeps:     fakeSleepRepo{},
+		}
+		tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+		client      = whoop.New(tokenSource, whoop.WithProxyURL(srv.URL))
+		logger      = slog.New(slog.DiscardHandler)
+	)
+
+	step := func(offline bool) dashboard.StepMsg {
+		m := New(Deps{
+			Ctx:         t.Context(),
+			Logger:      logger,
+			Repository:  repo,
+			DataFetcher: xsync.NewFetcher(client, repo, logger),
+			Offline:     offline,
+		})
+		m.state.dashboard.CycleStart = today
+
+		msg, ok := m.stepDashboard(dashboard.DirectionPrevious)().(dashboard.StepMsg)
+		if !ok {
+			t.Fatal("stepDashboard() didn't return a StepMsg")
+		}
+		return msg
+	}
+
+	msg := step(true)
+	if msg.Err != nil {
+		t.Fatalf("StepMsg.Err = %v", msg.Err)
+	}
+	if msg.Cycle == nil || msg.Cycle.ID != yesterday.ID {
+		t.Errorf("StepMsg.Cycle = %v, want the cached cycle %d", msg.Cycle, yesterday.ID)
+	}
+	if msg.Recovery != nil || msg.Sleep != nil {
+		t.Error("StepMsg has a recovery or sleep the cache doesn't")
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("stepping offline made %d requests, want none", n)
+	}
+
+	// online, the uncached recovery and sleep go to the API
+	step(false)
+	if requests.Load() == 0 {
+		t.Error("stepping online made no requests for the uncached recovery and sleep")
+	}
+}
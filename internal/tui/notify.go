@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/garrettladley/thoop/internal/storage"
+	"github.com/garrettladley/thoop/internal/tui/theme"
+	"github.com/garrettladley/thoop/internal/xsync"
+)
+
+// toastTickInterval paces re-renders while a toast fades.
+const toastTickInterval = 150 * time.Millisecond
+
+type ToastTickMsg struct{}
+
+func ToastTickCmd() tea.Cmd {
+	return tea.Tick(toastTickInterval, func(time.Time) tea.Msg {
+		return ToastTickMsg{}
+	})
+}
+
+// describeNotification summarises a processed notification for a toast, such
+// as "Sleep scored: 87%", along with the color of the data it's about.
+func describeNotification(p theme.Palette, result xsync.ProcessResult) (string, color.Color) {
+	if !result.Success {
+		return "Failed to sync " + string(result.EntityType) + " update", p.LowRecovery
+	}
+
+	if result.Action == storage.ActionDeleted {
+		return capitalize(string(result.EntityType)) + " deleted", p.Dim
+	}
+
+	switch {
+	case result.Workout != nil:
+		w := result.Workout
+		verb := "Workout updated"
+		if result.Created {
+			verb = "New workout"
+		}
+		text := verb + ": " + capitalize(w.SportName)
+		if w.Score != nil {
+			text += fmt.Sprintf(", strain %.1f", w.Score.Strain)
+		}
+		return text, p.Strain
+
+	case result.Sleep != nil:
+		s := result.Sleep
+		noun := "Sleep"
+		if s.Nap {
+			noun = "Nap"
+		}
+		if s.Score != nil {
+			return fmt.Sprintf("%s scored: %.0f%%", noun, s.Score.SleepPerformancePercentage), p.Sleep
+		}
+		if result.Created {
+			return "New " + strings.ToLower(noun), p.Sleep
+		}
+		return noun + " updated", p.Sleep
+
+	case result.Recovery != nil:
+		r := result.Recovery
+		if r.Score == nil {
+			return "Recovery updated", p.Recovery
+		}
+		verb := "updated"
+		if result.Created {
+			verb = "scored"
+		}
+		return fmt.Sprintf("Recovery %s: %.0f%%", verb, r.Score.RecoveryScore), p.Recovery
+
+	default:
+		return capitalize(string(result.EntityType)) + " updated", p.Dim
+	}
+}
+
+// capitalize upper-cases the first letter of s, turning "running" into "Running".
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
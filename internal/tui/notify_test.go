@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/storage"
+	"github.com/garrettladley/thoop/internal/tui/theme"
+	"github.com/garrettladley/thoop/internal/xsync"
+)
+
+func TestDescribeNotification(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		result xsync.ProcessResult
+		want   string
+	}{
+		{
+			name: "scored sleep",
+			result: xsync.ProcessResult{
+				EntityType: storage.EntityTypeSleep,
+				Action:     storage.ActionUpdated,
+				Success:    true,
+				Sleep:      &whoop.Sleep{Score: &whoop.SleepScore{SleepPerformancePercentage: 87.4}},
+			},
+			want: "Sleep scored: 87%",
+		},
+		{
+			name: "new nap pending a score",
+			result: xsync.ProcessResult{
+				EntityType: storage.EntityTypeSleep,
+				Action:     storage.ActionUpdated,
+				Success:    true,
+				Created:    true,
+				Sleep:      &whoop.Sleep{Nap: true},
+			},
+			want: "New nap",
+		},
+		{
+			name: "new workout",
+			result: xsync.ProcessResult{
+				EntityType: storage.EntityTypeWorkout,
+				Action:     storage.ActionUpdated,
+				Success:    true,
+				Created:    true,
+				Workout:    &whoop.Workout{SportName: "running", Score: &whoop.WorkoutScore{Strain: 12.43}},
+			},
+			want: "New workout: Running, strain 12.4",
+		},
+		{
+			name: "new workout with a multi-byte sport name",
+			result: xsync.ProcessResult{
+				EntityType: storage.EntityTypeWorkout,
+				Action:     storage.ActionUpdated,
+				Success:    true,
+				Created:    true,
+				Workout:    &whoop.Workout{SportName: "élan", Score: &whoop.WorkoutScore{Strain: 8}},
+			},
+			want: "New workout: Élan, strain 8.0",
+		},
+		{
+			name: "recovery without score",
+			result: xsync.ProcessResult{
+				EntityType: storage.EntityTypeRecovery,
+				Action:     storage.ActionUpdated,
+				Success:    true,
+				Recovery:   &whoop.Recovery{},
+			},
+			want: "Recovery updated",
+		},
+		{
+			name: "deleted",
+			result: xsync.ProcessResult{
+				EntityType: storage.EntityTypeWorkout,
+				Action:     storage.ActionDeleted,
+				Success:    true,
+			},
+			want: "Workout deleted",
+		},
+		{
+			name: "failed",
+			result: xsync.ProcessResult{
+				EntityType: storage.EntityTypeRecovery,
+				Action:     storage.ActionUpdated,
+			},
+			want: "Failed to sync recovery update",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got, _ := describeNotification(theme.New().Palette(), tt.result); got != tt.want {
+				t.Errorf("describeNotification() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
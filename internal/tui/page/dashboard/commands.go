@@ -5,72 +5,245 @@ import (
 	"time"
 
 	tea "charm.land/bubbletea/v2"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/goals"
+	"github.com/garrettladley/thoop/internal/insights"
+	"github.com/garrettladley/thoop/internal/repository"
+	"github.com/garrettladley/thoop/internal/xsync"
 )
 
-type CycleMsg struct {
-	Cycle *whoop.Cycle
-	Err   error
+type Source uint
+
+const (
+	SourceCache Source = iota
+	SourceAPI
+)
+
+// SnapshotMsg carries the current cycle along with its recovery and sleep.
+// Any field may be nil when the data isn't available yet.
+type SnapshotMsg struct {
+	Source   Source
+	Cycle    *whoop.Cycle
+	Recovery *whoop.Recovery
+	Sleep    *whoop.Sleep
+	LastSync *time.Time
+	Err      error
 }
 
-func FetchCycleCmd(ctx context.Context, client *whoop.Client) tea.Cmd {
-	if client == nil {
-		return func() tea.Msg {
-			return CycleMsg{Cycle: nil}
+// LoadCachedCmd reads the latest cycle and its recovery and sleep from the local cache.
+func LoadCachedCmd(ctx context.Context, repo *repository.Repository) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		msg := SnapshotMsg{Source: SourceCache}
+
+		state, err := repo.SyncState.Get(ctx)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		msg.LastSync = state.LastFullSync
+
+		cycles, err := repo.Cycles.GetLatest(ctx, 1)
+		if err != nil {
+			msg.Err = err
+			return msg
 		}
+		if len(cycles) == 0 {
+			return msg
+		}
+		msg.Cycle = &cycles[0]
+
+		if msg.Recovery, err = repo.Recoveries.Get(ctx, msg.Cycle.ID); err != nil {
+			msg.Err = err
+			return msg
+		}
+		if msg.Sleep, err = repo.Sleeps.GetByCycleID(ctx, msg.Cycle.ID); err != nil {
+			msg.Err = err
+			return msg
+		}
+		return msg
 	}
+}
 
+// RevalidateCmd refreshes the current cycle through the DataFetcher, which
+// only goes to the API for data the cache doesn't have scored yet. It only
+// refreshes one cycle, so the revalidate time is reported to the model rather
+// than recorded as the last full sync.
+func RevalidateCmd(ctx context.Context, fetcher xsync.DataFetcher) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 		defer cancel()
-		cycles, err := client.Cycle.List(ctx, &whoop.ListParams{Limit: 1})
+
+		msg := SnapshotMsg{Source: SourceAPI}
+
+		cycle, err := fetcher.GetCurrentCycle(ctx)
 		if err != nil {
-			return CycleMsg{Err: err}
+			msg.Err = err
+			return msg
 		}
-		if len(cycles.Records) == 0 {
-			return CycleMsg{Cycle: nil}
+		if cycle == nil {
+			return msg
 		}
-		return CycleMsg{Cycle: &cycles.Records[0]}
+		msg.Cycle = cycle
+
+		// recovery and sleep may legitimately be missing for a fresh cycle,
+		// so their errors don't fail the snapshot
+		var g errgroup.Group
+		g.Go(func() error {
+			if recovery, err := fetcher.GetRecovery(ctx, cycle.ID); err == nil {
+				msg.Recovery = recovery
+			}
+			return nil
+		})
+		g.Go(func() error {
+			if sleep, err := fetcher.GetSleep(ctx, cycle.ID); err == nil {
+				msg.Sleep = sleep
+			}
+			return nil
+		})
+		_ = g.Wait()
+
+		now := time.Now()
+		msg.LastSync = &now
+		return msg
 	}
 }
 
-type SleepMsg struct {
-	Sleep *whoop.Sleep
-	Err   error
+type Direction int
+
+const (
+	DirectionPrevious Direction = iota
+	DirectionNext
+)
+
+// stepSearchWindow bounds how far StepCmd looks for the adjacent cycle, which
+// covers days the strap wasn't worn.
+const stepSearchWindow = 7 * 24 * time.Hour
+
+// StepMsg carries the cycle before or after the one shown. Cycle is nil when
+// there is none in that direction.
+type StepMsg struct {
+	Direction Direction
+	Cycle     *whoop.Cycle
+	Recovery  *whoop.Recovery
+	Sleep     *whoop.Sleep
+	Err       error
 }
 
-func FetchSleepCmd(ctx context.Context, client *whoop.Client, cycleID int64) tea.Cmd {
-	if client == nil {
-		return func() tea.Msg {
-			return SleepMsg{Sleep: nil}
+// StepCmd loads the cycle adjacent to the one starting at from, along with its
+// recovery and sleep. The DataFetcher reads the cache first and only goes to
+// the API for days that aren't cached.
+func StepCmd(ctx context.Context, fetcher xsync.DataFetcher, from time.Time, dir Direction) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+
+		msg := StepMsg{Direction: dir}
+
+		var start, end time.Time
+		switch dir {
+		case DirectionPrevious:
+			start, end = from.Add(-stepSearchWindow), from.Add(-time.Second)
+		case DirectionNext:
+			start, end = from.Add(time.Second), from.Add(stepSearchWindow)
+		}
+
+		cycles, err := fetcher.GetCycles(ctx, start, end)
+		if err != nil {
+			msg.Err = err
+			return msg
 		}
+		if len(cycles) == 0 {
+			return msg
+		}
+
+		// cycles come newest first
+		cycle := cycles[0]
+		if dir == DirectionNext {
+			cycle = cycles[len(cycles)-1]
+		}
+		msg.Cycle = &cycle
+
+		var g errgroup.Group
+		g.Go(func() error {
+			if recovery, err := fetcher.GetRecovery(ctx, cycle.ID); err == nil {
+				msg.Recovery = recovery
+			}
+			return nil
+		})
+		g.Go(func() error {
+			if sleep, err := fetcher.GetSleep(ctx, cycle.ID); err == nil {
+				msg.Sleep = sleep
+			}
+			return nil
+		})
+		_ = g.Wait()
+
+		return msg
 	}
+}
+
+// InsightsMsg carries the metrics of a cycle that stray from their baseline.
+type InsightsMsg struct {
+	CycleID   int64
+	Anomalies []insights.Deviation
+	Err       error
+}
 
+// InsightsCmd compares the cycle starting at start against the cached days
+// before it. It only reads the cache.
+func InsightsCmd(ctx context.Context, repo *repository.Repository, cycleID int64, start time.Time) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 		defer cancel()
-		sleep, err := client.Cycle.GetSleep(ctx, cycleID)
-		return SleepMsg{Sleep: sleep, Err: err}
+
+		msg := InsightsMsg{CycleID: cycleID}
+
+		a := insights.New()
+		points, err := insights.Load(ctx, repo, start.Add(-a.Window()), start)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+
+		for _, d := range a.Anomalies(points) {
+			if d.CycleID == cycleID {
+				msg.Anomalies = append(msg.Anomalies, d)
+			}
+		}
+		return msg
 	}
 }
 
-type RecoveryMsg struct {
-	Recovery *whoop.Recovery
+// GoalsMsg carries how far each goal has come this period.
+type GoalsMsg struct {
+	Progress []goals.Progress
 	Err      error
 }
 
-func FetchRecoveryCmd(ctx context.Context, client *whoop.Client, cycleID int64) tea.Cmd {
-	if client == nil {
-		return func() tea.Msg {
-			return RecoveryMsg{Recovery: nil}
-		}
-	}
-
+// GoalsCmd evaluates the stored goals against the cache.
+func GoalsCmd(ctx context.Context, repo *repository.Repository) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 		defer cancel()
-		recovery, err := client.Cycle.GetRecovery(ctx, cycleID)
-		return RecoveryMsg{Recovery: recovery, Err: err}
+
+		progress, err := goals.Track(ctx, repo, time.Now())
+		return GoalsMsg{Progress: progress, Err: err}
+	}
+}
+
+type BackfillProgressMsg struct {
+	Progress *xsync.BackfillProgress
+	Err      error
+}
+
+func BackfillProgressCmd(ctx context.Context, syncService xsync.SyncService) tea.Cmd {
+	return func() tea.Msg {
+		progress, err := syncService.BackfillProgress(ctx)
+		return BackfillProgressMsg{Progress: progress, Err: err}
 	}
 }
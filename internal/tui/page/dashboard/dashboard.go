@@ -1,83 +1,400 @@
 package dashboard
 
 import (
+	"fmt"
 	"image/color"
+	"strings"
+	"time"
 
 	"charm.land/lipgloss/v2"
 
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/goals"
+	"github.com/garrettladley/thoop/internal/insights"
 	"github.com/garrettladley/thoop/internal/tui/components/auth"
 	"github.com/garrettladley/thoop/internal/tui/components/gauge"
+	"github.com/garrettladley/thoop/internal/tui/components/network"
 	"github.com/garrettladley/thoop/internal/tui/theme"
+	"github.com/garrettladley/thoop/internal/xsync"
 )
 
 type State struct {
-	AuthIndicator auth.Indicator
+	AuthIndicator    auth.Indicator
+	NetworkIndicator network.Indicator
 
-	CycleID       int64
-	SleepScore    *float64 // 0-100%
-	RecoveryScore *float64 // 0-100%
-	StrainScore   *float64 // 0-21
+	CycleID        int64
+	CycleStart     time.Time
+	TimezoneOffset string
+	SleepScore     *float64 // 0-100%
+	RecoveryScore  *float64 // 0-100%
+	StrainScore    *float64 // 0-21
+
+	// Anomalies are the cycle's recovery metrics that stray from their baseline.
+	Anomalies []insights.Deviation
+	// Goals are the user's goals for the current week or month. They are
+	// only shown for today.
+	Goals []goals.Progress
+
+	// Browsing is set while a past cycle is shown rather than the latest one.
+	Browsing bool
+	Stepping bool
+
+	LastSync     *time.Time
+	Revalidating bool
+	Backfill     *xsync.BackfillProgress
 }
 
-func View(state State, width, height int) string {
-	var (
-		sleepGauge = gauge.New(
-			state.SleepScore,
-			100,
-			"SLEEP",
-			theme.ColorSleep,
-		)
+// Show replaces the gauges with cycle and its recovery and sleep. A different
+// cycle clears the scores that belonged to the previous one.
+func (s *State) Show(cycle *whoop.Cycle, recovery *whoop.Recovery, sleep *whoop.Sleep) {
+	if cycle.ID != s.CycleID {
+		s.CycleID = cycle.ID
+		s.StrainScore = nil
+		s.RecoveryScore = nil
+		s.SleepScore = nil
+		s.Anomalies = nil
+	}
+	s.CycleStart = cycle.Start
+	s.TimezoneOffset = cycle.TimezoneOffset
 
-		recoveryGauge = gauge.New(
-			state.RecoveryScore,
-			100,
-			"RECOVERY",
-			recoveryColor(state.RecoveryScore),
-		)
-
-		strainGauge = gauge.New(
-			state.StrainScore,
-			21,
-			"STRAIN",
-			theme.ColorStrain,
-		)
-	)
+	if cycle.Score != nil {
+		s.StrainScore = &cycle.Score.Strain
+	}
+	if recovery != nil && recovery.Score != nil {
+		s.RecoveryScore = &recovery.Score.RecoveryScore
+	}
+	if sleep != nil && sleep.Score != nil {
+		s.SleepScore = &sleep.Score.SleepPerformancePercentage
+	}
+}
 
-	gaugeSpacing := "    "
-	gaugesRow := lipgloss.JoinHorizontal(
-		lipgloss.Top,
-		sleepGauge.Render(),
-		gaugeSpacing,
-		recoveryGauge.Render(),
-		gaugeSpacing,
-		strainGauge.Render(),
-	)
+// Synced moves LastSync forward to at. Cached snapshots can be read again
+// after a revalidate, so an older time than the one shown is ignored.
+func (s *State) Synced(at *time.Time) {
+	if at == nil || (s.LastSync != nil && !at.After(*s.LastSync)) {
+		return
+	}
+	s.LastSync = at
+}
+
+// Layout is how the gauges are arranged for a given viewport.
+type Layout uint
+
+const (
+	// LayoutHorizontal puts the three gauges side by side.
+	LayoutHorizontal Layout = iota
+	// LayoutStacked puts the gauges above one another for narrow terminals.
+	LayoutStacked
+	// LayoutCompact drops the gauges for a line of text when neither fits.
+	LayoutCompact
+)
+
+const (
+	gaugeGap = 4
+	// headerHeight is the date header and the blank line under it, and
+	// footerHeight the rows the footer is drawn over.
+	headerHeight = 2
+	footerHeight = 2
+)
+
+// layoutFor picks the largest arrangement that fits in width by height,
+// along with the gauge size it fits at.
+func layoutFor(width, height int) (Layout, int) {
+	rows := height - headerHeight - footerHeight
+
+	// side by side, each gauge's rows are size/2+1
+	size := min(gauge.DefaultSize, (width-2*gaugeGap)/3, 2*(rows-1))
+	if size >= gauge.MinSize {
+		return LayoutHorizontal, size
+	}
+
+	// stacked, the gauges are separated by a blank row
+	size = min(gauge.DefaultSize, width, 2*((rows-2)/3-1))
+	if size >= gauge.MinSize {
+		return LayoutStacked, size
+	}
+
+	return LayoutCompact, 0
+}
+
+func View(t theme.Theme, state State, width, height int) string {
+	p := t.Palette()
+
+	anomalies := anomaliesView(p, state.Anomalies)
+	// the anomalies sit under the gauges, separated by a blank row
+	reserved := 0
+	if anomalies != "" {
+		reserved = lipgloss.Height(anomalies) + 1
+	}
+
+	// the goals sit between the gauges and the anomalies, as small gauges of
+	// their own when the main gauges still fit side by side or stacked
+	var goalsRow string
+	if !state.Browsing && len(state.Goals) > 0 {
+		goalsRow = goalGauges(p, state.Goals, width)
+		if layout, _ := layoutFor(width, height-reserved-lipgloss.Height(goalsRow)-1); layout == LayoutCompact {
+			goalsRow = goalsList(p, state.Goals)
+		}
+		reserved += lipgloss.Height(goalsRow) + 1
+	}
+
+	layout, size := layoutFor(width, height-reserved)
+
+	var content string
+	switch layout {
+	case LayoutHorizontal:
+		gap := strings.Repeat(" ", gaugeGap)
+		sleep, recovery, strain := gauges(p, state, size)
+		content = lipgloss.JoinHorizontal(lipgloss.Top, sleep, gap, recovery, gap, strain)
+	case LayoutStacked:
+		sleep, recovery, strain := gauges(p, state, size)
+		content = lipgloss.JoinVertical(lipgloss.Center, sleep, "", recovery, "", strain)
+	case LayoutCompact:
+		content = compactView(p, state)
+	default:
+		content = compactView(p, state)
+	}
+	if goalsRow != "" {
+		content = lipgloss.JoinVertical(lipgloss.Center, content, "", goalsRow)
+	}
+	if anomalies != "" {
+		content = lipgloss.JoinVertical(lipgloss.Center, content, "", anomalies)
+	}
 
 	return lipgloss.Place(
 		width,
 		height,
 		lipgloss.Center,
 		lipgloss.Center,
-		gaugesRow,
+		lipgloss.JoinVertical(lipgloss.Center, dateHeader(p, state, width), "", content),
 	)
 }
 
-func AuthIndicatorView(state State) string {
-	return state.AuthIndicator.Render()
+func gauges(p theme.Palette, state State, size int) (sleep, recovery, strain string) {
+	sleep = gauge.New(
+		state.SleepScore,
+		100,
+		"SLEEP",
+		p.Sleep,
+		gauge.WithPalette(p),
+		gauge.WithSize(size),
+	).Render()
+
+	recovery = gauge.New(
+		state.RecoveryScore,
+		100,
+		"RECOVERY",
+		p.RecoveryColor(state.RecoveryScore),
+		gauge.WithPalette(p),
+		gauge.WithSize(size),
+	).Render()
+
+	strain = gauge.New(
+		state.StrainScore,
+		21,
+		"STRAIN",
+		p.Strain,
+		gauge.WithPalette(p),
+		gauge.WithSize(size),
+	).Render()
+
+	return sleep, recovery, strain
+}
+
+// compactView lists the scores one per line, for terminals too small for gauges.
+func compactView(p theme.Palette, state State) string {
+	var (
+		label = lipgloss.NewStyle().Foreground(p.Dim).Width(len("RECOVERY") + 1)
+		value = lipgloss.NewStyle().Bold(true).Width(5).Align(lipgloss.Right)
+	)
+
+	line := func(name, score string, c color.Color) string {
+		return label.Render(name) + value.Foreground(c).Render(score)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		line("SLEEP", formatScore(state.SleepScore, 100), p.Sleep),
+		line("RECOVERY", formatScore(state.RecoveryScore, 100), p.RecoveryColor(state.RecoveryScore)),
+		line("STRAIN", formatScore(state.StrainScore, 21), p.Strain),
+	)
 }
 
-func recoveryColor(score *float64) color.Color {
-	if score == nil {
-		return theme.ColorRecoveryBlue
+// maxAnomalies caps how many anomalies the dashboard lists; thoop insights
+// shows them all.
+const maxAnomalies = 3
+
+// anomaliesView lists the metrics that stray from their baseline, marked with
+// which way they went.
+func anomaliesView(p theme.Palette, anomalies []insights.Deviation) string {
+	if len(anomalies) == 0 {
+		return ""
 	}
 
-	s := *score
+	var (
+		marker = lipgloss.NewStyle().Foreground(p.MediumRecovery).Bold(true)
+		dim    = lipgloss.NewStyle().Foreground(p.Dim)
+		lines  = make([]string, 0, maxAnomalies)
+	)
+	for _, d := range anomalies[:min(len(anomalies), maxAnomalies)] {
+		arrow := "▲"
+		if d.Z < 0 {
+			arrow = "▼"
+		}
+		lines = append(lines, marker.Render(arrow)+" "+dim.Render(d.String()))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// goalColor is green for a goal already met.
+func goalColor(p theme.Palette, progress goals.Progress) color.Color {
+	if progress.Met {
+		return p.HighRecovery
+	}
+	return p.Accent
+}
+
+// goalGauges draws a small gauge per goal, filled as far as the goal has
+// come, as many as fit across width.
+func goalGauges(p theme.Palette, progress []goals.Progress, width int) string {
+	var (
+		n      = min(len(progress), max((width+gaugeGap)/(gauge.MinSize+gaugeGap), 1))
+		gap    = strings.Repeat(" ", gaugeGap)
+		gauges = make([]string, 0, 2*n-1)
+	)
+	for i, g := range progress[:n] {
+		if i > 0 {
+			gauges = append(gauges, gap)
+		}
+		pct := g.Fraction * 100
+		gauges = append(gauges, gauge.New(
+			&pct,
+			100,
+			truncate(g.Goal.Short(), gauge.MinSize),
+			goalColor(p, g),
+			gauge.WithPalette(p),
+			gauge.WithSize(gauge.MinSize),
+		).Render())
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, gauges...)
+}
+
+// maxGoalLines caps how many goals are listed when there's no room for their
+// gauges; thoop goals list shows them all.
+const maxGoalLines = 3
+
+// goalsList lists the goals one per line, for terminals too small for their gauges.
+func goalsList(p theme.Palette, progress []goals.Progress) string {
+	var (
+		dim   = lipgloss.NewStyle().Foreground(p.Dim)
+		lines = make([]string, 0, maxGoalLines)
+	)
+	for _, g := range progress[:min(len(progress), maxGoalLines)] {
+		pct := lipgloss.NewStyle().Foreground(goalColor(p, g)).Bold(true).Render(fmt.Sprintf("%3.0f%%", g.Fraction*100))
+		lines = append(lines, pct+" "+dim.Render(g.Goal.Short()))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// truncate shortens s to n runes, ending in an ellipsis when cut.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// formatScore matches the value text the gauges show.
+func formatScore(score *float64, max float64) string {
+	switch {
+	case score == nil:
+		return "--"
+	case max == 100:
+		return fmt.Sprintf("%.0f%%", *score)
+	default:
+		return fmt.Sprintf("%.1f", *score)
+	}
+}
+
+// dateHeader shows when the cycle started in the timezone it was recorded in,
+// leaving out the start time when it won't fit in width.
+func dateHeader(p theme.Palette, state State, width int) string {
+	dim := lipgloss.NewStyle().Foreground(p.Dim)
+	if state.CycleStart.IsZero() {
+		return dim.Render(" ")
+	}
+
+	var (
+		start = state.CycleStart.In(whoop.Location(state.TimezoneOffset))
+		date  = lipgloss.NewStyle().Foreground(p.Foreground).Bold(true).Render(start.Format("Mon, Jan 2"))
+		since = dim.Render(" · from " + start.Format("3:04pm") + " " + formatOffset(state.TimezoneOffset))
+	)
+
+	var label string
+	switch {
+	case state.Stepping:
+		label = dim.Render("  loading...")
+	case !state.Browsing:
+		label = lipgloss.NewStyle().Foreground(p.Accent).Render("  today")
+	}
+
+	if lipgloss.Width(date+since+label) > width {
+		return date + label
+	}
+	return date + since + label
+}
+
+func formatOffset(offset string) string {
+	if offset == "" {
+		return "local"
+	}
+	return "UTC" + offset
+}
+
+func AuthIndicatorView(t theme.Theme, state State) string {
+	return state.AuthIndicator.Render(t.Palette())
+}
+
+func NetworkIndicatorView(t theme.Theme, state State) string {
+	return state.NetworkIndicator.Render(t.Palette())
+}
+
+// SyncStatusView renders when the dashboard data was last synced with WHOOP.
+func SyncStatusView(t theme.Theme, state State, now time.Time) string {
+	style := lipgloss.NewStyle().Foreground(t.Palette().Dim)
+
 	switch {
-	case s >= 67:
-		return theme.ColorHighRecovery
-	case s >= 34:
-		return theme.ColorMediumRecovery
+	case state.Backfill != nil && state.Backfill.Running && !state.Backfill.Complete():
+		return style.Render(backfillStatus(state.Backfill))
+	case state.Revalidating:
+		return style.Render("syncing...")
+	case state.LastSync == nil:
+		return style.Render("never synced")
 	default:
-		return theme.ColorLowRecovery
+		return style.Render("last synced " + formatSyncTime(*state.LastSync, now))
+	}
+}
+
+func backfillStatus(p *xsync.BackfillProgress) string {
+	status := "backfilling"
+	if oldest := p.Oldest(); oldest != nil {
+		status += " to " + oldest.Local().Format("Jan 2")
+	}
+	if left, ok := p.RequestsLeft(); ok {
+		status += fmt.Sprintf(" · ~%d requests left", left)
+	}
+	if p.PausedUntil != nil {
+		status += " · paused for rate limit"
+	}
+	return status
+}
+
+func formatSyncTime(t, now time.Time) string {
+	t = t.Local()
+	now = now.Local()
+	if t.Year() == now.Year() && t.YearDay() == now.YearDay() {
+		return t.Format("3:04pm")
 	}
+	return t.Format("Jan 2 3:04pm")
 }
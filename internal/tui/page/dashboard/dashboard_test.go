@@ -0,0 +1,133 @@
+package dashboard
+
+import (
+	"embed"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/garrettladley/thoop/internal/goals"
+	"github.com/garrettladley/thoop/internal/insights"
+	"github.com/garrettladley/thoop/internal/tui/theme"
+)
+
+//go:embed testdata/*.golden
+var goldenFiles embed.FS
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+func TestLayoutFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		width      int
+		height     int
+		wantLayout Layout
+		wantSize   int
+	}{
+		{"roomy", 200, 60, LayoutHorizontal, 26},
+		{"narrow", 70, 40, LayoutHorizontal, 20},
+		{"short", 120, 15, LayoutHorizontal, 20},
+		{"smallest side by side", 50, 12, LayoutHorizontal, 14},
+		{"too narrow for three", 49, 60, LayoutStacked, 26},
+		{"narrow and short", 40, 30, LayoutStacked, 14},
+		{"too small for gauges", 40, 12, LayoutCompact, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			layout, size := layoutFor(tt.width, tt.height)
+			if layout != tt.wantLayout || size != tt.wantSize {
+				t.Errorf("layoutFor(%d, %d) = %d, %d, want %d, %d",
+					tt.width, tt.height, layout, size, tt.wantLayout, tt.wantSize)
+			}
+		})
+	}
+}
+
+func TestView_Golden(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sleep    = 87.0
+		recovery = 64.0
+		strain   = 12.4
+	)
+	state := State{
+		CycleStart:     time.Date(2025, 3, 14, 11, 30, 0, 0, time.UTC),
+		TimezoneOffset: "-05:00",
+		SleepScore:     &sleep,
+		RecoveryScore:  &recovery,
+		StrainScore:    &strain,
+	}
+
+	anomalies := []insights.Deviation{
+		{Metric: insights.MetricHRV, Value: 38, Baseline: insights.Baseline{Mean: 55, StdDev: 7, Samples: 30}, Z: -2.43},
+		{Metric: insights.MetricRHR, Value: 61, Baseline: insights.Baseline{Mean: 52, StdDev: 3, Samples: 30}, Z: 3},
+	}
+
+	sleepGoal, err := goals.New("sleep_performance", ">=", "85", nil, "week")
+	if err != nil {
+		t.Fatal(err)
+	}
+	three := 3
+	workoutGoal, err := goals.New("workout_strain", ">", "10", &three, "week")
+	if err != nil {
+		t.Fatal(err)
+	}
+	progress := []goals.Progress{
+		{Goal: sleepGoal, Current: 80, Samples: 4, Fraction: 80.0 / 85},
+		{Goal: workoutGoal, Current: 3, Samples: 5, Fraction: 1, Met: true},
+	}
+
+	tests := []struct {
+		name      string
+		size      tea.WindowSizeMsg
+		anomalies []insights.Deviation
+		goals     []goals.Progress
+	}{
+		{"horizontal", tea.WindowSizeMsg{Width: 120, Height: 30}, nil, nil},
+		{"horizontal_scaled", tea.WindowSizeMsg{Width: 64, Height: 20}, nil, nil},
+		{"horizontal_anomalies", tea.WindowSizeMsg{Width: 120, Height: 30}, anomalies, nil},
+		{"horizontal_goals", tea.WindowSizeMsg{Width: 120, Height: 40}, anomalies, progress},
+		{"stacked", tea.WindowSizeMsg{Width: 40, Height: 40}, nil, nil},
+		{"compact", tea.WindowSizeMsg{Width: 36, Height: 10}, nil, nil},
+		{"compact_goals", tea.WindowSizeMsg{Width: 36, Height: 14}, nil, progress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := state
+			state.Anomalies = tt.anomalies
+			state.Goals = tt.goals
+
+			got := ansi.Strip(View(theme.New(), state, tt.size.Width, tt.size.Height))
+
+			path := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				if err := os.WriteFile(path, []byte(got), 0o600); err != nil {
+					t.Fatalf("failed to write golden file: %v", err)
+				}
+				return
+			}
+
+			want, err := goldenFiles.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+			if diff := cmp.Diff(string(want), got); diff != "" {
+				t.Errorf("View() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
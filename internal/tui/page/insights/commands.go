@@ -0,0 +1,45 @@
+package insights
+
+import (
+	"context"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/garrettladley/thoop/internal/insights"
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+// windowDays is how much cached history the page correlates.
+const windowDays = 90
+
+type DataMsg struct {
+	Correlations []insights.Correlation
+	Days         int
+	Circadian    insights.Circadian
+	Err          error
+}
+
+// LoadCmd correlates the last windowDays of cached days with the recoveries
+// that followed them, and analyzes when their nights were slept.
+func LoadCmd(ctx context.Context, repo *repository.Repository) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		end := time.Now()
+		days, err := insights.LoadDays(ctx, repo, end.AddDate(0, 0, -windowDays), end)
+		if err != nil {
+			return DataMsg{Err: err}
+		}
+		nights, err := insights.LoadNights(ctx, repo, end.AddDate(0, 0, -windowDays), end)
+		if err != nil {
+			return DataMsg{Err: err}
+		}
+		return DataMsg{
+			Correlations: insights.Correlate(days),
+			Days:         len(days),
+			Circadian:    insights.Analyze(nights, nil, end),
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package insights
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/garrettladley/thoop/internal/insights"
+	"github.com/garrettladley/thoop/internal/tui/theme"
+	"github.com/garrettladley/thoop/internal/xtime"
+)
+
+type Method uint
+
+const (
+	MethodPearson Method = iota
+	MethodSpearman
+)
+
+func (m Method) String() string {
+	switch m {
+	case MethodSpearman:
+		return "spearman"
+	default:
+		return "pearson"
+	}
+}
+
+type State struct {
+	Correlations []insights.Correlation
+	// Days is how many cached days the correlations were drawn from.
+	Days      int
+	Circadian insights.Circadian
+	Method    Method
+	Loading   bool
+	ErrMsg    string
+}
+
+// ToggleMethod switches between Pearson and Spearman correlations.
+func (s *State) ToggleMethod() {
+	if s.Method == MethodPearson {
+		s.Method = MethodSpearman
+	} else {
+		s.Method = MethodPearson
+	}
+}
+
+// estimate returns the correlation of p with recovery lag days later under
+// the selected method.
+func (s State) estimate(p insights.Predictor, lag int) (insights.Estimate, bool) {
+	for _, c := range s.Correlations {
+		if c.Predictor != p || c.Lag != lag {
+			continue
+		}
+		if s.Method == MethodSpearman {
+			return c.Spearman, true
+		}
+		return c.Pearson, true
+	}
+	return insights.Estimate{}, false
+}
+
+const (
+	labelWidth = 18
+	cellWidth  = 22
+	maxWidth   = labelWidth + cellWidth*3
+)
+
+// renderer draws the page in a theme's colors.
+type renderer struct {
+	palette      theme.Palette
+	titleStyle   lipgloss.Style
+	sectionStyle lipgloss.Style
+	labelStyle   lipgloss.Style
+	textStyle    lipgloss.Style
+}
+
+func newRenderer(t theme.Theme) renderer {
+	p := t.Palette()
+	return renderer{
+		palette:      p,
+		titleStyle:   lipgloss.NewStyle().Foreground(p.Foreground).Bold(true),
+		sectionStyle: lipgloss.NewStyle().Foreground(p.Sleep).Bold(true),
+		labelStyle:   lipgloss.NewStyle().Foreground(p.Dim),
+		textStyle:    lipgloss.NewStyle().Foreground(p.Foreground),
+	}
+}
+
+func View(t theme.Theme, state State, width, height int) string {
+	var (
+		r            = newRenderer(t)
+		contentWidth = min(width-4, maxWidth)
+		body         string
+	)
+
+	switch {
+	case len(state.Correlations) == 0 && state.Loading:
+		body = r.labelStyle.Render("loading...")
+	case len(state.Correlations) == 0:
+		body = r.labelStyle.Render(fmt.Sprintf("not enough data yet: correlations need %d days with a recovery after them", insights.MinPairs))
+	default:
+		body = lipgloss.JoinVertical(lipgloss.Left, r.grid(state), "", r.legend(state))
+	}
+	if state.Circadian.Bedtime.Nights > 0 {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, "", r.circadian(state.Circadian))
+	}
+
+	return lipgloss.Place(
+		width,
+		height,
+		lipgloss.Center,
+		lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left, r.header(state, contentWidth), "", body),
+	)
+}
+
+func (r renderer) header(state State, width int) string {
+	left := r.titleStyle.Render("INSIGHTS")
+
+	var status string
+	switch {
+	case state.ErrMsg != "":
+		status = lipgloss.NewStyle().Foreground(r.palette.LowRecovery).Render(state.ErrMsg)
+	case state.Loading:
+		status = r.labelStyle.Render("loading...")
+	default:
+		status = r.labelStyle.Render(fmt.Sprintf("%s · %d days", state.Method, state.Days))
+	}
+
+	spacer := max(width-lipgloss.Width(left)-lipgloss.Width(status), 1)
+	return left + strings.Repeat(" ", spacer) + status
+}
+
+// grid lays predictors out against the recovery 1 to 3 days after them.
+func (r renderer) grid(state State) string {
+	head := r.labelStyle.Width(labelWidth).Render("recovery after")
+	for _, lag := range insights.Lags {
+		head += r.labelStyle.Width(cellWidth).Render(fmt.Sprintf("+%d day", lag))
+	}
+	lines := []string{head}
+
+	for _, p := range insights.Predictors {
+		line := r.textStyle.Width(labelWidth).Render(p.Label())
+		for _, lag := range insights.Lags {
+			line += r.cell(state, p, lag)
+		}
+		lines = append(lines, line)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// cell renders a correlation as "-0.42 [-0.61 -0.19]", colored by its sign
+// when the interval excludes zero.
+func (r renderer) cell(state State, p insights.Predictor, lag int) string {
+	e, ok := state.estimate(p, lag)
+	if !ok {
+		return r.labelStyle.Width(cellWidth).Render("-")
+	}
+
+	style := r.labelStyle
+	switch {
+	case e.Significant() && e.R > 0:
+		style = lipgloss.NewStyle().Foreground(r.palette.HighRecovery)
+	case e.Significant():
+		style = lipgloss.NewStyle().Foreground(r.palette.LowRecovery)
+	default:
+	}
+	return style.Width(cellWidth).Render(fmt.Sprintf("%+.2f [%+.2f %+.2f]", e.R, e.Low, e.High))
+}
+
+func (r renderer) legend(state State) string {
+	pairs := 0
+	for _, c := range state.Correlations {
+		pairs = max(pairs, c.N)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		r.labelStyle.Render(fmt.Sprintf("%s r with 95%% interval over up to %d days", state.Method, pairs)),
+		r.labelStyle.Render("colored where the interval excludes zero: ")+
+			lipgloss.NewStyle().Foreground(r.palette.HighRecovery).Render("higher recovery")+
+			r.labelStyle.Render(" · ")+
+			lipgloss.NewStyle().Foreground(r.palette.LowRecovery).Render("lower recovery"),
+	)
+}
+
+// circadian summarizes when the user sleeps in a few lines.
+func (r renderer) circadian(c insights.Circadian) string {
+	lines := []string{
+		r.sectionStyle.Render("SLEEP TIMING"),
+		r.textStyle.Render(fmt.Sprintf("bed %s ± %s · wake %s ± %s · mid-sleep %s",
+			c.Bedtime.Mean, xtime.FormatDuration(c.Bedtime.StdDev),
+			c.Wake.Mean, xtime.FormatDuration(c.Wake.StdDev),
+			c.Midpoint.Mean)),
+	}
+
+	var extras []string
+	if c.SocialJetlag != nil {
+		extras = append(extras, "social jetlag "+xtime.FormatDuration(c.SocialJetlag.Shift))
+	}
+	if c.Chronotype != nil {
+		extras = append(extras, c.Chronotype.Type+" chronotype")
+	}
+	if rec := c.Recommendation; rec != nil {
+		extras = append(extras, fmt.Sprintf("bed by %s to wake %s", rec.Bedtime.Format("3:04pm"), rec.Wake.Format("3:04pm")))
+	}
+	if len(extras) > 0 {
+		lines = append(lines, r.labelStyle.Render(strings.Join(extras, " · ")))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,59 @@
+package insights
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/garrettladley/thoop/internal/insights"
+	"github.com/garrettladley/thoop/internal/tui/theme"
+)
+
+func TestView(t *testing.T) {
+	t.Parallel()
+
+	state := State{
+		Days: 90,
+		Correlations: []insights.Correlation{{
+			Predictor: insights.PredictorStrain,
+			Lag:       1,
+			N:         80,
+			Pearson:   insights.Estimate{R: -0.42, Low: -0.58, High: -0.22},
+			Spearman:  insights.Estimate{R: -0.1, Low: -0.3, High: 0.12},
+		}},
+	}
+
+	view := ansi.Strip(View(theme.New(), state, 100, 20))
+	for _, want := range []string{"pearson · 90 days", "Day strain", "-0.42 [-0.58 -0.22]", "up to 80 days"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() is missing %q:\n%s", want, view)
+		}
+	}
+
+	state.ToggleMethod()
+	view = ansi.Strip(View(theme.New(), state, 100, 20))
+	if !strings.Contains(view, "-0.10 [-0.30 +0.12]") {
+		t.Errorf("spearman View() is missing the spearman estimate:\n%s", view)
+	}
+}
+
+func TestView_Circadian(t *testing.T) {
+	t.Parallel()
+
+	bed, _ := insights.ParseClock("23:15")
+	wake, _ := insights.ParseClock("07:00")
+	state := State{Circadian: insights.Circadian{
+		Bedtime:    insights.Distribution{Nights: 30, Mean: bed, StdDev: 40 * time.Minute},
+		Wake:       insights.Distribution{Nights: 30, Mean: wake, StdDev: 20 * time.Minute},
+		Chronotype: &insights.Chronotype{Type: insights.ChronotypeLate},
+	}}
+
+	view := ansi.Strip(View(theme.New(), state, 100, 20))
+	for _, want := range []string{"not enough data yet", "bed 11:15pm ± 40m · wake 7:00am ± 20m", "late chronotype"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() is missing %q:\n%s", want, view)
+		}
+	}
+}
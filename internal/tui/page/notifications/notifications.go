@@ -0,0 +1,96 @@
+package notifications
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/garrettladley/thoop/internal/tui/theme"
+)
+
+// chromeHeight is the rows View spends on the header and the footer.
+const chromeHeight = 6
+
+// Entry is a notification received this session.
+type Entry struct {
+	At     time.Time
+	Text   string
+	Accent color.Color
+}
+
+type State struct {
+	// Entries are in the order they arrived.
+	Entries []Entry
+	// Offset is how many of the newest entries are scrolled past.
+	Offset int
+}
+
+func (s *State) Add(e Entry) {
+	s.Entries = append(s.Entries, e)
+	// keep the view anchored on the same entries while scrolled
+	if s.Offset > 0 {
+		s.Offset++
+	}
+}
+
+// Down scrolls towards older entries.
+func (s *State) Down() {
+	if s.Offset < len(s.Entries)-1 {
+		s.Offset++
+	}
+}
+
+// Up scrolls towards newer entries.
+func (s *State) Up() {
+	if s.Offset > 0 {
+		s.Offset--
+	}
+}
+
+// visible returns up to rows entries from Offset, newest first.
+func (s State) visible(rows int) []Entry {
+	newest := len(s.Entries) - 1 - s.Offset
+	out := make([]Entry, 0, min(rows, newest+1))
+	for i := newest; i >= 0 && len(out) < rows; i-- {
+		out = append(out, s.Entries[i])
+	}
+	return out
+}
+
+func View(t theme.Theme, state State, width, height int) string {
+	var (
+		p          = t.Palette()
+		titleStyle = lipgloss.NewStyle().Foreground(p.Foreground).Bold(true)
+		dimStyle   = lipgloss.NewStyle().Foreground(p.Dim)
+		textStyle  = lipgloss.NewStyle().Foreground(p.Foreground)
+	)
+
+	header := titleStyle.Render("NOTIFICATIONS") + dimStyle.Render("  this session")
+
+	var body string
+	if len(state.Entries) == 0 {
+		body = dimStyle.Render("nothing yet, updates from WHOOP show up here as they arrive")
+	} else {
+		entries := state.visible(max(height-chromeHeight, 1))
+		lines := make([]string, 0, len(entries)+1)
+		for _, e := range entries {
+			bullet := lipgloss.NewStyle().Foreground(e.Accent).Render("●")
+			lines = append(lines, dimStyle.Render(e.At.Format("15:04:05"))+"  "+bullet+" "+textStyle.Render(e.Text))
+		}
+		if older := len(state.Entries) - state.Offset - len(entries); older > 0 {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("%s… %d older", strings.Repeat(" ", 10), older)))
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	return lipgloss.Place(
+		width,
+		height,
+		lipgloss.Center,
+		lipgloss.Center,
+		lipgloss.JoinVertical(lipgloss.Left, header, "", body),
+	)
+}
@@ -0,0 +1,62 @@
+package notifications
+
+import (
+	"testing"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/tui/theme"
+)
+
+func TestStateScroll(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	entry := func(text string) Entry {
+		return Entry{At: at, Text: text, Accent: theme.ColorSleep}
+	}
+
+	var s State
+	s.Add(entry("one"))
+	s.Add(entry("two"))
+	s.Add(entry("three"))
+
+	texts := func(entries []Entry) []string {
+		out := make([]string, len(entries))
+		for i, e := range entries {
+			out[i] = e.Text
+		}
+		return out
+	}
+	assert := func(t *testing.T, got []Entry, want ...string) {
+		t.Helper()
+		g := texts(got)
+		if len(g) != len(want) {
+			t.Fatalf("visible = %v, want %v", g, want)
+		}
+		for i := range want {
+			if g[i] != want[i] {
+				t.Fatalf("visible = %v, want %v", g, want)
+			}
+		}
+	}
+
+	assert(t, s.visible(2), "three", "two")
+
+	s.Down()
+	assert(t, s.visible(2), "two", "one")
+
+	// a new entry while scrolled doesn't move the view
+	s.Add(entry("four"))
+	assert(t, s.visible(2), "two", "one")
+
+	s.Down()
+	s.Down()
+	s.Down()
+	assert(t, s.visible(2), "one")
+
+	s.Up()
+	s.Up()
+	s.Up()
+	s.Up()
+	assert(t, s.visible(2), "four", "three")
+}
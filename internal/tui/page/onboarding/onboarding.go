@@ -42,19 +42,21 @@ func View(t theme.Theme, state State, width, height int) string {
 }
 
 func welcomeView(t theme.Theme) string {
+	p := t.Palette()
+
 	titleStyle := lipgloss.NewStyle().
-		Foreground(theme.ColorTeal).
+		Foreground(p.Accent).
 		Bold(true)
 
 	subtitleStyle := lipgloss.NewStyle().
-		Foreground(theme.ColorWhite)
+		Foreground(p.Foreground)
 
 	hintStyle := lipgloss.NewStyle().
-		Foreground(theme.ColorDim)
+		Foreground(p.Dim)
 
 	buttonStyle := lipgloss.NewStyle().
-		Foreground(theme.ColorBgDark).
-		Background(theme.ColorTeal).
+		Foreground(p.Background).
+		Background(p.Accent).
 		Padding(0, 2).
 		Bold(true)
 
@@ -84,15 +86,17 @@ func welcomeView(t theme.Theme) string {
 }
 
 func authenticatingView(t theme.Theme) string {
+	p := t.Palette()
+
 	titleStyle := lipgloss.NewStyle().
-		Foreground(theme.ColorTeal).
+		Foreground(p.Accent).
 		Bold(true)
 
 	subtitleStyle := lipgloss.NewStyle().
-		Foreground(theme.ColorWhite)
+		Foreground(p.Foreground)
 
 	hintStyle := lipgloss.NewStyle().
-		Foreground(theme.ColorDim)
+		Foreground(p.Dim)
 
 	logo := splash.LogoView(t)
 
@@ -117,18 +121,20 @@ func authenticatingView(t theme.Theme) string {
 }
 
 func errorView(t theme.Theme, errorMsg string) string {
+	p := t.Palette()
+
 	titleStyle := lipgloss.NewStyle().
-		Foreground(theme.ColorLowRecovery).
+		Foreground(p.LowRecovery).
 		Bold(true)
 
 	subtitleStyle := lipgloss.NewStyle().
-		Foreground(theme.ColorWhite)
+		Foreground(p.Foreground)
 
 	errorStyle := lipgloss.NewStyle().
-		Foreground(theme.ColorLowRecovery)
+		Foreground(p.LowRecovery)
 
 	hintStyle := lipgloss.NewStyle().
-		Foreground(theme.ColorDim)
+		Foreground(p.Dim)
 
 	logo := splash.LogoView(t)
 
@@ -6,4 +6,9 @@ const (
 	Splash ID = iota
 	Onboarding
 	Dashboard
+	Trends
+	Sleep
+	Workouts
+	Insights
+	Notifications
 )
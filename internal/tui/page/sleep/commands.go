@@ -0,0 +1,38 @@
+package sleep
+
+import (
+	"context"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+// pageSize is the number of sleeps, naps included, loaded per page.
+const pageSize = 20
+
+type DataMsg struct {
+	Sleeps     []whoop.Sleep // newest first
+	NextCursor *time.Time
+	Err        error
+}
+
+// LoadCmd loads a page of cached sleeps that started before cursor, or the
+// most recent page when cursor is nil.
+func LoadCmd(ctx context.Context, repo *repository.Repository, cursor *time.Time) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		result, err := repo.Sleeps.GetByDateRange(ctx, time.Time{}, time.Now(), &repository.CursorParams{
+			Cursor: cursor,
+			Limit:  pageSize,
+		})
+		if err != nil {
+			return DataMsg{Err: err}
+		}
+		return DataMsg{Sleeps: result.Records, NextCursor: result.NextCursor}
+	}
+}
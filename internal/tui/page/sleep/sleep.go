@@ -0,0 +1,329 @@
+package sleep
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/tui/components/bar"
+	"github.com/garrettladley/thoop/internal/tui/theme"
+	"github.com/garrettladley/thoop/internal/xtime"
+)
+
+type State struct {
+	Nights []whoop.Sleep // main sleeps, newest first
+	Naps   []whoop.Sleep // newest first
+	// Index selects the night shown, 0 being the most recent.
+	Index int
+
+	// Cursor is where the next older page starts. Exhausted is set once the
+	// cache has no older sleeps.
+	Cursor    *time.Time
+	Exhausted bool
+	Loading   bool
+	ErrMsg    string
+}
+
+// Append adds a page of sleeps, splitting off the naps.
+func (s *State) Append(sleeps []whoop.Sleep, next *time.Time) {
+	for _, sl := range sleeps {
+		if sl.Nap {
+			s.Naps = append(s.Naps, sl)
+		} else {
+			s.Nights = append(s.Nights, sl)
+		}
+	}
+	s.Cursor = next
+	s.Exhausted = next == nil
+}
+
+// Selected returns the night being shown, or nil when there are none.
+func (s State) Selected() *whoop.Sleep {
+	if s.Index < 0 || s.Index >= len(s.Nights) {
+		return nil
+	}
+	return &s.Nights[s.Index]
+}
+
+// Older steps back a night. It reports whether another page should be loaded
+// first, in which case the index is left alone.
+func (s *State) Older() bool {
+	if s.Index+1 < len(s.Nights) {
+		s.Index++
+		return false
+	}
+	return !s.Exhausted && !s.Loading
+}
+
+func (s *State) Newer() {
+	if s.Index > 0 {
+		s.Index--
+	}
+}
+
+// NapsFor returns the naps taken during a cycle, oldest first.
+func (s State) NapsFor(cycleID int64) []whoop.Sleep {
+	var naps []whoop.Sleep
+	for i := len(s.Naps) - 1; i >= 0; i-- {
+		if s.Naps[i].CycleID == cycleID {
+			naps = append(naps, s.Naps[i])
+		}
+	}
+	return naps
+}
+
+const (
+	maxWidth   = 80
+	labelWidth = 10
+	valueWidth = 16
+)
+
+// renderer draws the page in a theme's colors.
+type renderer struct {
+	palette      theme.Palette
+	titleStyle   lipgloss.Style
+	sectionStyle lipgloss.Style
+	labelStyle   lipgloss.Style
+	textStyle    lipgloss.Style
+}
+
+func newRenderer(t theme.Theme) renderer {
+	p := t.Palette()
+	return renderer{
+		palette:      p,
+		titleStyle:   lipgloss.NewStyle().Foreground(p.Foreground).Bold(true),
+		sectionStyle: lipgloss.NewStyle().Foreground(p.Sleep).Bold(true),
+		labelStyle:   lipgloss.NewStyle().Foreground(p.Dim),
+		textStyle:    lipgloss.NewStyle().Foreground(p.Foreground),
+	}
+}
+
+func View(t theme.Theme, state State, width, height int) string {
+	var (
+		r            = newRenderer(t)
+		contentWidth = min(width-4, maxWidth)
+	)
+
+	rows := []string{r.header(state, contentWidth), ""}
+
+	night := state.Selected()
+	switch {
+	case night == nil && state.Loading:
+		rows = append(rows, r.labelStyle.Render("loading..."))
+	case night == nil:
+		rows = append(rows, r.labelStyle.Render("no sleeps cached yet"))
+	case night.Score == nil:
+		rows = append(rows,
+			r.timeline(*night, contentWidth),
+			"",
+			r.labelStyle.Render(fmt.Sprintf("not scored yet (%s)", strings.ToLower(string(night.ScoreState)))),
+		)
+	default:
+		rows = append(rows,
+			r.sectionStyle.Render("TIME IN BED"),
+			r.timeline(*night, contentWidth),
+			"",
+			r.sectionStyle.Render("STAGES"),
+			r.stages(night.Score.StageSummary, contentWidth),
+			"",
+			r.sectionStyle.Render("SLEEP NEED"),
+			r.need(*night.Score, contentWidth),
+			"",
+			r.stats(*night.Score),
+		)
+	}
+
+	if night != nil {
+		rows = append(rows, "", r.sectionStyle.Render("NAPS"), r.naps(state.NapsFor(night.CycleID)))
+	}
+
+	return lipgloss.Place(
+		width,
+		height,
+		lipgloss.Center,
+		lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+	)
+}
+
+func (r renderer) header(state State, width int) string {
+	left := r.titleStyle.Render("SLEEP")
+	if night := state.Selected(); night != nil {
+		start := night.Start.In(whoop.Location(night.TimezoneOffset))
+		left += "  " + r.textStyle.Render(start.Format("Mon, Jan 2"))
+	}
+
+	var status string
+	switch {
+	case state.ErrMsg != "":
+		status = lipgloss.NewStyle().Foreground(r.palette.LowRecovery).Render(state.ErrMsg)
+	case state.Loading:
+		status = r.labelStyle.Render("loading...")
+	}
+
+	spacer := max(width-lipgloss.Width(left)-lipgloss.Width(status), 1)
+	return left + strings.Repeat(" ", spacer) + status
+}
+
+// timeline draws the time in bed as a bar between the bed and wake times,
+// with a tick for every other hour in between.
+func (r renderer) timeline(night whoop.Sleep, width int) string {
+	var (
+		loc   = whoop.Location(night.TimezoneOffset)
+		start = night.Start.In(loc)
+		end   = night.End.In(loc)
+		from  = r.clockStyle(start)
+		to    = r.clockStyle(end)
+		track = max(width-lipgloss.Width(from)-lipgloss.Width(to)-2, 1)
+	)
+
+	line := from + " " +
+		lipgloss.NewStyle().Foreground(r.palette.Sleep).Render(strings.Repeat("━", track)) +
+		" " + to
+
+	span := end.Sub(start)
+	if span <= 0 {
+		return line
+	}
+
+	ticks := []rune(strings.Repeat(" ", track))
+	offset := lipgloss.Width(from) + 1
+	for hour := start.Truncate(time.Hour).Add(time.Hour); hour.Before(end); hour = hour.Add(time.Hour) {
+		if hour.Hour()%2 != 0 {
+			continue
+		}
+		label := []rune(hour.Format("3pm"))
+		pos := int(float64(hour.Sub(start)) / float64(span) * float64(track))
+		if pos+len(label) > track || (pos > 0 && ticks[pos-1] != ' ') {
+			continue
+		}
+		copy(ticks[pos:], label)
+	}
+
+	return line + "\n" + strings.Repeat(" ", offset) + r.labelStyle.Render(string(ticks))
+}
+
+func (r renderer) clockStyle(t time.Time) string {
+	return r.textStyle.Render(t.Format("3:04pm"))
+}
+
+func (r renderer) stages(s whoop.SleepStages, width int) string {
+	segments := []struct {
+		label string
+		milli int
+		color color.Color
+	}{
+		{"awake", s.TotalAwakeTimeMilli, r.palette.SleepAwake},
+		{"light", s.TotalLightSleepTimeMilli, r.palette.SleepLight},
+		{"deep", s.TotalSlowWaveSleepTimeMilli, r.palette.SleepSWS},
+		{"rem", s.TotalREMSleepTimeMilli, r.palette.SleepREM},
+	}
+
+	var (
+		bars   = make([]bar.Segment, len(segments))
+		legend = make([]string, len(segments))
+	)
+	for i, seg := range segments {
+		bars[i] = bar.Segment{Value: float64(seg.milli), Color: seg.color}
+		legend[i] = lipgloss.NewStyle().Foreground(seg.color).Render("■") + " " +
+			r.labelStyle.Render(seg.label) + " " +
+			r.textStyle.Render(formatMillis(seg.milli))
+	}
+
+	summary := r.labelStyle.Render(fmt.Sprintf("%d sleep cycles · %d disturbances · %s in bed",
+		s.SleepCycleCount,
+		s.DisturbanceCount,
+		formatMillis(s.TotalInBedTimeMilli),
+	))
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		bar.Stacked(bars, width, r.palette.Dim),
+		strings.Join(legend, "   "),
+		summary,
+	)
+}
+
+// need compares the hours of sleep needed, split into WHOOP's four
+// components, against the hours actually slept.
+func (r renderer) need(score whoop.SleepScore, width int) string {
+	var (
+		n        = score.SleepNeeded
+		s        = score.StageSummary
+		needed   = n.BaselineMilli + n.NeedFromSleepDebtMilli + n.NeedFromRecentStrainMilli + n.NeedFromRecentNapMilli
+		achieved = s.TotalSleepTimeMilli()
+		scale    = float64(max(needed, achieved))
+		barWidth = max(width-labelWidth-valueWidth, 1)
+	)
+
+	// the nap credit is negative, so it shrinks the bar rather than adding a segment
+	components := []bar.Segment{
+		{Value: float64(n.BaselineMilli + min(n.NeedFromRecentNapMilli, 0)), Color: r.palette.Accent},
+		{Value: float64(n.NeedFromSleepDebtMilli), Color: r.palette.MediumRecovery},
+		{Value: float64(n.NeedFromRecentStrainMilli), Color: r.palette.Strain},
+	}
+	neededWidth := barWidth
+	if scale > 0 {
+		neededWidth = int(float64(needed) / scale * float64(barWidth))
+	}
+
+	var pct string
+	if needed > 0 {
+		pct = fmt.Sprintf("  %d%%", achieved*100/needed)
+	}
+
+	row := func(label, b, value string) string {
+		return r.labelStyle.Width(labelWidth).Render(label) + b + "  " + r.textStyle.Render(value)
+	}
+
+	breakdown := r.labelStyle.Render(fmt.Sprintf("baseline %s · debt %s · strain %s · nap %s",
+		formatMillis(n.BaselineMilli),
+		signedDuration(n.NeedFromSleepDebtMilli),
+		signedDuration(n.NeedFromRecentStrainMilli),
+		signedDuration(n.NeedFromRecentNapMilli),
+	))
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		row("needed", bar.Stacked(components, neededWidth, r.palette.Dim)+strings.Repeat(" ", barWidth-neededWidth), formatMillis(needed)),
+		row("achieved", bar.Horizontal(float64(achieved), scale, barWidth, r.palette.Sleep, r.palette.Dim), formatMillis(achieved)+pct),
+		strings.Repeat(" ", labelWidth)+breakdown,
+	)
+}
+
+func (r renderer) stats(score whoop.SleepScore) string {
+	parts := []string{
+		fmt.Sprintf("performance %.0f%%", score.SleepPerformancePercentage),
+		fmt.Sprintf("efficiency %.0f%%", score.SleepEfficiencyPercentage),
+		fmt.Sprintf("consistency %.0f%%", score.SleepConsistencyPercentage),
+		fmt.Sprintf("respiratory rate %.1f", score.RespiratoryRate),
+	}
+	return r.labelStyle.Render(strings.Join(parts, " · "))
+}
+
+func (r renderer) naps(naps []whoop.Sleep) string {
+	if len(naps) == 0 {
+		return r.labelStyle.Render("none")
+	}
+
+	rows := make([]string, len(naps))
+	for i, nap := range naps {
+		loc := whoop.Location(nap.TimezoneOffset)
+		rows[i] = r.clockStyle(nap.Start.In(loc)) + r.labelStyle.Render(" – ") + r.clockStyle(nap.End.In(loc)) +
+			"  " + r.labelStyle.Render(xtime.FormatDuration(nap.End.Sub(nap.Start)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+func formatMillis(milli int) string {
+	return xtime.FormatDuration(xtime.Millis(milli))
+}
+
+func signedDuration(milli int) string {
+	if milli >= 0 {
+		return "+" + formatMillis(milli)
+	}
+	return formatMillis(milli)
+}
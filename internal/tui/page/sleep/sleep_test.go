@@ -0,0 +1,53 @@
+package sleep
+
+import (
+	"testing"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+)
+
+func TestStateNavigation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		day    = 24 * time.Hour
+		now    = time.Date(2025, 1, 10, 7, 0, 0, 0, time.UTC)
+		cursor = now.Add(-3 * day)
+	)
+
+	var s State
+	s.Append([]whoop.Sleep{
+		{ID: "n1", CycleID: 3, Start: now},
+		{ID: "nap", CycleID: 2, Start: now.Add(-day + 6*time.Hour), Nap: true},
+		{ID: "n2", CycleID: 2, Start: now.Add(-day)},
+	}, &cursor)
+
+	if len(s.Nights) != 2 || len(s.Naps) != 1 {
+		t.Fatalf("Append() split %d nights and %d naps, want 2 and 1", len(s.Nights), len(s.Naps))
+	}
+	if naps := s.NapsFor(2); len(naps) != 1 {
+		t.Errorf("NapsFor(2) returned %d naps, want 1", len(naps))
+	}
+
+	if s.Older() {
+		t.Fatal("Older() wants a page while a loaded night is left")
+	}
+	if s.Selected().ID != "n2" {
+		t.Errorf("Selected() = %s, want n2", s.Selected().ID)
+	}
+	if !s.Older() {
+		t.Fatal("Older() doesn't want a page at the last loaded night")
+	}
+
+	s.Append(nil, nil)
+	if s.Older() {
+		t.Error("Older() wants a page once the cache is exhausted")
+	}
+
+	s.Newer()
+	s.Newer()
+	if s.Selected().ID != "n1" {
+		t.Errorf("Selected() = %s, want n1", s.Selected().ID)
+	}
+}
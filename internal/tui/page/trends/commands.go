@@ -0,0 +1,98 @@
+package trends
+
+import (
+	"context"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+	"github.com/garrettladley/thoop/internal/xsync"
+)
+
+type DataMsg struct {
+	Window    Window
+	Days      []Day
+	Watermark *time.Time
+	Err       error
+}
+
+type HistoricalMsg struct {
+	Window Window
+	Start  time.Time
+	Err    error
+}
+
+func LoadCmd(ctx context.Context, repo *repository.Repository, window Window) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		var (
+			end   = time.Now()
+			start = window.Start(end)
+		)
+
+		records, err := repository.LoadRecords(ctx, repo, start, end)
+		if err != nil {
+			return DataMsg{Window: window, Err: err}
+		}
+
+		state, err := repo.SyncState.Get(ctx)
+		if err != nil {
+			return DataMsg{Window: window, Err: err}
+		}
+
+		return DataMsg{
+			Window:    window,
+			Days:      buildDays(records.Cycles, records.Recoveries, records.Sleeps),
+			Watermark: state.BackfillWatermark,
+		}
+	}
+}
+
+func FetchHistoricalCmd(ctx context.Context, syncService xsync.SyncService, window Window, start, end time.Time) tea.Cmd {
+	return func() tea.Msg {
+		err := syncService.FetchHistorical(ctx, start, end)
+		return HistoricalMsg{Window: window, Start: start, Err: err}
+	}
+}
+
+// buildDays joins cycles with their recovery and main sleep, oldest first.
+func buildDays(cycles []whoop.Cycle, recoveries []whoop.Recovery, sleeps []whoop.Sleep) []Day {
+	recoveryByCycle := make(map[int64]*whoop.RecoveryScore, len(recoveries))
+	for _, r := range recoveries {
+		if r.Score != nil {
+			recoveryByCycle[r.CycleID] = r.Score
+		}
+	}
+
+	sleepByCycle := make(map[int64]*whoop.SleepScore, len(sleeps))
+	for _, s := range sleeps {
+		if !s.Nap && s.Score != nil {
+			sleepByCycle[s.CycleID] = s.Score
+		}
+	}
+
+	days := make([]Day, 0, len(cycles))
+	for i := len(cycles) - 1; i >= 0; i-- {
+		c := cycles[i]
+		day := Day{Start: c.Start}
+
+		if c.Score != nil {
+			day.Strain = &c.Score.Strain
+		}
+		if r, ok := recoveryByCycle[c.ID]; ok {
+			day.Recovery = &r.RecoveryScore
+			day.HRV = &r.HRVRmssdMilli
+			day.RestingHR = &r.RestingHeartRate
+		}
+		if s, ok := sleepByCycle[c.ID]; ok {
+			day.SleepPerformance = &s.SleepPerformancePercentage
+		}
+
+		days = append(days, day)
+	}
+	return days
+}
@@ -0,0 +1,203 @@
+package trends
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/garrettladley/thoop/internal/tui/components/chart"
+	"github.com/garrettladley/thoop/internal/tui/theme"
+)
+
+type Window uint
+
+const (
+	WindowWeek Window = iota
+	WindowMonth
+	WindowQuarter
+)
+
+var Windows = []Window{WindowWeek, WindowMonth, WindowQuarter}
+
+func (w Window) Days() int {
+	switch w {
+	case WindowWeek:
+		return 7
+	case WindowMonth:
+		return 30
+	case WindowQuarter:
+		return 90
+	default:
+		return 7
+	}
+}
+
+func (w Window) String() string {
+	return fmt.Sprintf("%dd", w.Days())
+}
+
+// Start returns the beginning of the window ending at end.
+func (w Window) Start(end time.Time) time.Time {
+	return end.AddDate(0, 0, -w.Days())
+}
+
+// watermarkSlack tolerates the gap between a window's start and the oldest
+// cycle start, which lands wherever the user happened to fall asleep.
+const watermarkSlack = 24 * time.Hour
+
+// Day holds the trend metrics for a single cycle.
+type Day struct {
+	Start            time.Time
+	Recovery         *float64 // 0-100%
+	HRV              *float64 // ms
+	RestingHR        *float64 // bpm
+	Strain           *float64 // 0-21
+	SleepPerformance *float64 // 0-100%
+}
+
+type State struct {
+	Window  Window
+	Days    []Day
+	Loading bool
+	Syncing bool
+	ErrMsg  string
+
+	// HistoricalFrom is the oldest start already requested from the API this session.
+	HistoricalFrom *time.Time
+}
+
+// NeedsHistorical reports whether the current window reaches past the backfill
+// watermark and hasn't been requested yet. It returns the range to fetch.
+func (s State) NeedsHistorical(now time.Time, watermark *time.Time) (time.Time, time.Time, bool) {
+	if watermark == nil || s.Syncing {
+		return time.Time{}, time.Time{}, false
+	}
+
+	start := s.Window.Start(now)
+	if !start.Before(watermark.Add(-watermarkSlack)) {
+		return time.Time{}, time.Time{}, false
+	}
+	if s.HistoricalFrom != nil && !start.Before(*s.HistoricalFrom) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	return start, *watermark, true
+}
+
+const (
+	headerHeight = 2
+	footerHeight = 2
+	maxWidth     = 120
+	minPlotRows  = 2
+)
+
+func View(t theme.Theme, state State, width, height int) string {
+	var (
+		p          = t.Palette()
+		chartWidth = min(width-4, maxWidth)
+		available  = height - headerHeight - footerHeight
+		series     = seriesFor(p, state.Days)
+		// each chart has a label row and a blank separator row
+		plotRows = max(available/len(series)-2, minPlotRows)
+	)
+
+	rows := make([]string, 0, len(series)*2+1)
+	rows = append(rows, header(p, state, chartWidth), "")
+	for _, s := range series {
+		c := chart.New(s.values, s.label, s.color, chartWidth, plotRows, chart.WithFormat(s.format), chart.WithPalette(p))
+		rows = append(rows, c.Render(), "")
+	}
+
+	return lipgloss.Place(
+		width,
+		height,
+		lipgloss.Center,
+		lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+	)
+}
+
+func header(p theme.Palette, state State, width int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(p.Foreground).
+		Bold(true)
+
+	activeStyle := lipgloss.NewStyle().
+		Foreground(p.Background).
+		Background(p.Accent).
+		Bold(true).
+		Padding(0, 1)
+
+	inactiveStyle := lipgloss.NewStyle().
+		Foreground(p.Dim).
+		Padding(0, 1)
+
+	statusStyle := lipgloss.NewStyle().
+		Foreground(p.Dim)
+
+	tabs := make([]string, 0, len(Windows))
+	for i, w := range Windows {
+		label := fmt.Sprintf("%d %s", i+1, w)
+		if w == state.Window {
+			tabs = append(tabs, activeStyle.Render(label))
+		} else {
+			tabs = append(tabs, inactiveStyle.Render(label))
+		}
+	}
+
+	var status string
+	switch {
+	case state.ErrMsg != "":
+		status = lipgloss.NewStyle().Foreground(p.LowRecovery).Render(state.ErrMsg)
+	case state.Syncing:
+		status = statusStyle.Render("fetching history...")
+	case state.Loading:
+		status = statusStyle.Render("loading...")
+	}
+
+	left := titleStyle.Render("TRENDS") + "  " + strings.Join(tabs, "")
+	spacer := max(width-lipgloss.Width(left)-lipgloss.Width(status), 1)
+	return left + strings.Repeat(" ", spacer) + status
+}
+
+type series struct {
+	label  string
+	values []*float64
+	color  color.Color
+	format func(float64) string
+}
+
+func seriesFor(p theme.Palette, days []Day) []series {
+	var (
+		recovery  = make([]*float64, len(days))
+		hrv       = make([]*float64, len(days))
+		restingHR = make([]*float64, len(days))
+		strain    = make([]*float64, len(days))
+		sleep     = make([]*float64, len(days))
+	)
+	for i, d := range days {
+		recovery[i] = d.Recovery
+		hrv[i] = d.HRV
+		restingHR[i] = d.RestingHR
+		strain[i] = d.Strain
+		sleep[i] = d.SleepPerformance
+	}
+
+	var (
+		percent = func(v float64) string { return fmt.Sprintf("%.0f%%", v) }
+		millis  = func(v float64) string { return fmt.Sprintf("%.0fms", v) }
+		bpm     = func(v float64) string { return fmt.Sprintf("%.0f", v) }
+		decimal = func(v float64) string { return fmt.Sprintf("%.1f", v) }
+	)
+
+	return []series{
+		{"RECOVERY", recovery, p.HighRecovery, percent},
+		{"HRV", hrv, p.Recovery, millis},
+		{"RESTING HR", restingHR, p.Recovery, bpm},
+		{"STRAIN", strain, p.Strain, decimal},
+		{"SLEEP PERFORMANCE", sleep, p.Sleep, percent},
+	}
+}
@@ -0,0 +1,148 @@
+package trends
+
+import (
+	"testing"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+)
+
+func TestBuildDays(t *testing.T) {
+	t.Parallel()
+
+	var (
+		monday  = time.Date(2025, 1, 6, 22, 0, 0, 0, time.UTC)
+		tuesday = monday.AddDate(0, 0, 1)
+	)
+
+	// the repository returns cycles newest first
+	cycles := []whoop.Cycle{
+		{ID: 2, Start: tuesday},
+		{ID: 1, Start: monday, Score: &whoop.CycleScore{Strain: 12.5}},
+	}
+	recoveries := []whoop.Recovery{
+		{CycleID: 1, Score: &whoop.RecoveryScore{RecoveryScore: 66, HRVRmssdMilli: 48, RestingHeartRate: 52}},
+		{CycleID: 2},
+	}
+	sleeps := []whoop.Sleep{
+		{CycleID: 1, Nap: true, Score: &whoop.SleepScore{SleepPerformancePercentage: 10}},
+		{CycleID: 1, Score: &whoop.SleepScore{SleepPerformancePercentage: 91}},
+		{CycleID: 2, Score: &whoop.SleepScore{SleepPerformancePercentage: 74}},
+	}
+
+	days := buildDays(cycles, recoveries, sleeps)
+	if len(days) != 2 {
+		t.Fatalf("buildDays() returned %d days, want 2", len(days))
+	}
+
+	first, second := days[0], days[1]
+	if !first.Start.Equal(monday) || !second.Start.Equal(tuesday) {
+		t.Fatalf("buildDays() = [%s %s], want oldest first", first.Start, second.Start)
+	}
+
+	if first.Strain == nil || *first.Strain != 12.5 {
+		t.Errorf("first.Strain = %v, want 12.5", first.Strain)
+	}
+	if first.Recovery == nil || *first.Recovery != 66 {
+		t.Errorf("first.Recovery = %v, want 66", first.Recovery)
+	}
+	if first.HRV == nil || *first.HRV != 48 {
+		t.Errorf("first.HRV = %v, want 48", first.HRV)
+	}
+	if first.RestingHR == nil || *first.RestingHR != 52 {
+		t.Errorf("first.RestingHR = %v, want 52", first.RestingHR)
+	}
+	if first.SleepPerformance == nil || *first.SleepPerformance != 91 {
+		t.Errorf("first.SleepPerformance = %v, want 91 from the main sleep, not the nap", first.SleepPerformance)
+	}
+
+	if second.Strain != nil {
+		t.Errorf("second.Strain = %v, want nil for an unscored cycle", *second.Strain)
+	}
+	if second.Recovery != nil || second.HRV != nil || second.RestingHR != nil {
+		t.Error("second has recovery metrics, want none for an unscored recovery")
+	}
+	if second.SleepPerformance == nil || *second.SleepPerformance != 74 {
+		t.Errorf("second.SleepPerformance = %v, want 74", second.SleepPerformance)
+	}
+}
+
+func TestStateNeedsHistorical(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now           = time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+		recent        = now.AddDate(0, 0, -20)
+		withinSlack   = now.AddDate(0, 0, -30).Add(12 * time.Hour)
+		monthStart    = WindowMonth.Start(now)
+		quarterStart  = WindowQuarter.Start(now)
+		olderThanSpan = quarterStart.AddDate(0, 0, -1)
+	)
+
+	tests := []struct {
+		name      string
+		state     State
+		watermark *time.Time
+		want      bool
+		wantStart time.Time
+	}{
+		{
+			name:  "no watermark",
+			state: State{Window: WindowQuarter},
+		},
+		{
+			name:      "window inside the backfilled range",
+			state:     State{Window: WindowWeek},
+			watermark: &recent,
+		},
+		{
+			name:      "window start within the slack",
+			state:     State{Window: WindowMonth},
+			watermark: &withinSlack,
+		},
+		{
+			name:      "window past the watermark",
+			state:     State{Window: WindowMonth},
+			watermark: &recent,
+			want:      true,
+			wantStart: monthStart,
+		},
+		{
+			name:      "already syncing",
+			state:     State{Window: WindowMonth, Syncing: true},
+			watermark: &recent,
+		},
+		{
+			name:      "already requested further back",
+			state:     State{Window: WindowMonth, HistoricalFrom: &olderThanSpan},
+			watermark: &recent,
+		},
+		{
+			name:      "wider window than already requested",
+			state:     State{Window: WindowQuarter, HistoricalFrom: &monthStart},
+			watermark: &recent,
+			want:      true,
+			wantStart: quarterStart,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			start, end, ok := tt.state.NeedsHistorical(now, tt.watermark)
+			if ok != tt.want {
+				t.Fatalf("NeedsHistorical() ok = %v, want %v", ok, tt.want)
+			}
+			if !ok {
+				return
+			}
+			if !start.Equal(tt.wantStart) {
+				t.Errorf("NeedsHistorical() start = %s, want %s", start, tt.wantStart)
+			}
+			if !end.Equal(*tt.watermark) {
+				t.Errorf("NeedsHistorical() end = %s, want the watermark %s", end, *tt.watermark)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package workouts
+
+import (
+	"context"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+	"github.com/garrettladley/thoop/internal/training"
+)
+
+const pageSize = 30
+
+type DataMsg struct {
+	Workouts   []whoop.Workout // newest first
+	NextCursor *time.Time
+	Err        error
+}
+
+// LoadCmd loads a page of cached workouts that started before cursor, or the
+// most recent page when cursor is nil.
+func LoadCmd(ctx context.Context, repo *repository.Repository, cursor *time.Time) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		result, err := repo.Workouts.GetByDateRange(ctx, time.Time{}, time.Now(), &repository.CursorParams{
+			Cursor: cursor,
+			Limit:  pageSize,
+		})
+		if err != nil {
+			return DataMsg{Err: err}
+		}
+		return DataMsg{Workouts: result.Records, NextCursor: result.NextCursor}
+	}
+}
+
+type TrainingMsg struct {
+	Report *training.Report
+	Err    error
+}
+
+// TrainingCmd works out today's training load in strain from the cached workouts.
+func TrainingCmd(ctx context.Context, repo *repository.Repository) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		now := time.Now()
+		workouts, err := training.Load(ctx, repo, now)
+		if err != nil {
+			return TrainingMsg{Err: err}
+		}
+		r := training.Compute(workouts, training.MeasureStrain, now)
+		return TrainingMsg{Report: &r}
+	}
+}
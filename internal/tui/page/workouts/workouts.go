@@ -0,0 +1,359 @@
+package workouts
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/training"
+	"github.com/garrettladley/thoop/internal/tui/components/bar"
+	"github.com/garrettladley/thoop/internal/tui/theme"
+	"github.com/garrettladley/thoop/internal/xtime"
+)
+
+type State struct {
+	Workouts []whoop.Workout // newest first
+	Selected int
+	// Detail is set while the selected workout's detail pane is open.
+	Detail bool
+
+	// Training is the current training load, nil until it has loaded.
+	// ShowTraining swaps the list for its per sport breakdown, and
+	// TrainingKey is the key that toggles it.
+	Training     *training.Report
+	ShowTraining bool
+	TrainingKey  string
+
+	// Cursor is where the next older page starts. Exhausted is set once the
+	// cache has no older workouts.
+	Cursor    *time.Time
+	Exhausted bool
+	Loading   bool
+	ErrMsg    string
+}
+
+func (s *State) Append(workouts []whoop.Workout, next *time.Time) {
+	s.Workouts = append(s.Workouts, workouts...)
+	s.Cursor = next
+	s.Exhausted = next == nil
+}
+
+// Down moves the selection to an older workout. It reports whether another
+// page should be loaded first, in which case the selection is left alone.
+func (s *State) Down() bool {
+	if s.Selected+1 < len(s.Workouts) {
+		s.Selected++
+		return false
+	}
+	return !s.Exhausted && !s.Loading
+}
+
+func (s *State) Up() {
+	if s.Selected > 0 {
+		s.Selected--
+	}
+}
+
+// Current returns the selected workout, or nil when there are none.
+func (s State) Current() *whoop.Workout {
+	if s.Selected < 0 || s.Selected >= len(s.Workouts) {
+		return nil
+	}
+	return &s.Workouts[s.Selected]
+}
+
+const (
+	headerHeight = 2
+	footerHeight = 2
+	maxWidth     = 120
+	listWidth    = 64
+	detailWidth  = 48
+	// sideBySideWidth is the narrowest terminal that fits the list and the
+	// detail pane next to each other.
+	sideBySideWidth = listWidth + detailWidth + 4
+)
+
+// renderer draws the page in a theme's colors.
+type renderer struct {
+	palette       theme.Palette
+	titleStyle    lipgloss.Style
+	sectionStyle  lipgloss.Style
+	labelStyle    lipgloss.Style
+	textStyle     lipgloss.Style
+	selectedStyle lipgloss.Style
+}
+
+func newRenderer(t theme.Theme) renderer {
+	p := t.Palette()
+	return renderer{
+		palette:       p,
+		titleStyle:    lipgloss.NewStyle().Foreground(p.Foreground).Bold(true),
+		sectionStyle:  lipgloss.NewStyle().Foreground(p.Strain).Bold(true),
+		labelStyle:    lipgloss.NewStyle().Foreground(p.Dim),
+		textStyle:     lipgloss.NewStyle().Foreground(p.Foreground),
+		selectedStyle: lipgloss.NewStyle().Foreground(p.Background).Background(p.Strain),
+	}
+}
+
+func View(t theme.Theme, state State, width, height int) string {
+	var (
+		r            = newRenderer(t)
+		contentWidth = min(width-4, maxWidth)
+		rows         = max(height-headerHeight-footerHeight-1, 1)
+		body         string
+	)
+
+	top := r.header(state, contentWidth)
+	if state.Training != nil {
+		top = lipgloss.JoinVertical(lipgloss.Left, top, r.trainingSummary(state.Training.Total))
+		rows = max(rows-1, 1)
+	}
+
+	workout := state.Current()
+	switch {
+	case state.ShowTraining && state.Training != nil:
+		body = r.training(*state.Training, rows)
+	case workout == nil && state.Loading:
+		body = r.labelStyle.Render("loading...")
+	case workout == nil:
+		body = r.labelStyle.Render("no workouts cached yet")
+	case state.Detail && contentWidth >= sideBySideWidth:
+		body = lipgloss.JoinHorizontal(lipgloss.Top,
+			r.list(state, listWidth, rows),
+			"    ",
+			r.detail(*workout, detailWidth),
+		)
+	case state.Detail:
+		body = r.detail(*workout, contentWidth)
+	default:
+		body = r.list(state, min(contentWidth, listWidth), rows)
+	}
+
+	return lipgloss.Place(
+		width,
+		height,
+		lipgloss.Center,
+		lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left, top, "", body),
+	)
+}
+
+func (r renderer) header(state State, width int) string {
+	left := r.titleStyle.Render("WORKOUTS")
+
+	var status string
+	switch {
+	case state.ErrMsg != "":
+		status = lipgloss.NewStyle().Foreground(r.palette.LowRecovery).Render(state.ErrMsg)
+	case state.Loading:
+		status = r.labelStyle.Render("loading...")
+	case state.ShowTraining && state.TrainingKey != "":
+		status = r.labelStyle.Render(state.TrainingKey + " workouts")
+	}
+
+	spacer := max(width-lipgloss.Width(left)-lipgloss.Width(status), 1)
+	return left + strings.Repeat(" ", spacer) + status
+}
+
+// list renders a window of rows workouts that keeps the selection in view.
+func (r renderer) list(state State, width, rows int) string {
+	const columns = "%-10s %-18s %6s %8s %5s %5s"
+
+	lines := []string{r.labelStyle.Render(fmt.Sprintf(columns, "date", "sport", "strain", "time", "avg", "max"))}
+
+	visible := rows - 1
+	offset := max(state.Selected-visible+1, 0)
+	for i := offset; i < len(state.Workouts) && i < offset+visible; i++ {
+		var (
+			w      = state.Workouts[i]
+			loc    = whoop.Location(w.TimezoneOffset)
+			strain = "-"
+			avg    = "-"
+			maxHR  = "-"
+		)
+		if w.Score != nil {
+			strain = fmt.Sprintf("%.1f", w.Score.Strain)
+			avg = fmt.Sprintf("%d", w.Score.AverageHeartRate)
+			maxHR = fmt.Sprintf("%d", w.Score.MaxHeartRate)
+		}
+
+		line := fmt.Sprintf(columns,
+			w.Start.In(loc).Format("Mon Jan 2"),
+			truncate(w.SportName, 18),
+			strain,
+			xtime.FormatDuration(w.End.Sub(w.Start)),
+			avg,
+			maxHR,
+		)
+		line += strings.Repeat(" ", max(width-lipgloss.Width(line), 0))
+
+		if i == state.Selected {
+			lines = append(lines, r.selectedStyle.Render(line))
+		} else {
+			lines = append(lines, r.textStyle.Render(line))
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func (r renderer) detail(w whoop.Workout, width int) string {
+	loc := whoop.Location(w.TimezoneOffset)
+
+	lines := []string{
+		r.titleStyle.Render(strings.ToUpper(w.SportName)),
+		r.labelStyle.Render(fmt.Sprintf("%s · %s – %s",
+			w.Start.In(loc).Format("Mon, Jan 2"),
+			w.Start.In(loc).Format("3:04pm"),
+			w.End.In(loc).Format("3:04pm"),
+		)),
+		"",
+	}
+
+	if w.Score == nil {
+		lines = append(lines, r.labelStyle.Render(fmt.Sprintf("not scored yet (%s)", strings.ToLower(string(w.ScoreState)))))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	score := w.Score
+	stats := []string{
+		fmt.Sprintf("strain %.1f", score.Strain),
+		fmt.Sprintf("avg %d bpm", score.AverageHeartRate),
+		fmt.Sprintf("max %d bpm", score.MaxHeartRate),
+		fmt.Sprintf("%.0f kcal", score.Kilojoule/kilojoulesPerCalorie),
+	}
+	lines = append(lines, r.textStyle.Render(strings.Join(stats, " · ")))
+
+	var extras []string
+	if score.DistanceMeter != nil {
+		extras = append(extras, fmt.Sprintf("%.2f km", *score.DistanceMeter/1000))
+	}
+	if score.AltitudeGainMeter != nil {
+		extras = append(extras, fmt.Sprintf("%.0f m climbed", *score.AltitudeGainMeter))
+	}
+	extras = append(extras, fmt.Sprintf("%.0f%% recorded", score.PercentRecorded))
+	lines = append(lines, r.labelStyle.Render(strings.Join(extras, " · ")), "")
+
+	lines = append(lines, r.sectionStyle.Render("HEART RATE ZONES"), r.zones(score.ZoneDurations, width))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// kilojoulesPerCalorie converts WHOOP's energy in kilojoules to kcal.
+const kilojoulesPerCalorie = 4.184
+
+func (r renderer) zoneColors() []color.Color {
+	return []color.Color{
+		r.palette.Dim,
+		r.palette.Recovery,
+		r.palette.Accent,
+		r.palette.HighRecovery,
+		r.palette.MediumRecovery,
+		r.palette.LowRecovery,
+	}
+}
+
+// zones draws the time spent in each heart rate zone as a horizontal histogram.
+func (r renderer) zones(z whoop.WorkoutZones, width int) string {
+	var (
+		durations = []int{
+			z.ZoneZeroMilli,
+			z.ZoneOneMilli,
+			z.ZoneTwoMilli,
+			z.ZoneThreeMilli,
+			z.ZoneFourMilli,
+			z.ZoneFiveMilli,
+		}
+		total, longest int
+	)
+	for _, d := range durations {
+		total += d
+		longest = max(longest, d)
+	}
+
+	const (
+		labelWidth = 4
+		valueWidth = 14
+	)
+	var (
+		barWidth = max(width-labelWidth-valueWidth, 1)
+		colors   = r.zoneColors()
+	)
+
+	rows := make([]string, len(durations))
+	for i, d := range durations {
+		pct := 0
+		if total > 0 {
+			pct = d * 100 / total
+		}
+		rows[i] = r.labelStyle.Width(labelWidth).Render(fmt.Sprintf("Z%d", i)) +
+			bar.Horizontal(float64(d), float64(longest), barWidth, colors[i], r.palette.Dim) +
+			r.textStyle.Render(fmt.Sprintf(" %7s %3d%%", xtime.FormatDuration(xtime.Millis(d)), pct))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// zoneColor colors an acute:chronic workload ratio zone by its injury risk.
+func (r renderer) zoneColor(z training.Zone) color.Color {
+	switch z {
+	case training.ZoneOptimal:
+		return r.palette.HighRecovery
+	case training.ZoneCaution:
+		return r.palette.MediumRecovery
+	case training.ZoneDanger:
+		return r.palette.LowRecovery
+	default:
+		return r.palette.Dim
+	}
+}
+
+// trainingSummary renders the overall load on one line, like
+// "load 9.8 acute · 7.1 chronic · acwr 1.38 caution".
+func (r renderer) trainingSummary(w training.Workload) string {
+	return r.labelStyle.Render(fmt.Sprintf("load %.1f acute · %.1f chronic · acwr %.2f ", w.Acute, w.Chronic, w.ACWR)) +
+		lipgloss.NewStyle().Foreground(r.zoneColor(w.Zone)).Render(string(w.Zone))
+}
+
+// training renders the load of each sport and any warnings, within rows lines.
+func (r renderer) training(report training.Report, rows int) string {
+	const columns = "%-18s %5s %6s %7s %5s %-8s %8s %7s"
+
+	lines := []string{
+		r.sectionStyle.Render("TRAINING LOAD · STRAIN"),
+		r.labelStyle.Render(fmt.Sprintf(columns, "sport", "count", "acute", "chronic", "acwr", "zone", "monotony", "balance")),
+	}
+	for _, l := range append([]training.Workload{report.Total}, report.Sports...) {
+		sport := l.Sport
+		if sport == "" {
+			sport = "all sports"
+		}
+		cells := fmt.Sprintf("%-18s %5d %6.1f %7.1f %5.2f ", truncate(sport, 18), l.Workouts, l.Acute, l.Chronic, l.ACWR)
+		lines = append(lines, r.textStyle.Render(cells)+
+			lipgloss.NewStyle().Foreground(r.zoneColor(l.Zone)).Render(fmt.Sprintf("%-8s", l.Zone))+
+			r.textStyle.Render(fmt.Sprintf(" %8.2f %+7.1f", l.Monotony, l.Balance)))
+	}
+
+	if len(report.Warnings) > 0 {
+		lines = append(lines, "")
+	}
+	warn := lipgloss.NewStyle().Foreground(r.palette.MediumRecovery)
+	for _, w := range report.Warnings {
+		lines = append(lines, warn.Render("! "+w.String()))
+	}
+
+	if len(lines) > rows {
+		lines = lines[:rows]
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
@@ -0,0 +1,101 @@
+package workouts
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/training"
+	"github.com/garrettladley/thoop/internal/tui/theme"
+)
+
+func TestStateSelection(t *testing.T) {
+	t.Parallel()
+
+	cursor := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var s State
+	s.Append([]whoop.Workout{{ID: "a"}, {ID: "b"}}, &cursor)
+
+	if s.Down() {
+		t.Fatal("Down() wants a page while a loaded workout is left")
+	}
+	if s.Current().ID != "b" {
+		t.Errorf("Current() = %s, want b", s.Current().ID)
+	}
+	if !s.Down() {
+		t.Fatal("Down() doesn't want a page at the last loaded workout")
+	}
+
+	s.Loading = true
+	if s.Down() {
+		t.Error("Down() wants a page while one is already loading")
+	}
+
+	s.Loading = false
+	s.Append(nil, nil)
+	if s.Down() {
+		t.Error("Down() wants a page once the cache is exhausted")
+	}
+
+	s.Up()
+	s.Up()
+	if s.Current().ID != "a" {
+		t.Errorf("Current() = %s, want a", s.Current().ID)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{"running", 18, "running"},
+		{"functional-fitness", 18, "functional-fitness"},
+		{"functional-fitness-extra", 18, "functional-fitnes…"},
+	}
+
+	for _, tt := range tests {
+		if got := truncate(tt.in, tt.n); got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestView_Training(t *testing.T) {
+	t.Parallel()
+
+	state := State{
+		Training: &training.Report{
+			Measure: training.MeasureStrain,
+			Total:   training.Workload{Workouts: 9, Acute: 12, Chronic: 8, ACWR: 1.5, Zone: training.ZoneCaution},
+			Sports: []training.Workload{
+				{Sport: "running", Workouts: 9, Acute: 12, Chronic: 8, ACWR: 1.5, Zone: training.ZoneCaution},
+			},
+			Warnings: []training.Warning{{Message: "acute load is 1.50× chronic"}},
+		},
+	}
+
+	view := ansi.Strip(View(theme.New(), state, 100, 30))
+	if !strings.Contains(view, "load 12.0 acute · 8.0 chronic · acwr 1.50 caution") {
+		t.Errorf("View() is missing the load summary:\n%s", view)
+	}
+	if strings.Contains(view, "running") {
+		t.Errorf("View() shows the sports before toggling:\n%s", view)
+	}
+
+	state.ShowTraining = true
+	state.TrainingKey = "t"
+	view = ansi.Strip(View(theme.New(), state, 100, 30))
+	for _, want := range []string{"t workouts", "all sports", "running", "! acute load is 1.50× chronic"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() is missing %q:\n%s", want, view)
+		}
+	}
+}
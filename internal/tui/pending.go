@@ -0,0 +1,31 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/garrettladley/thoop/internal/xsync"
+)
+
+type PendingTickMsg struct{}
+
+type PendingReconciledMsg struct {
+	Result xsync.PendingResult
+	Err    error
+}
+
+func PendingTickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return PendingTickMsg{}
+	})
+}
+
+// ReconcilePendingCmd re-fetches records still waiting on a WHOOP score.
+func ReconcilePendingCmd(ctx context.Context, reconciler *xsync.PendingReconciler) tea.Cmd {
+	return func() tea.Msg {
+		result, err := reconciler.Reconcile(ctx)
+		return PendingReconciledMsg{Result: result, Err: err}
+	}
+}
@@ -22,3 +22,10 @@ var (
 	ColorBgDark  = lipgloss.Color("#101518") // Darker end of gradient
 	ColorBgLight = lipgloss.Color("#283339") // Lighter end of gradient
 )
+
+var (
+	ColorSleepAwake = lipgloss.Color("#C9D6DF") // Awake time in bed
+	ColorSleepLight = lipgloss.Color("#7BA1BB") // Light sleep
+	ColorSleepSWS   = lipgloss.Color("#3E6A8A") // Slow wave (deep) sleep
+	ColorSleepREM   = lipgloss.Color("#9B8CE8") // REM sleep
+)
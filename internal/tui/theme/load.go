@@ -0,0 +1,111 @@
+package theme
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"charm.land/lipgloss/v2"
+	"github.com/BurntSushi/toml"
+)
+
+// ErrUnknown is returned by Load when name is neither built in nor a palette file.
+var ErrUnknown = errors.New("unknown theme")
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// paletteKeys are the keys of a palette file's [colors] table.
+var paletteKeys = map[string]func(*Palette) *color.Color{
+	"backdrop":        func(p *Palette) *color.Color { return &p.Backdrop },
+	"background":      func(p *Palette) *color.Color { return &p.Background },
+	"surface":         func(p *Palette) *color.Color { return &p.Surface },
+	"foreground":      func(p *Palette) *color.Color { return &p.Foreground },
+	"dim":             func(p *Palette) *color.Color { return &p.Dim },
+	"accent":          func(p *Palette) *color.Color { return &p.Accent },
+	"strain":          func(p *Palette) *color.Color { return &p.Strain },
+	"recovery":        func(p *Palette) *color.Color { return &p.Recovery },
+	"high_recovery":   func(p *Palette) *color.Color { return &p.HighRecovery },
+	"medium_recovery": func(p *Palette) *color.Color { return &p.MediumRecovery },
+	"low_recovery":    func(p *Palette) *color.Color { return &p.LowRecovery },
+	"sleep":           func(p *Palette) *color.Color { return &p.Sleep },
+	"sleep_awake":     func(p *Palette) *color.Color { return &p.SleepAwake },
+	"sleep_light":     func(p *Palette) *color.Color { return &p.SleepLight },
+	"sleep_sws":       func(p *Palette) *color.Color { return &p.SleepSWS },
+	"sleep_rem":       func(p *Palette) *color.Color { return &p.SleepREM },
+}
+
+// Load returns the theme called name. Built-in themes win; anything else is
+// read from <name>.toml in dir, which looks like:
+//
+//	base = "light" # optional, defaults to dark
+//
+//	[colors]
+//	accent = "#00A36C"
+//	low_recovery = "#C00020"
+//
+// Colors the file leaves out come from the base theme.
+func Load(dir, name string) (Theme, error) {
+	if t, ok := Builtin(name); ok {
+		return t, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, filepath.Base(name)+".toml")) //nolint:gosec // dir is from trusted paths package
+	if errors.Is(err, os.ErrNotExist) {
+		return Theme{}, fmt.Errorf("%w %q", ErrUnknown, name)
+	}
+	if err != nil {
+		return Theme{}, fmt.Errorf("failed to read theme %q: %w", name, err)
+	}
+
+	palette, err := parsePalette(data)
+	if err != nil {
+		return Theme{}, fmt.Errorf("failed to parse theme %q: %w", name, err)
+	}
+	return FromPalette(name, palette), nil
+}
+
+// paletteFile is the layout of a palette file.
+type paletteFile struct {
+	Base   string            `toml:"base"`
+	Colors map[string]string `toml:"colors"`
+}
+
+func parsePalette(data []byte) (Palette, error) {
+	var f paletteFile
+	md, err := toml.Decode(string(data), &f)
+	if err != nil {
+		return Palette{}, fmt.Errorf("%w", err)
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		return Palette{}, fmt.Errorf("unknown key %q", undecoded[0].String())
+	}
+
+	baseName := NameDark
+	if f.Base != "" {
+		baseName = f.Base
+	}
+	base, ok := builtins[baseName]
+	if !ok {
+		return Palette{}, fmt.Errorf("unknown base theme %q", baseName)
+	}
+	p := base()
+
+	// sorted so problems are reported in a stable order
+	for _, key := range slices.Sorted(maps.Keys(f.Colors)) {
+		field, ok := paletteKeys[key]
+		if !ok {
+			return Palette{}, fmt.Errorf("unknown color %q", key)
+		}
+		value := f.Colors[key]
+		if !hexColor.MatchString(value) {
+			return Palette{}, fmt.Errorf("color %q: %q is not a hex color like #1A2B3C", key, value)
+		}
+		*field(&p) = lipgloss.Color(value)
+	}
+	return p, nil
+}
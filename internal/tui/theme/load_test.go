@@ -0,0 +1,114 @@
+package theme
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, contents string) {
+		if err := os.WriteFile(filepath.Join(dir, name+".toml"), []byte(contents), 0o600); err != nil {
+			t.Fatalf("failed to write palette: %v", err)
+		}
+	}
+	write("solarized", `
+base = "light"
+
+[colors]
+accent = "#2AA198"
+low_recovery = "#DC322F"
+`)
+	write("unknown-key", "[colors]\nmauve = \"#E0B0FF\"\n")
+	write("bad-hex", "[colors]\naccent = \"teal\"\n")
+	write("bad-base", "base = \"sepia\"\n")
+	write("unquoted", "[colors]\naccent = teal\n")
+	write("array", "[colors]\naccent = [1, 2]\n")
+	write("inline-table", "colors = { accent = 1 }\n")
+	write("unknown-root-key", "bsae = \"light\"\n")
+	// a palette file can't shadow a built-in theme
+	write(NameLight, "[colors]\naccent = \"#000000\"\n")
+
+	t.Run("builtin", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := Load(dir, NameLight)
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got.Palette() != lightPalette() {
+			t.Error("Load(light) didn't return the built-in light palette")
+		}
+	})
+
+	t.Run("custom palette over its base", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := Load(dir, "solarized")
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		want := lightPalette()
+		want.Accent = lipgloss.Color("#2AA198")
+		want.LowRecovery = lipgloss.Color("#DC322F")
+		if got.Palette() != want {
+			t.Errorf("Load(solarized) palette = %+v, want %+v", got.Palette(), want)
+		}
+		if got.Name() != "solarized" {
+			t.Errorf("Name() = %q, want %q", got.Name(), "solarized")
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := Load(dir, "nord"); !errors.Is(err, ErrUnknown) {
+			t.Errorf("Load(nord) error = %v, want %v", err, ErrUnknown)
+		}
+	})
+
+	for _, name := range []string{"unknown-key", "bad-hex", "bad-base", "unquoted", "array", "inline-table", "unknown-root-key"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := Load(dir, name); err == nil {
+				t.Errorf("Load(%s) error = nil, want error", name)
+			}
+		})
+	}
+}
+
+func TestRecoveryColor(t *testing.T) {
+	t.Parallel()
+
+	p := darkPalette()
+	score := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name  string
+		score *float64
+		want  any
+	}{
+		{name: "no score", score: nil, want: p.Recovery},
+		{name: "high", score: score(67), want: p.HighRecovery},
+		{name: "medium", score: score(34), want: p.MediumRecovery},
+		{name: "low", score: score(33.9), want: p.LowRecovery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := p.RecoveryColor(tt.score); got != tt.want {
+				t.Errorf("RecoveryColor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package theme
+
+import "image/color"
+
+// Palette is every color the TUI draws with.
+type Palette struct {
+	// Backdrop fills the splash and onboarding pages.
+	Backdrop color.Color
+	// Background fills the data pages; Surface is drawn on top of it for
+	// unfilled gauge arcs and other inactive marks.
+	Background color.Color
+	Surface    color.Color
+	Foreground color.Color
+	Dim        color.Color
+	// Accent marks calls to action, highlights and Sleep Need.
+	Accent color.Color
+
+	Strain color.Color
+	// Recovery is for recovery data without a valuation, like HRV.
+	Recovery       color.Color
+	HighRecovery   color.Color
+	MediumRecovery color.Color
+	LowRecovery    color.Color
+
+	Sleep      color.Color
+	SleepAwake color.Color
+	SleepLight color.Color
+	SleepSWS   color.Color
+	SleepREM   color.Color
+}
+
+// RecoveryColor returns the color WHOOP uses for a recovery score, or the
+// neutral recovery color when there is no score.
+func (p Palette) RecoveryColor(score *float64) color.Color {
+	if score == nil {
+		return p.Recovery
+	}
+
+	switch s := *score; {
+	case s >= 67:
+		return p.HighRecovery
+	case s >= 34:
+		return p.MediumRecovery
+	default:
+		return p.LowRecovery
+	}
+}
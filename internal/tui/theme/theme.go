@@ -7,19 +7,39 @@ import (
 )
 
 type Theme struct {
-	background color.Color
-	foreground color.Color
-	base       lipgloss.Style
+	name    string
+	palette Palette
+	base    lipgloss.Style
 }
 
+// New returns the default dark theme.
 func New() Theme {
-	var t Theme
+	return FromPalette(NameDark, darkPalette())
+}
+
+// Builtin returns the built-in theme called name.
+func Builtin(name string) (Theme, bool) {
+	palette, ok := builtins[name]
+	if !ok {
+		return Theme{}, false
+	}
+	return FromPalette(name, palette()), true
+}
+
+func FromPalette(name string, p Palette) Theme {
+	return Theme{
+		name:    name,
+		palette: p,
+		base:    lipgloss.NewStyle().Foreground(p.Foreground),
+	}
+}
 
-	t.background = ColorBgDark
-	t.foreground = ColorWhite
-	t.base = lipgloss.NewStyle().Foreground(t.foreground)
+func (t Theme) Name() string {
+	return t.name
+}
 
-	return t
+func (t Theme) Palette() Palette {
+	return t.palette
 }
 
 func (t Theme) Base() lipgloss.Style {
@@ -27,13 +47,17 @@ func (t Theme) Base() lipgloss.Style {
 }
 
 func (t Theme) TextAccent() lipgloss.Style {
-	return lipgloss.NewStyle().Foreground(t.foreground)
+	return lipgloss.NewStyle().Foreground(t.palette.Foreground)
 }
 
 func (t Theme) Background() color.Color {
-	return t.background
+	return t.palette.Background
+}
+
+func (t Theme) Backdrop() color.Color {
+	return t.palette.Backdrop
 }
 
 func (t Theme) Foreground() color.Color {
-	return t.foreground
+	return t.palette.Foreground
 }
@@ -0,0 +1,105 @@
+package theme
+
+import (
+	"slices"
+
+	"charm.land/lipgloss/v2"
+)
+
+const (
+	NameDark         = "dark"
+	NameLight        = "light"
+	NameHighContrast = "high-contrast"
+	// NameColorBlind swaps the recovery colors for ones that stay distinct
+	// with red-green color blindness.
+	NameColorBlind = "colorblind"
+)
+
+var builtins = map[string]func() Palette{
+	NameDark:         darkPalette,
+	NameLight:        lightPalette,
+	NameHighContrast: highContrastPalette,
+	NameColorBlind:   colorBlindPalette,
+}
+
+// Names returns the built-in theme names.
+func Names() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func darkPalette() Palette {
+	return Palette{
+		Backdrop:       ColorBlack,
+		Background:     ColorBgDark,
+		Surface:        ColorBgLight,
+		Foreground:     ColorWhite,
+		Dim:            ColorDim,
+		Accent:         ColorTeal,
+		Strain:         ColorStrain,
+		Recovery:       ColorRecoveryBlue,
+		HighRecovery:   ColorHighRecovery,
+		MediumRecovery: ColorMediumRecovery,
+		LowRecovery:    ColorLowRecovery,
+		Sleep:          ColorSleep,
+		SleepAwake:     ColorSleepAwake,
+		SleepLight:     ColorSleepLight,
+		SleepSWS:       ColorSleepSWS,
+		SleepREM:       ColorSleepREM,
+	}
+}
+
+func lightPalette() Palette {
+	return Palette{
+		Backdrop:       lipgloss.Color("#FFFFFF"),
+		Background:     lipgloss.Color("#F4F6F7"),
+		Surface:        lipgloss.Color("#D5DDE2"),
+		Foreground:     lipgloss.Color("#101518"),
+		Dim:            lipgloss.Color("#7A8590"),
+		Accent:         lipgloss.Color("#00A36C"),
+		Strain:         lipgloss.Color("#0071B8"),
+		Recovery:       lipgloss.Color("#3F86C0"),
+		HighRecovery:   lipgloss.Color("#1E9E12"),
+		MediumRecovery: lipgloss.Color("#B89600"),
+		LowRecovery:    lipgloss.Color("#D0001F"),
+		Sleep:          lipgloss.Color("#4F7A96"),
+		SleepAwake:     lipgloss.Color("#8FA3B2"),
+		SleepLight:     lipgloss.Color("#6F93AB"),
+		SleepSWS:       lipgloss.Color("#2E5675"),
+		SleepREM:       lipgloss.Color("#6E5CC8"),
+	}
+}
+
+func highContrastPalette() Palette {
+	return Palette{
+		Backdrop:       lipgloss.Color("#000000"),
+		Background:     lipgloss.Color("#000000"),
+		Surface:        lipgloss.Color("#4D4D4D"),
+		Foreground:     lipgloss.Color("#FFFFFF"),
+		Dim:            lipgloss.Color("#B3B3B3"),
+		Accent:         lipgloss.Color("#00FFAA"),
+		Strain:         lipgloss.Color("#33B5FF"),
+		Recovery:       lipgloss.Color("#99CCFF"),
+		HighRecovery:   lipgloss.Color("#00FF00"),
+		MediumRecovery: lipgloss.Color("#FFFF00"),
+		LowRecovery:    lipgloss.Color("#FF3333"),
+		Sleep:          lipgloss.Color("#A8D0FF"),
+		SleepAwake:     lipgloss.Color("#FFFFFF"),
+		SleepLight:     lipgloss.Color("#A8D0FF"),
+		SleepSWS:       lipgloss.Color("#3399FF"),
+		SleepREM:       lipgloss.Color("#CC99FF"),
+	}
+}
+
+func colorBlindPalette() Palette {
+	p := darkPalette()
+	// Okabe-Ito bluish green, yellow and vermillion
+	p.HighRecovery = lipgloss.Color("#009E73")
+	p.MediumRecovery = lipgloss.Color("#F0E442")
+	p.LowRecovery = lipgloss.Color("#D55E00")
+	return p
+}
@@ -155,6 +155,11 @@ func Backoff(d time.Duration) slog.Attr {
 	return slog.Duration(backoffKey, d)
 }
 
+func Attempt(n int) slog.Attr {
+	const attemptKey = "attempt"
+	return slog.Int(attemptKey, n)
+}
+
 func Type(t string) slog.Attr {
 	const typeKey = "type"
 	return slog.String(typeKey, t)
@@ -2,57 +2,134 @@ package xsync
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
 	"github.com/garrettladley/thoop/internal/xslog"
 	"golang.org/x/sync/errgroup"
 )
 
 const (
+	// BackfillDuration is the default backfill horizon.
 	BackfillDuration = 30 * 24 * time.Hour
 
+	// FullHistory is the backfill horizon that covers the entire account.
+	FullHistory time.Duration = 0
+
 	BackfillPageSize = 10
 
 	maxRecoveryConcurrency = 2
 )
 
-func (s *Service) runBackfill(ctx context.Context) {
+// pageResult summarises a single backfilled page.
+type pageResult struct {
+	count     int
+	oldest    *time.Time
+	nextToken *string
+}
+
+// fetchPageFunc fetches and stores a single page of an entity type.
+type fetchPageFunc func(ctx context.Context, params *whoop.ListParams) (pageResult, error)
+
+var ErrBackfillRunning = errors.New("backfill already running")
+
+// Backfill runs the backfill in the foreground, resuming each entity type from
+// its stored watermark until the configured horizon is reached.
+func (s *Service) Backfill(ctx context.Context) error {
+	if !s.backfilling.CompareAndSwap(false, true) {
+		return ErrBackfillRunning
+	}
+	defer s.backfilling.Store(false)
+
+	return s.backfill(ctx)
+}
+
+func (s *Service) backfill(ctx context.Context) error {
+	ctx = whoop.WithPriority(ctx, whoop.PriorityBackfill)
+
 	s.logger.InfoContext(ctx, "starting backfill")
 
-	end := time.Now()
-	start := end.Add(-BackfillDuration)
+	cycles, err := s.repo.BackfillProgress.Get(ctx, repository.BackfillEntityCycle)
+	if err != nil {
+		return fmt.Errorf("failed to get cycle backfill progress: %w", err)
+	}
 
 	g, gctx := errgroup.WithContext(ctx)
-	g.Go(func() error { return s.backfillCycles(gctx, start, end) })
-	g.Go(func() error { return s.backfillSleeps(gctx, start, end) })
-	g.Go(func() error { return s.backfillWorkouts(gctx, start, end) })
+	g.Go(func() error { return s.backfillEntity(gctx, repository.BackfillEntityCycle, s.fetchCyclePage) })
+	g.Go(func() error { return s.backfillEntity(gctx, repository.BackfillEntityRecovery, s.fetchRecoveryPage) })
+	g.Go(func() error { return s.backfillEntity(gctx, repository.BackfillEntitySleep, s.fetchSleepPage) })
+	g.Go(func() error { return s.backfillEntity(gctx, repository.BackfillEntityWorkout, s.fetchWorkoutPage) })
 	if err := g.Wait(); err != nil {
-		s.logger.ErrorContext(ctx, "backfill failed", xslog.Error(err))
-		return
+		return fmt.Errorf("%w", err)
+	}
+
+	if err := s.fillBackfilledRecoveryGaps(ctx, cycles); err != nil {
+		s.logger.WarnContext(ctx, "failed to fill recovery gaps", xslog.Error(err))
 	}
 
 	if err := s.repo.SyncState.MarkBackfillComplete(ctx); err != nil {
-		s.logger.ErrorContext(ctx, "failed to mark backfill complete", xslog.Error(err))
-		return
+		return fmt.Errorf("failed to mark backfill complete: %w", err)
 	}
 
 	s.logger.InfoContext(ctx, "backfill complete")
+	return nil
+}
+
+// runBackfill runs a backfill that StartBackfill has already claimed.
+func (s *Service) runBackfill(ctx context.Context) {
+	defer s.backfilling.Store(false)
+
+	if err := s.backfill(ctx); err != nil {
+		s.logger.ErrorContext(ctx, "backfill failed", xslog.Error(err))
+	}
+}
+
+// horizonStart returns the oldest start the backfill should reach, or nil to
+// walk back through the entire account.
+func (s *Service) horizonStart(now time.Time) *time.Time {
+	if s.horizon == FullHistory {
+		return nil
+	}
+	start := now.Add(-s.horizon)
+	return &start
 }
 
-func (s *Service) backfillCycles(ctx context.Context, start, end time.Time) error {
-	s.logger.InfoContext(ctx, "backfilling cycles",
-		xslog.Start(start),
+// backfillEntity pages backwards from the entity's watermark to the horizon,
+// checkpointing progress after every page so an interrupted run can resume.
+func (s *Service) backfillEntity(ctx context.Context, entity repository.BackfillEntity, fetch fetchPageFunc) error {
+	now := time.Now()
+	start := s.horizonStart(now)
+
+	progress, err := s.repo.BackfillProgress.Get(ctx, entity)
+	if err != nil {
+		return fmt.Errorf("failed to get %s backfill progress: %w", entity, err)
+	}
+	if progress == nil {
+		progress = &repository.BackfillProgress{Entity: entity, RangeEnd: now}
+	}
+	if reachedHorizon(progress, start) {
+		s.logger.InfoContext(ctx, "backfill already complete", xslog.EntityType(string(entity)))
+		return nil
+	}
+
+	end := progress.RangeEnd
+	if progress.Watermark != nil {
+		end = *progress.Watermark
+	}
+
+	s.logger.InfoContext(ctx, "backfilling",
+		xslog.EntityType(string(entity)),
 		xslog.End(end))
 
 	params := &whoop.ListParams{
-		Start: &start,
+		Start: start,
 		End:   &end,
 		Limit: BackfillPageSize,
 	}
 
-	totalCycles := 0
 	for {
 		select {
 		case <-ctx.Done():
@@ -60,138 +137,173 @@ func (s *Service) backfillCycles(ctx context.Context, start, end time.Time) erro
 		default:
 		}
 
-		resp, err := s.client.Cycle.List(ctx, params)
+		page, err := s.fetchPaced(ctx, entity, fetch, params)
 		if err != nil {
-			return fmt.Errorf("failed to list cycles: %w", err)
+			return fmt.Errorf("failed to backfill %s: %w", entity, err)
 		}
 
-		if err := s.repo.Cycles.UpsertBatch(ctx, resp.Records); err != nil {
-			return fmt.Errorf("failed to upsert cycles batch: %w", err)
+		progress.Records += page.count
+		if page.oldest != nil && (progress.Watermark == nil || page.oldest.Before(*progress.Watermark)) {
+			progress.Watermark = page.oldest
 		}
-
-		g, gctx := errgroup.WithContext(ctx)
-		g.SetLimit(maxRecoveryConcurrency)
-		for _, cycle := range resp.Records {
-			g.Go(func() error {
-				if err := s.backfillRecoveryForCycle(gctx, cycle.ID); err != nil {
-					s.logger.WarnContext(
-						gctx,
-						"failed to backfill recovery",
-						xslog.CycleID(cycle.ID),
-						xslog.Error(err),
-					)
-				}
-				return nil
-			})
+		if page.nextToken == nil {
+			if start == nil {
+				progress.Exhausted = true
+			} else {
+				progress.Watermark = start
+			}
 		}
-		_ = g.Wait()
 
-		totalCycles += len(resp.Records)
+		if err := s.repo.BackfillProgress.Upsert(ctx, progress); err != nil {
+			return fmt.Errorf("failed to checkpoint %s backfill: %w", entity, err)
+		}
 
-		if len(resp.Records) > 0 {
-			oldest := resp.Records[len(resp.Records)-1].Start
-			if err := s.repo.SyncState.UpdateBackfillWatermark(ctx, oldest); err != nil {
+		// the trends page reads the cycle watermark to decide what to fetch on demand
+		if entity == repository.BackfillEntityCycle && progress.Watermark != nil {
+			if err := s.repo.SyncState.UpdateBackfillWatermark(ctx, *progress.Watermark); err != nil {
 				s.logger.WarnContext(ctx, "failed to update backfill watermark", xslog.Error(err))
 			}
 		}
 
-		if !resp.HasMore() {
+		if page.nextToken == nil {
 			break
 		}
-		params.NextToken = resp.NextToken
+		params.NextToken = page.nextToken
 	}
 
-	s.logger.InfoContext(ctx, "backfilled cycles", xslog.Count(totalCycles))
+	s.logger.InfoContext(ctx, "backfilled",
+		xslog.EntityType(string(entity)),
+		xslog.Count(progress.Records))
 	return nil
 }
 
-func (s *Service) backfillRecoveryForCycle(ctx context.Context, cycleID int64) error {
-	recovery, err := s.client.Cycle.GetRecovery(ctx, cycleID)
-	if err != nil {
-		return fmt.Errorf("failed to get recovery: %w", err)
-	}
-	if err := s.repo.Recoveries.Upsert(ctx, recovery); err != nil {
-		return fmt.Errorf("failed to upsert recovery: %w", err)
+// fetchPaced fetches a page, pausing for Retry-After and retrying instead of
+// failing when the proxy responds 429. Pacing below the limit is left to the
+// client's governor, which holds backfill requests behind interactive ones.
+func (s *Service) fetchPaced(ctx context.Context, entity repository.BackfillEntity, fetch fetchPageFunc, params *whoop.ListParams) (pageResult, error) {
+	for {
+		page, err := fetch(ctx, params)
+
+		var apiErr *whoop.APIError
+		if errors.As(err, &apiErr) && apiErr.IsRateLimited() {
+			s.logger.WarnContext(ctx, "rate limited, pausing backfill",
+				xslog.EntityType(string(entity)),
+				xslog.Backoff(apiErr.RetryAfter))
+			if err := s.pause(ctx, apiErr.RetryAfter); err != nil {
+				return pageResult{}, err
+			}
+			continue
+		}
+		return page, err
 	}
-	return nil
 }
 
-func (s *Service) backfillSleeps(ctx context.Context, start, end time.Time) error {
-	s.logger.InfoContext(ctx, "backfilling sleeps",
-		xslog.Start(start),
-		xslog.End(end))
-
-	params := &whoop.ListParams{
-		Start: &start,
-		End:   &end,
-		Limit: BackfillPageSize,
-	}
+// pause blocks for d, recording when the backfill resumes so progress can report it.
+func (s *Service) pause(ctx context.Context, d time.Duration) error {
+	until := time.Now().Add(d)
+	s.pausedUntil.Store(&until)
+	defer s.pausedUntil.CompareAndSwap(&until, nil)
 
-	totalSleeps := 0
-	for {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("context cancelled: %w", ctx.Err())
-		default:
-		}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
 
-		resp, err := s.client.Sleep.List(ctx, params)
-		if err != nil {
-			return fmt.Errorf("failed to list sleeps: %w", err)
-		}
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context cancelled: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
 
-		if err := s.repo.Sleeps.UpsertBatch(ctx, resp.Records); err != nil {
-			return fmt.Errorf("failed to upsert sleeps batch: %w", err)
-		}
+// reachedHorizon reports whether progress covers everything back to start.
+func reachedHorizon(progress *repository.BackfillProgress, start *time.Time) bool {
+	if progress.Exhausted {
+		return true
+	}
+	if start == nil || progress.Watermark == nil {
+		return false
+	}
+	return !progress.Watermark.After(*start)
+}
 
-		totalSleeps += len(resp.Records)
+func (s *Service) fetchCyclePage(ctx context.Context, params *whoop.ListParams) (pageResult, error) {
+	resp, err := s.client.Cycle.List(ctx, params)
+	if err != nil {
+		return pageResult{}, fmt.Errorf("failed to list cycles: %w", err)
+	}
 
-		if !resp.HasMore() {
-			break
-		}
-		params.NextToken = resp.NextToken
+	if err := s.repo.Cycles.UpsertBatch(ctx, resp.Records); err != nil {
+		return pageResult{}, fmt.Errorf("failed to upsert cycles batch: %w", err)
 	}
 
-	s.logger.InfoContext(ctx, "backfilled sleeps", xslog.Count(totalSleeps))
-	return nil
+	result := pageResult{count: len(resp.Records)}
+	if len(resp.Records) > 0 {
+		result.oldest = &resp.Records[len(resp.Records)-1].Start
+	}
+	if resp.HasMore() {
+		result.nextToken = resp.NextToken
+	}
+	return result, nil
 }
 
-func (s *Service) backfillWorkouts(ctx context.Context, start, end time.Time) error {
-	s.logger.InfoContext(ctx, "backfilling workouts",
-		xslog.Start(start),
-		xslog.End(end))
+func (s *Service) fetchRecoveryPage(ctx context.Context, params *whoop.ListParams) (pageResult, error) {
+	resp, err := s.client.Recovery.List(ctx, params)
+	if err != nil {
+		return pageResult{}, fmt.Errorf("failed to list recoveries: %w", err)
+	}
 
-	params := &whoop.ListParams{
-		Start: &start,
-		End:   &end,
-		Limit: BackfillPageSize,
+	if err := s.repo.Recoveries.UpsertBatch(ctx, resp.Records); err != nil {
+		return pageResult{}, fmt.Errorf("failed to upsert recoveries batch: %w", err)
 	}
 
-	totalWorkouts := 0
-	for {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("context cancelled: %w", ctx.Err())
-		default:
-		}
+	// recoveries have no start of their own; they're created shortly after
+	// the cycle they belong to starts, which is close enough for a watermark
+	result := pageResult{count: len(resp.Records)}
+	if len(resp.Records) > 0 {
+		result.oldest = &resp.Records[len(resp.Records)-1].CreatedAt
+	}
+	if resp.HasMore() {
+		result.nextToken = resp.NextToken
+	}
+	return result, nil
+}
 
-		resp, err := s.client.Workout.List(ctx, params)
-		if err != nil {
-			return fmt.Errorf("failed to list workouts: %w", err)
-		}
+func (s *Service) fetchSleepPage(ctx context.Context, params *whoop.ListParams) (pageResult, error) {
+	resp, err := s.client.Sleep.List(ctx, params)
+	if err != nil {
+		return pageResult{}, fmt.Errorf("failed to list sleeps: %w", err)
+	}
 
-		if err := s.repo.Workouts.UpsertBatch(ctx, resp.Records); err != nil {
-			return fmt.Errorf("failed to upsert workouts batch: %w", err)
-		}
+	if err := s.repo.Sleeps.UpsertBatch(ctx, resp.Records); err != nil {
+		return pageResult{}, fmt.Errorf("failed to upsert sleeps batch: %w", err)
+	}
 
-		totalWorkouts += len(resp.Records)
+	result := pageResult{count: len(resp.Records)}
+	if len(resp.Records) > 0 {
+		result.oldest = &resp.Records[len(resp.Records)-1].Start
+	}
+	if resp.HasMore() {
+		result.nextToken = resp.NextToken
+	}
+	return result, nil
+}
 
-		if !resp.HasMore() {
-			break
-		}
-		params.NextToken = resp.NextToken
+func (s *Service) fetchWorkoutPage(ctx context.Context, params *whoop.ListParams) (pageResult, error) {
+	resp, err := s.client.Workout.List(ctx, params)
+	if err != nil {
+		return pageResult{}, fmt.Errorf("failed to list workouts: %w", err)
 	}
 
-	s.logger.InfoContext(ctx, "backfilled workouts", xslog.Count(totalWorkouts))
-	return nil
+	if err := s.repo.Workouts.UpsertBatch(ctx, resp.Records); err != nil {
+		return pageResult{}, fmt.Errorf("failed to upsert workouts batch: %w", err)
+	}
+
+	result := pageResult{count: len(resp.Records)}
+	if len(resp.Records) > 0 {
+		result.oldest = &resp.Records[len(resp.Records)-1].Start
+	}
+	if resp.HasMore() {
+		result.nextToken = resp.NextToken
+	}
+	return result, nil
 }
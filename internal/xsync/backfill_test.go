@@ -0,0 +1,129 @@
+package xsync
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+// fakeBackfillProgressRepo returns stored for every entity, which is nil for
+// a fresh backfill, and keeps a copy of each checkpoint.
+type fakeBackfillProgressRepo struct {
+	repository.BackfillProgressRepository
+
+	stored      *repository.BackfillProgress
+	checkpoints []repository.BackfillProgress
+}
+
+func (r *fakeBackfillProgressRepo) Get(context.Context, repository.BackfillEntity) (*repository.BackfillProgress, error) {
+	return r.stored, nil
+}
+
+func (r *fakeBackfillProgressRepo) Upsert(_ context.Context, progress *repository.BackfillProgress) error {
+	r.checkpoints = append(r.checkpoints, *progress)
+	return nil
+}
+
+func TestBackfillEntity_RateLimited(t *testing.T) {
+	t.Parallel()
+
+	var (
+		newer    = time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+		older    = newer.AddDate(0, 0, -10)
+		next     = "next"
+		progress = &fakeBackfillProgressRepo{}
+		s        = NewService(nil, &repository.Repository{BackfillProgress: progress}, slog.New(slog.DiscardHandler),
+			WithBackfillHorizon(FullHistory))
+	)
+
+	// the second page is rate limited once before it comes through
+	var (
+		calls  int
+		tokens []*string
+	)
+	fetch := func(_ context.Context, params *whoop.ListParams) (pageResult, error) {
+		calls++
+		tokens = append(tokens, params.NextToken)
+		switch calls {
+		case 1:
+			return pageResult{count: 10, oldest: &newer, nextToken: &next}, nil
+		case 2:
+			if n := len(progress.checkpoints); n != 1 {
+				t.Errorf("%d checkpoints before the second page, want 1", n)
+			}
+			return pageResult{}, &whoop.APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 10 * time.Millisecond}
+		default:
+			if s.pausedUntil.Load() != nil {
+				t.Error("pausedUntil still set after resuming")
+			}
+			return pageResult{count: 4, oldest: &older}, nil
+		}
+	}
+
+	if err := s.backfillEntity(t.Context(), repository.BackfillEntitySleep, fetch); err != nil {
+		t.Fatalf("backfillEntity() error = %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("fetched %d times, want 3 with the retry", calls)
+	}
+	if tokens[2] == nil || *tokens[2] != next {
+		t.Errorf("the retry was sent with token %v, want %q", tokens[2], next)
+	}
+
+	want := []repository.BackfillProgress{
+		{Entity: repository.BackfillEntitySleep, Watermark: &newer, Records: 10},
+		{Entity: repository.BackfillEntitySleep, Watermark: &older, Records: 14, Exhausted: true},
+	}
+	ignoreRangeEnd := func(ps []repository.BackfillProgress) []repository.BackfillProgress {
+		out := make([]repository.BackfillProgress, len(ps))
+		for i, p := range ps {
+			p.RangeEnd = time.Time{}
+			out[i] = p
+		}
+		return out
+	}
+	if diff := cmp.Diff(want, ignoreRangeEnd(progress.checkpoints)); diff != "" {
+		t.Errorf("checkpoints mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPause(t *testing.T) {
+	t.Parallel()
+
+	s := NewService(nil, &repository.Repository{}, slog.New(slog.DiscardHandler))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	before := time.Now()
+	go func() { done <- s.pause(ctx, time.Hour) }()
+
+	var until *time.Time
+	for until == nil {
+		select {
+		case err := <-done:
+			t.Fatalf("pause() returned early: %v", err)
+		case <-time.After(time.Millisecond):
+		}
+		until = s.pausedUntil.Load()
+	}
+	if until.Before(before.Add(time.Hour)) {
+		t.Errorf("pausedUntil = %s, want an hour from %s", until, before)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("pause() error = %v, want context.Canceled", err)
+	}
+	if s.pausedUntil.Load() != nil {
+		t.Error("pausedUntil still set after the pause ended")
+	}
+}
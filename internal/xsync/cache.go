@@ -0,0 +1,67 @@
+package xsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+// CacheFetcher is a DataFetcher that only reads the local cache. It never
+// makes a request, so missing data comes back nil rather than fetched.
+type CacheFetcher struct {
+	repo *repository.Repository
+}
+
+var _ DataFetcher = (*CacheFetcher)(nil)
+
+func NewCacheFetcher(repo *repository.Repository) *CacheFetcher {
+	return &CacheFetcher{repo: repo}
+}
+
+// GetCurrentCycle returns the most recent cached cycle, which may be behind
+// the API until the next sync.
+func (f *CacheFetcher) GetCurrentCycle(ctx context.Context) (*whoop.Cycle, error) {
+	cycles, err := f.repo.Cycles.GetLatest(ctx, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest cycle: %w", err)
+	}
+	if len(cycles) == 0 {
+		return nil, nil
+	}
+	return &cycles[0], nil
+}
+
+func (f *CacheFetcher) GetCycles(ctx context.Context, start, end time.Time) ([]whoop.Cycle, error) {
+	result, err := f.repo.Cycles.GetByDateRange(ctx, start, end, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cycles by date range: %w", err)
+	}
+	return result.Records, nil
+}
+
+func (f *CacheFetcher) GetRecovery(ctx context.Context, cycleID int64) (*whoop.Recovery, error) {
+	recovery, err := f.repo.Recoveries.Get(ctx, cycleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recovery from repo: %w", err)
+	}
+	return recovery, nil
+}
+
+func (f *CacheFetcher) GetSleep(ctx context.Context, cycleID int64) (*whoop.Sleep, error) {
+	sleep, err := f.repo.Sleeps.GetByCycleID(ctx, cycleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sleep from repo: %w", err)
+	}
+	return sleep, nil
+}
+
+func (f *CacheFetcher) GetWorkouts(ctx context.Context, start, end time.Time) ([]whoop.Workout, error) {
+	result, err := f.repo.Workouts.GetByDateRange(ctx, start, end, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workouts by date range: %w", err)
+	}
+	return result.Records, nil
+}
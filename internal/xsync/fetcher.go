@@ -20,8 +20,12 @@ type DataFetcher interface {
 
 	GetCycles(ctx context.Context, start, end time.Time) ([]whoop.Cycle, error)
 
+	// GetRecovery returns the recovery for a cycle.
+	// Cached recoveries that are not yet scored are treated as stale.
 	GetRecovery(ctx context.Context, cycleID int64) (*whoop.Recovery, error)
 
+	// GetSleep returns the sleep for a cycle.
+	// Cached sleeps that are not yet scored are treated as stale.
 	GetSleep(ctx context.Context, cycleID int64) (*whoop.Sleep, error)
 
 	GetWorkouts(ctx context.Context, start, end time.Time) ([]whoop.Workout, error)
@@ -75,16 +79,22 @@ func (f *Fetcher) GetCycles(ctx context.Context, start, end time.Time) ([]whoop.
 }
 
 func (f *Fetcher) GetRecovery(ctx context.Context, cycleID int64) (*whoop.Recovery, error) {
-	recovery, err := f.repo.Recoveries.Get(ctx, cycleID)
+	cached, err := f.repo.Recoveries.Get(ctx, cycleID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get recovery from repo: %w", err)
 	}
-	if recovery != nil {
-		return recovery, nil
+	if cached != nil && cached.ScoreState == whoop.ScoreStateScored {
+		return cached, nil
 	}
 
-	recovery, err = f.client.Cycle.GetRecovery(ctx, cycleID)
+	recovery, err := f.client.Cycle.GetRecovery(ctx, cycleID)
 	if err != nil {
+		if cached != nil {
+			f.logger.WarnContext(ctx, "failed to revalidate recovery, using cache",
+				xslog.CycleID(cycleID),
+				xslog.Error(err))
+			return cached, nil
+		}
 		return nil, fmt.Errorf("failed to get recovery from api: %w", err)
 	}
 
@@ -96,16 +106,22 @@ func (f *Fetcher) GetRecovery(ctx context.Context, cycleID int64) (*whoop.Recove
 }
 
 func (f *Fetcher) GetSleep(ctx context.Context, cycleID int64) (*whoop.Sleep, error) {
-	sleep, err := f.repo.Sleeps.GetByCycleID(ctx, cycleID)
+	cached, err := f.repo.Sleeps.GetByCycleID(ctx, cycleID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sleep from repo: %w", err)
 	}
-	if sleep != nil {
-		return sleep, nil
+	if cached != nil && cached.ScoreState == whoop.ScoreStateScored {
+		return cached, nil
 	}
 
-	sleep, err = f.client.Cycle.GetSleep(ctx, cycleID)
+	sleep, err := f.client.Cycle.GetSleep(ctx, cycleID)
 	if err != nil {
+		if cached != nil {
+			f.logger.WarnContext(ctx, "failed to revalidate sleep, using cache",
+				xslog.CycleID(cycleID),
+				xslog.Error(err))
+			return cached, nil
+		}
 		return nil, fmt.Errorf("failed to get sleep from api: %w", err)
 	}
 
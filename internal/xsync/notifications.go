@@ -30,6 +30,14 @@ type ProcessResult struct {
 	Action     storage.Action
 	EntityID   string
 	Success    bool
+
+	// Created is set when an update is for an entity that wasn't cached yet.
+	Created bool
+	// The entity as cached by a successful update. Only the one matching
+	// EntityType is set, and none are for deletes.
+	Workout  *whoop.Workout
+	Sleep    *whoop.Sleep
+	Recovery *whoop.Recovery
 }
 
 func (p *NotificationProcessor) Process(ctx context.Context, n storage.Notification) ProcessResult {
@@ -39,10 +47,12 @@ func (p *NotificationProcessor) Process(ctx context.Context, n storage.Notificat
 		EntityID:   n.EntityID,
 	}
 
+	ctx = whoop.WithPriority(ctx, whoop.PriorityNotification)
+
 	var err error
 	switch n.Action {
 	case storage.ActionUpdated:
-		err = p.handleUpdate(ctx, n)
+		err = p.handleUpdate(ctx, n, &result)
 	case storage.ActionDeleted:
 		err = p.handleDelete(ctx, n)
 	default:
@@ -72,14 +82,14 @@ func (p *NotificationProcessor) Process(ctx context.Context, n storage.Notificat
 	return result
 }
 
-func (p *NotificationProcessor) handleUpdate(ctx context.Context, n storage.Notification) error {
+func (p *NotificationProcessor) handleUpdate(ctx context.Context, n storage.Notification, result *ProcessResult) error {
 	switch n.EntityType {
 	case storage.EntityTypeWorkout:
-		return p.fetchAndCacheWorkout(ctx, n.EntityID)
+		return p.fetchAndCacheWorkout(ctx, n.EntityID, result)
 	case storage.EntityTypeSleep:
-		return p.fetchAndCacheSleep(ctx, n.EntityID)
+		return p.fetchAndCacheSleep(ctx, n.EntityID, result)
 	case storage.EntityTypeRecovery:
-		return p.fetchAndCacheRecoveryBySleepID(ctx, n.EntityID)
+		return p.fetchAndCacheRecoveryBySleepID(ctx, n.EntityID, result)
 	default:
 		return nil
 	}
@@ -113,7 +123,12 @@ func (p *NotificationProcessor) handleDelete(ctx context.Context, n storage.Noti
 	}
 }
 
-func (p *NotificationProcessor) fetchAndCacheWorkout(ctx context.Context, id string) error {
+func (p *NotificationProcessor) fetchAndCacheWorkout(ctx context.Context, id string, result *ProcessResult) error {
+	cached, err := p.repo.Workouts.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get cached workout: %w", err)
+	}
+
 	workout, err := p.client.Workout.Get(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to get workout: %w", err)
@@ -121,10 +136,18 @@ func (p *NotificationProcessor) fetchAndCacheWorkout(ctx context.Context, id str
 	if err := p.repo.Workouts.Upsert(ctx, workout); err != nil {
 		return fmt.Errorf("failed to upsert workout: %w", err)
 	}
+
+	result.Created = cached == nil
+	result.Workout = workout
 	return nil
 }
 
-func (p *NotificationProcessor) fetchAndCacheSleep(ctx context.Context, id string) error {
+func (p *NotificationProcessor) fetchAndCacheSleep(ctx context.Context, id string, result *ProcessResult) error {
+	cached, err := p.repo.Sleeps.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get cached sleep: %w", err)
+	}
+
 	sleep, err := p.client.Sleep.Get(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to get sleep: %w", err)
@@ -132,10 +155,13 @@ func (p *NotificationProcessor) fetchAndCacheSleep(ctx context.Context, id strin
 	if err := p.repo.Sleeps.Upsert(ctx, sleep); err != nil {
 		return fmt.Errorf("failed to upsert sleep: %w", err)
 	}
+
+	result.Created = cached == nil
+	result.Sleep = sleep
 	return nil
 }
 
-func (p *NotificationProcessor) fetchAndCacheRecoveryBySleepID(ctx context.Context, sleepID string) error {
+func (p *NotificationProcessor) fetchAndCacheRecoveryBySleepID(ctx context.Context, sleepID string, result *ProcessResult) error {
 	sleep, err := p.repo.Sleeps.Get(ctx, sleepID)
 	if err != nil {
 		return fmt.Errorf("failed to get sleep: %w", err)
@@ -150,6 +176,11 @@ func (p *NotificationProcessor) fetchAndCacheRecoveryBySleepID(ctx context.Conte
 		}
 	}
 
+	cached, err := p.repo.Recoveries.Get(ctx, sleep.CycleID)
+	if err != nil {
+		return fmt.Errorf("failed to get cached recovery: %w", err)
+	}
+
 	recovery, err := p.client.Cycle.GetRecovery(ctx, sleep.CycleID)
 	if err != nil {
 		return fmt.Errorf("failed to get recovery: %w", err)
@@ -157,5 +188,8 @@ func (p *NotificationProcessor) fetchAndCacheRecoveryBySleepID(ctx context.Conte
 	if err := p.repo.Recoveries.Upsert(ctx, recovery); err != nil {
 		return fmt.Errorf("failed to upsert recovery: %w", err)
 	}
+
+	result.Created = cached == nil
+	result.Recovery = recovery
 	return nil
 }
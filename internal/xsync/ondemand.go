@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
 	"github.com/garrettladley/thoop/internal/xslog"
 	"golang.org/x/sync/errgroup"
 )
@@ -25,11 +26,55 @@ func (s *Service) fetchHistorical(ctx context.Context, start, end time.Time) err
 		return fmt.Errorf("%w", err)
 	}
 
+	s.extendWatermark(ctx, start, end)
+
 	s.logger.InfoContext(ctx, "historical data fetch complete")
 	return nil
 }
 
-// fetchHistoricalCycles fetches cycles for a date range with pagination.
+// extendWatermark moves the backfill watermarks back to start when the fetched
+// range is contiguous with them, so the same range isn't fetched again.
+func (s *Service) extendWatermark(ctx context.Context, start, end time.Time) {
+	state, err := s.repo.SyncState.Get(ctx)
+	if err != nil {
+		s.logger.WarnContext(ctx, "failed to get sync state", xslog.Error(err))
+		return
+	}
+
+	if contiguous(state.BackfillWatermark, start, end) {
+		if err := s.repo.SyncState.UpdateBackfillWatermark(ctx, start); err != nil {
+			s.logger.WarnContext(ctx, "failed to update backfill watermark", xslog.Error(err))
+		}
+	}
+
+	for _, entity := range repository.BackfillEntities {
+		progress, err := s.repo.BackfillProgress.Get(ctx, entity)
+		if err != nil {
+			s.logger.WarnContext(ctx, "failed to get backfill progress",
+				xslog.EntityType(string(entity)),
+				xslog.Error(err))
+			continue
+		}
+		if progress == nil || progress.Exhausted || !contiguous(progress.Watermark, start, end) {
+			continue
+		}
+
+		progress.Watermark = &start
+		if err := s.repo.BackfillProgress.Upsert(ctx, progress); err != nil {
+			s.logger.WarnContext(ctx, "failed to update backfill progress",
+				xslog.EntityType(string(entity)),
+				xslog.Error(err))
+		}
+	}
+}
+
+// contiguous reports whether [start, end] extends back past watermark without a gap.
+func contiguous(watermark *time.Time, start, end time.Time) bool {
+	return watermark != nil && start.Before(*watermark) && !end.Before(*watermark)
+}
+
+// fetchHistoricalCycles fetches cycles for a date range with pagination,
+// then their recoveries in bulk.
 func (s *Service) fetchHistoricalCycles(ctx context.Context, start, end time.Time) error {
 	params := &whoop.ListParams{
 		Start: &start,
@@ -37,6 +82,8 @@ func (s *Service) fetchHistoricalCycles(ctx context.Context, start, end time.Tim
 		Limit: BackfillPageSize,
 	}
 
+	var cycles []whoop.Cycle
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -53,27 +100,7 @@ func (s *Service) fetchHistoricalCycles(ctx context.Context, start, end time.Tim
 			return fmt.Errorf("%w", err)
 		}
 
-		// Fetch recoveries for each cycle in parallel
-		rg, rgctx := errgroup.WithContext(ctx)
-		rg.SetLimit(maxRecoveryConcurrency)
-		for _, cycle := range resp.Records {
-			rg.Go(func() error {
-				recovery, err := s.client.Cycle.GetRecovery(rgctx, cycle.ID)
-				if err != nil {
-					s.logger.WarnContext(rgctx, "failed to fetch recovery",
-						xslog.CycleID(cycle.ID),
-						xslog.Error(err))
-					return nil
-				}
-				if err := s.repo.Recoveries.Upsert(rgctx, recovery); err != nil {
-					s.logger.WarnContext(rgctx, "failed to save recovery",
-						xslog.CycleID(cycle.ID),
-						xslog.Error(err))
-				}
-				return nil
-			})
-		}
-		_ = rg.Wait()
+		cycles = append(cycles, resp.Records...)
 
 		if !resp.HasMore() {
 			break
@@ -81,7 +108,7 @@ func (s *Service) fetchHistoricalCycles(ctx context.Context, start, end time.Tim
 		params.NextToken = resp.NextToken
 	}
 
-	return nil
+	return s.fetchRecoveriesForCycles(ctx, cycles, start, end)
 }
 
 // fetchHistoricalSleeps fetches sleeps for a date range with pagination.
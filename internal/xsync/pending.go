@@ -0,0 +1,214 @@
+package xsync
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+	"github.com/garrettladley/thoop/internal/xslog"
+)
+
+const (
+	pendingBaseBackoff = 5 * time.Minute
+	pendingMaxBackoff  = 6 * time.Hour
+)
+
+// PendingResult summarises a reconciliation pass.
+type PendingResult struct {
+	// Scored is the number of records WHOOP has finished scoring.
+	Scored int
+	// Unscorable is the number of records WHOOP gave up on; they're no longer polled.
+	Unscorable int
+	// Pending is the number of records still waiting on a score.
+	Pending int
+}
+
+// Resolved returns the number of records that are no longer pending.
+func (r PendingResult) Resolved() int {
+	return r.Scored + r.Unscorable
+}
+
+// PendingReconciler re-fetches records stored with a pending score until
+// WHOOP scores them or marks them unscorable. Records that stay pending are
+// retried with exponential backoff, tracked for the reconciler's lifetime.
+type PendingReconciler struct {
+	client *whoop.Client
+	repo   *repository.Repository
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu       sync.Mutex
+	attempts map[string]pendingAttempt
+}
+
+type pendingAttempt struct {
+	count int
+	next  time.Time
+}
+
+func NewPendingReconciler(client *whoop.Client, repo *repository.Repository, logger *slog.Logger) *PendingReconciler {
+	return &PendingReconciler{
+		client:   client,
+		repo:     repo,
+		logger:   logger,
+		now:      time.Now,
+		attempts: make(map[string]pendingAttempt),
+	}
+}
+
+// Reconcile re-fetches every pending cycle, recovery, sleep and workout that
+// is due and stores whatever WHOOP returns.
+func (r *PendingReconciler) Reconcile(ctx context.Context) (PendingResult, error) {
+	ctx = whoop.WithPriority(ctx, whoop.PriorityNotification)
+
+	var result PendingResult
+
+	cycles, err := r.repo.Cycles.GetPending(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to get pending cycles: %w", err)
+	}
+	for _, cycle := range cycles {
+		reconcilePending(ctx, r, &result, "cycle", strconv.FormatInt(cycle.ID, 10),
+			func(ctx context.Context) (*whoop.Cycle, error) { return r.client.Cycle.Get(ctx, cycle.ID) },
+			func(c *whoop.Cycle) whoop.ScoreState { return c.ScoreState },
+			r.repo.Cycles.Upsert,
+		)
+	}
+
+	recoveries, err := r.repo.Recoveries.GetPending(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to get pending recoveries: %w", err)
+	}
+	for _, recovery := range recoveries {
+		reconcilePending(ctx, r, &result, "recovery", strconv.FormatInt(recovery.CycleID, 10),
+			func(ctx context.Context) (*whoop.Recovery, error) {
+				return r.client.Cycle.GetRecovery(ctx, recovery.CycleID)
+			},
+			func(rec *whoop.Recovery) whoop.ScoreState { return rec.ScoreState },
+			r.repo.Recoveries.Upsert,
+		)
+	}
+
+	sleeps, err := r.repo.Sleeps.GetPending(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to get pending sleeps: %w", err)
+	}
+	for _, sleep := range sleeps {
+		reconcilePending(ctx, r, &result, "sleep", sleep.ID,
+			func(ctx context.Context) (*whoop.Sleep, error) { return r.client.Sleep.Get(ctx, sleep.ID) },
+			func(s *whoop.Sleep) whoop.ScoreState { return s.ScoreState },
+			r.repo.Sleeps.Upsert,
+		)
+	}
+
+	workouts, err := r.repo.Workouts.GetPending(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to get pending workouts: %w", err)
+	}
+	for _, workout := range workouts {
+		reconcilePending(ctx, r, &result, "workout", workout.ID,
+			func(ctx context.Context) (*whoop.Workout, error) { return r.client.Workout.Get(ctx, workout.ID) },
+			func(w *whoop.Workout) whoop.ScoreState { return w.ScoreState },
+			r.repo.Workouts.Upsert,
+		)
+	}
+
+	if result.Resolved() > 0 {
+		r.logger.InfoContext(ctx, "reconciled pending scores",
+			xslog.Count(result.Resolved()))
+	}
+	return result, nil
+}
+
+// reconcilePending re-fetches a single pending record if it's due, storing it
+// once it has left the pending state.
+func reconcilePending[T any](
+	ctx context.Context,
+	r *PendingReconciler,
+	result *PendingResult,
+	entity, id string,
+	fetch func(ctx context.Context) (*T, error),
+	state func(*T) whoop.ScoreState,
+	upsert func(ctx context.Context, record *T) error,
+) {
+	key := entity + ":" + id
+	if !r.due(key) {
+		result.Pending++
+		return
+	}
+
+	record, err := fetch(ctx)
+	if err != nil {
+		r.logger.WarnContext(ctx, "failed to re-fetch pending record",
+			xslog.EntityType(entity),
+			xslog.EntityID(id),
+			xslog.Error(err))
+		r.backoff(key)
+		result.Pending++
+		return
+	}
+
+	var resolved *int
+	switch state(record) {
+	case whoop.ScoreStateScored:
+		resolved = &result.Scored
+	case whoop.ScoreStateUnscorable:
+		resolved = &result.Unscorable
+	default:
+		r.backoff(key)
+		result.Pending++
+		return
+	}
+
+	// the cache keeps the pending record until the upsert lands, so it's
+	// retried like any other record that's still pending
+	if err := upsert(ctx, record); err != nil {
+		r.logger.WarnContext(ctx, "failed to save reconciled record",
+			xslog.EntityType(entity),
+			xslog.EntityID(id),
+			xslog.Error(err))
+		r.backoff(key)
+		result.Pending++
+		return
+	}
+	r.forget(key)
+	*resolved++
+}
+
+func (r *PendingReconciler) due(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt, ok := r.attempts[key]
+	return !ok || !r.now().Before(attempt.next)
+}
+
+func (r *PendingReconciler) backoff(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt := r.attempts[key]
+	attempt.next = r.now().Add(pendingBackoff(attempt.count))
+	attempt.count++
+	r.attempts[key] = attempt
+}
+
+func (r *PendingReconciler) forget(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+}
+
+// pendingBackoff doubles the wait after every attempt that's still pending.
+func pendingBackoff(attempts int) time.Duration {
+	if attempts >= 16 {
+		return pendingMaxBackoff
+	}
+	return min(pendingBaseBackoff<<attempts, pendingMaxBackoff)
+}
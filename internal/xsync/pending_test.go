@@ -0,0 +1,201 @@
+package xsync
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+func TestPendingBackoff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: 5 * time.Minute},
+		{attempts: 1, want: 10 * time.Minute},
+		{attempts: 3, want: 40 * time.Minute},
+		{attempts: 7, want: 6 * time.Hour},
+		{attempts: 100, want: 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := pendingBackoff(tt.attempts); got != tt.want {
+			t.Errorf("pendingBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestPendingReconcilerDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := NewPendingReconciler(nil, nil, nil)
+	r.now = func() time.Time { return now }
+
+	const key = "sleep:abc"
+	if !r.due(key) {
+		t.Fatal("due() = false for a record never attempted")
+	}
+
+	r.backoff(key)
+	if r.due(key) {
+		t.Error("due() = true straight after backing off")
+	}
+
+	now = now.Add(pendingBaseBackoff)
+	if !r.due(key) {
+		t.Error("due() = false once the backoff elapsed")
+	}
+
+	r.backoff(key)
+	now = now.Add(pendingBaseBackoff)
+	if r.due(key) {
+		t.Error("due() = true before the doubled backoff elapsed")
+	}
+
+	r.forget(key)
+	if !r.due(key) {
+		t.Error("due() = false after forgetting the record")
+	}
+}
+
+type fakeCycleRepo struct {
+	repository.CycleRepository
+}
+
+func (fakeCycleRepo) GetPending(context.Context) ([]whoop.Cycle, error) { return nil, nil }
+
+type fakeRecoveryRepo struct {
+	repository.RecoveryRepository
+}
+
+func (fakeRecoveryRepo) GetPending(context.Context) ([]whoop.Recovery, error) { return nil, nil }
+
+type fakeSleepRepo struct {
+	repository.SleepRepository
+
+	pending  []whoop.Sleep
+	upserted []string
+}
+
+func (r *fakeSleepRepo) GetPending(context.Context) ([]whoop.Sleep, error) { return r.pending, nil }
+
+func (r *fakeSleepRepo) Upsert(_ context.Context, sleep *whoop.Sleep) error {
+	r.upserted = append(r.upserted, sleep.ID)
+	return nil
+}
+
+type fakeWorkoutRepo struct {
+	repository.WorkoutRepository
+
+	pending []whoop.Workout
+}
+
+func (r *fakeWorkoutRepo) GetPending(context.Context) ([]whoop.Workout, error) { return r.pending, nil }
+
+func (*fakeWorkoutRepo) Upsert(context.Context, *whoop.Workout) error {
+	return errors.New("database is locked")
+}
+
+func TestPendingReconcilerReconcile(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		calls = map[string]int{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		calls[req.URL.Path]++
+		mu.Unlock()
+
+		switch req.URL.Path {
+		case "/v2/activity/sleep/scored":
+			_, _ = w.Write([]byte(`{"id":"scored","score_state":"SCORED"}`))
+		case "/v2/activity/sleep/unscorable":
+			_, _ = w.Write([]byte(`{"id":"unscorable","score_state":"UNSCORABLE"}`))
+		case "/v2/activity/sleep/pending":
+			_, _ = w.Write([]byte(`{"id":"pending","score_state":"PENDING_SCORE"}`))
+		case "/v2/activity/workout/unsaved":
+			_, _ = w.Write([]byte(`{"id":"unsaved","score_state":"SCORED"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message":"internal error"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	var (
+		tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+		client      = whoop.New(tokenSource, whoop.WithProxyURL(srv.URL))
+		sleeps      = &fakeSleepRepo{pending: []whoop.Sleep{
+			{ID: "scored"}, {ID: "unscorable"}, {ID: "pending"}, {ID: "broken"},
+		}}
+		repo = &repository.Repository{
+			Cycles:     fakeCycleRepo{},
+			Recoveries: fakeRecoveryRepo{},
+			Sleeps:     sleeps,
+			Workouts:   &fakeWorkoutRepo{pending: []whoop.Workout{{ID: "unsaved"}}},
+		}
+		logger = slog.New(slog.DiscardHandler)
+		now    = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	r := NewPendingReconciler(client, repo, logger)
+	r.now = func() time.Time { return now }
+
+	result, err := r.Reconcile(t.Context())
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	want := PendingResult{Scored: 1, Unscorable: 1, Pending: 3}
+	if result != want {
+		t.Errorf("Reconcile() = %+v, want %+v", result, want)
+	}
+	if diff := cmp.Diff([]string{"scored", "unscorable"}, sleeps.upserted); diff != "" {
+		t.Errorf("upserted sleeps mismatch (-want +got):\n%s", diff)
+	}
+
+	for key, wantDue := range map[string]bool{
+		"sleep:scored":     true,
+		"sleep:unscorable": true,
+		"sleep:pending":    false,
+		"sleep:broken":     false,
+		"workout:unsaved":  false,
+	} {
+		if got := r.due(key); got != wantDue {
+			t.Errorf("due(%q) = %v, want %v", key, got, wantDue)
+		}
+	}
+
+	// records backed off aren't fetched again until their backoff elapses
+	sleeps.pending = []whoop.Sleep{{ID: "pending"}, {ID: "broken"}}
+	if result, err = r.Reconcile(t.Context()); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if want := (PendingResult{Pending: 3}); result != want {
+		t.Errorf("Reconcile() while backed off = %+v, want %+v", result, want)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, path := range []string{"/v2/activity/sleep/pending", "/v2/activity/sleep/broken", "/v2/activity/workout/unsaved"} {
+		if calls[path] != 1 {
+			t.Errorf("%s requested %d times, want 1", path, calls[path])
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package xsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+// recordsPerDay is assumed when there's no history yet to estimate density from.
+const recordsPerDay = 1
+
+// EntityProgress describes how far an entity type has been backfilled.
+type EntityProgress struct {
+	Entity   repository.BackfillEntity
+	Records  int
+	Oldest   *time.Time
+	Complete bool
+	// RequestsLeft estimates the API requests needed to reach the horizon.
+	// It is nil when the horizon is the full account history.
+	RequestsLeft *int
+}
+
+// BackfillProgress describes the backfill across every entity type.
+type BackfillProgress struct {
+	Horizon  time.Duration
+	Running  bool
+	Entities []EntityProgress
+	// PausedUntil is set while the backfill waits out the rate limit.
+	PausedUntil *time.Time
+}
+
+func (p BackfillProgress) Complete() bool {
+	for _, e := range p.Entities {
+		if !e.Complete {
+			return false
+		}
+	}
+	return true
+}
+
+// Records returns the total number of records fetched.
+func (p BackfillProgress) Records() int {
+	var total int
+	for _, e := range p.Entities {
+		total += e.Records
+	}
+	return total
+}
+
+// Oldest returns the most recent of the entity watermarks, which is the date
+// every entity type has been backfilled to.
+func (p BackfillProgress) Oldest() *time.Time {
+	var oldest *time.Time
+	for _, e := range p.Entities {
+		if e.Oldest == nil {
+			return nil
+		}
+		if oldest == nil || e.Oldest.After(*oldest) {
+			oldest = e.Oldest
+		}
+	}
+	return oldest
+}
+
+// RequestsLeft returns the estimated requests left across every entity type.
+// ok is false when any estimate is unknown.
+func (p BackfillProgress) RequestsLeft() (int, bool) {
+	var total int
+	for _, e := range p.Entities {
+		if e.Complete {
+			continue
+		}
+		if e.RequestsLeft == nil {
+			return 0, false
+		}
+		total += *e.RequestsLeft
+	}
+	return total, true
+}
+
+func (s *Service) BackfillProgress(ctx context.Context) (*BackfillProgress, error) {
+	var (
+		now   = time.Now()
+		start = s.horizonStart(now)
+	)
+
+	progress := &BackfillProgress{
+		Horizon:  s.horizon,
+		Running:  s.backfilling.Load(),
+		Entities: make([]EntityProgress, 0, len(repository.BackfillEntities)),
+	}
+	if until := s.pausedUntil.Load(); until != nil {
+		progress.PausedUntil = until
+	}
+
+	for _, entity := range repository.BackfillEntities {
+		stored, err := s.repo.BackfillProgress.Get(ctx, entity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s backfill progress: %w", entity, err)
+		}
+		if stored == nil {
+			stored = &repository.BackfillProgress{Entity: entity, RangeEnd: now}
+		}
+
+		e := EntityProgress{
+			Entity:   entity,
+			Records:  stored.Records,
+			Oldest:   stored.Watermark,
+			Complete: reachedHorizon(stored, start),
+		}
+		if !e.Complete && start != nil {
+			left := estimateRequestsLeft(stored, *start)
+			e.RequestsLeft = &left
+		}
+		progress.Entities = append(progress.Entities, e)
+	}
+
+	return progress, nil
+}
+
+// estimateRequestsLeft extrapolates the record density seen so far over the
+// range still to fetch.
+func estimateRequestsLeft(progress *repository.BackfillProgress, start time.Time) int {
+	oldest := progress.RangeEnd
+	if progress.Watermark != nil {
+		oldest = *progress.Watermark
+	}
+
+	remaining := oldest.Sub(start)
+	if remaining <= 0 {
+		return 0
+	}
+
+	var (
+		day     = 24 * time.Hour
+		covered = progress.RangeEnd.Sub(oldest)
+		density = float64(recordsPerDay)
+	)
+	if progress.Records > 0 && covered >= day {
+		density = float64(progress.Records) / (float64(covered) / float64(day))
+	}
+
+	records := int(density*float64(remaining)/float64(day) + 0.5)
+	requests := (records + BackfillPageSize - 1) / BackfillPageSize
+	return max(requests, 1)
+}
@@ -0,0 +1,94 @@
+package xsync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+func TestReachedHorizon(t *testing.T) {
+	t.Parallel()
+
+	var (
+		horizon = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		before  = horizon.Add(-time.Hour)
+		after   = horizon.Add(time.Hour)
+	)
+
+	tests := []struct {
+		name     string
+		progress repository.BackfillProgress
+		start    *time.Time
+		want     bool
+	}{
+		{"no watermark", repository.BackfillProgress{}, &horizon, false},
+		{"watermark after horizon", repository.BackfillProgress{Watermark: &after}, &horizon, false},
+		{"watermark at horizon", repository.BackfillProgress{Watermark: &horizon}, &horizon, true},
+		{"watermark before horizon", repository.BackfillProgress{Watermark: &before}, &horizon, true},
+		{"full history not exhausted", repository.BackfillProgress{Watermark: &before}, nil, false},
+		{"full history exhausted", repository.BackfillProgress{Watermark: &before, Exhausted: true}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := reachedHorizon(&tt.progress, tt.start); got != tt.want {
+				t.Errorf("reachedHorizon() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateRequestsLeft(t *testing.T) {
+	t.Parallel()
+
+	var (
+		day      = 24 * time.Hour
+		rangeEnd = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		tenDays  = rangeEnd.Add(-10 * day)
+	)
+
+	tests := []struct {
+		name     string
+		progress repository.BackfillProgress
+		start    time.Time
+		want     int
+	}{
+		{
+			name:     "no history assumes one record a day",
+			progress: repository.BackfillProgress{Entity: repository.BackfillEntitySleep, RangeEnd: rangeEnd},
+			start:    rangeEnd.Add(-30 * day),
+			want:     3,
+		},
+		{
+			name:     "extrapolates observed density",
+			progress: repository.BackfillProgress{Entity: repository.BackfillEntityWorkout, RangeEnd: rangeEnd, Watermark: &tenDays, Records: 20},
+			start:    tenDays.Add(-20 * day),
+			want:     4,
+		},
+		{
+			name:     "cycles cost only their pages",
+			progress: repository.BackfillProgress{Entity: repository.BackfillEntityCycle, RangeEnd: rangeEnd, Watermark: &tenDays, Records: 10},
+			start:    tenDays.Add(-20 * day),
+			want:     2,
+		},
+		{
+			name:     "past the horizon",
+			progress: repository.BackfillProgress{Entity: repository.BackfillEntitySleep, RangeEnd: rangeEnd, Watermark: &tenDays, Records: 10},
+			start:    rangeEnd,
+			want:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := estimateRequestsLeft(&tt.progress, tt.start); got != tt.want {
+				t.Errorf("estimateRequestsLeft() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package xsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+	"github.com/garrettladley/thoop/internal/xslog"
+	"golang.org/x/sync/errgroup"
+)
+
+// recoveryLag pads the end of a recovery listing, since a recovery is created
+// when its cycle's sleep ends rather than when the cycle starts.
+const recoveryLag = 24 * time.Hour
+
+// fetchRecoveriesForCycles stores the recoveries for cycles that started within
+// [start, end]. Recoveries are listed in bulk and joined to the cycles by
+// CycleID; only cycles the listing and the cache both miss are fetched one by one.
+func (s *Service) fetchRecoveriesForCycles(ctx context.Context, cycles []whoop.Cycle, start, end time.Time) error {
+	if len(cycles) == 0 {
+		return nil
+	}
+
+	found, err := s.listRecoveries(ctx, start, end.Add(recoveryLag))
+	if err != nil {
+		return err
+	}
+
+	return s.fillRecoveryGaps(ctx, cycles, found)
+}
+
+// listRecoveries pages through every recovery in [start, end], stores them,
+// and returns the set of cycle IDs they belong to.
+func (s *Service) listRecoveries(ctx context.Context, start, end time.Time) (map[int64]struct{}, error) {
+	params := &whoop.ListParams{
+		Start: &start,
+		End:   &end,
+		Limit: BackfillPageSize,
+	}
+
+	found := make(map[int64]struct{})
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w", ctx.Err())
+		default:
+		}
+
+		resp, err := s.client.Recovery.List(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list recoveries: %w", err)
+		}
+
+		if err := s.repo.Recoveries.UpsertBatch(ctx, resp.Records); err != nil {
+			return nil, fmt.Errorf("failed to upsert recoveries batch: %w", err)
+		}
+		for _, recovery := range resp.Records {
+			found[recovery.CycleID] = struct{}{}
+		}
+
+		if !resp.HasMore() {
+			return found, nil
+		}
+		params.NextToken = resp.NextToken
+	}
+}
+
+// fillRecoveryGaps fetches the recovery of each cycle that isn't in found or
+// already cached. found may be nil. A cycle may legitimately have no recovery,
+// so failures are logged rather than returned.
+func (s *Service) fillRecoveryGaps(ctx context.Context, cycles []whoop.Cycle, found map[int64]struct{}) error {
+	ids := make([]int64, 0, len(cycles))
+	for _, cycle := range cycles {
+		if _, ok := found[cycle.ID]; !ok {
+			ids = append(ids, cycle.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	cached, err := s.repo.Recoveries.GetByCycleIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get cached recoveries: %w", err)
+	}
+	have := make(map[int64]struct{}, len(cached))
+	for _, recovery := range cached {
+		have[recovery.CycleID] = struct{}{}
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxRecoveryConcurrency)
+	for _, id := range ids {
+		if _, ok := have[id]; ok {
+			continue
+		}
+		g.Go(func() error {
+			recovery, err := s.client.Cycle.GetRecovery(gctx, id)
+			if err != nil {
+				var apiErr *whoop.APIError
+				if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+					s.logger.DebugContext(gctx, "cycle has no recovery", xslog.CycleID(id))
+					return nil
+				}
+				s.logger.WarnContext(gctx, "failed to fetch recovery",
+					xslog.CycleID(id),
+					xslog.Error(err))
+				return nil
+			}
+			if err := s.repo.Recoveries.Upsert(gctx, recovery); err != nil {
+				s.logger.WarnContext(gctx, "failed to save recovery",
+					xslog.CycleID(id),
+					xslog.Error(err))
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return nil
+}
+
+// fillBackfilledRecoveryGaps fills recovery gaps for the cycles a backfill run
+// covered, from the cycle watermark before the run back to the one after it.
+func (s *Service) fillBackfilledRecoveryGaps(ctx context.Context, before *repository.BackfillProgress) error {
+	end := time.Now()
+	if before != nil {
+		end = before.RangeEnd
+		if before.Watermark != nil {
+			end = *before.Watermark
+		}
+	}
+
+	after, err := s.repo.BackfillProgress.Get(ctx, repository.BackfillEntityCycle)
+	if err != nil {
+		return fmt.Errorf("failed to get cycle backfill progress: %w", err)
+	}
+	if after == nil || after.Watermark == nil || !after.Watermark.Before(end) {
+		return nil
+	}
+
+	cycles, err := repository.Collect(ctx, func(ctx context.Context, cursor *repository.CursorParams) (*repository.CursorResult[whoop.Cycle], error) {
+		return s.repo.Cycles.GetByDateRange(ctx, *after.Watermark, end, cursor)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get backfilled cycles: %w", err)
+	}
+
+	// the recovery backfill has already listed this range, so anything not
+	// cached by now is a gap
+	return s.fillRecoveryGaps(ctx, cycles, nil)
+}
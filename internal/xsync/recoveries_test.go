@@ -0,0 +1,229 @@
+package xsync
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/garrettladley/thoop/internal/client/whoop"
+	"github.com/garrettladley/thoop/internal/repository"
+)
+
+// recoveryServer lists a recovery for each cycle ID in pages, a page at a
+// time, and serves the per-cycle recoveries in byCycle. Other cycles have no
+// recovery.
+type recoveryServer struct {
+	pages   [][]int64
+	byCycle []int64
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func (s *recoveryServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, req.URL.Path)
+	s.mu.Unlock()
+
+	if req.URL.Path == "/v2/recovery" {
+		page := 0
+		if token := req.URL.Query().Get("nextToken"); token != "" {
+			_, _ = fmt.Sscan(token, &page)
+		}
+		next := ""
+		if page+1 < len(s.pages) {
+			next = fmt.Sprintf(`,"next_token":"%d"`, page+1)
+		}
+		records := make([]string, len(s.pages[page]))
+		for i, id := range s.pages[page] {
+			records[i] = fmt.Sprintf(`{"cycle_id":%d}`, id)
+		}
+		_, _ = fmt.Fprintf(w, `{"records":[%s]%s}`, strings.Join(records, ","), next)
+		return
+	}
+
+	var id int64
+	if _, err := fmt.Sscanf(req.URL.Path, "/v2/cycle/%d/recovery", &id); err == nil && slices.Contains(s.byCycle, id) {
+		_, _ = fmt.Fprintf(w, `{"cycle_id":%d}`, id)
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte(`{"message":"not found"}`))
+}
+
+func (s *recoveryServer) requested() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// perCycle returns the cycles whose recovery was fetched on its own.
+func (s *recoveryServer) perCycle() []int64 {
+	var ids []int64
+	for _, path := range s.requested() {
+		var id int64
+		if _, err := fmt.Sscanf(path, "/v2/cycle/%d/recovery", &id); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// cachedRecoveryRepo has the recoveries for cached and records what's stored.
+type cachedRecoveryRepo struct {
+	repository.RecoveryRepository
+
+	cached []int64
+
+	mu       sync.Mutex
+	batched  []int64
+	upserted []int64
+}
+
+func (r *cachedRecoveryRepo) GetByCycleIDs(_ context.Context, ids []int64) ([]whoop.Recovery, error) {
+	var recoveries []whoop.Recovery
+	for _, id := range ids {
+		if slices.Contains(r.cached, id) {
+			recoveries = append(recoveries, whoop.Recovery{CycleID: id})
+		}
+	}
+	return recoveries, nil
+}
+
+func (r *cachedRecoveryRepo) UpsertBatch(_ context.Context, recoveries []whoop.Recovery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, recovery := range recoveries {
+		r.batched = append(r.batched, recovery.CycleID)
+	}
+	return nil
+}
+
+func (r *cachedRecoveryRepo) Upsert(_ context.Context, recovery *whoop.Recovery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.upserted = append(r.upserted, recovery.CycleID)
+	return nil
+}
+
+// rangeCycleRepo returns its cycles for any range.
+type rangeCycleRepo struct {
+	repository.CycleRepository
+
+	cycles []whoop.Cycle
+}
+
+func (r rangeCycleRepo) GetByDateRange(context.Context, time.Time, time.Time, *repository.CursorParams) (*repository.CursorResult[whoop.Cycle], error) {
+	return &repository.CursorResult[whoop.Cycle]{Records: r.cycles}, nil
+}
+
+func newRecoveryService(t *testing.T, srv *recoveryServer, repo *repository.Repository) *Service {
+	t.Helper()
+
+	httpSrv := httptest.NewServer(srv)
+	t.Cleanup(httpSrv.Close)
+
+	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+	client := whoop.New(tokenSource, whoop.WithProxyURL(httpSrv.URL))
+	return NewService(client, repo, slog.New(slog.DiscardHandler))
+}
+
+func cyclesWithIDs(ids ...int64) []whoop.Cycle {
+	cycles := make([]whoop.Cycle, len(ids))
+	for i, id := range ids {
+		cycles[i] = whoop.Cycle{ID: id}
+	}
+	return cycles
+}
+
+func TestFetchRecoveriesForCycles(t *testing.T) {
+	t.Parallel()
+
+	var (
+		// the listing misses cycles 2, 4 and 5: 2 has a recovery of its own,
+		// 4 has none, and 5 is already cached
+		srv        = &recoveryServer{pages: [][]int64{{1}, {3}}, byCycle: []int64{2}}
+		recoveries = &cachedRecoveryRepo{cached: []int64{5}}
+		s          = newRecoveryService(t, srv, &repository.Repository{Recoveries: recoveries})
+		end        = time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	)
+
+	if err := s.fetchRecoveriesForCycles(t.Context(), cyclesWithIDs(1, 2, 3, 4, 5), end.AddDate(0, 0, -5), end); err != nil {
+		t.Fatalf("fetchRecoveriesForCycles() error = %v", err)
+	}
+
+	if want := []int64{1, 3}; !slices.Equal(recoveries.batched, want) {
+		t.Errorf("listed recoveries stored = %v, want %v", recoveries.batched, want)
+	}
+	if want := []int64{2, 4}; !slices.Equal(srv.perCycle(), want) {
+		t.Errorf("fetched recoveries for cycles %v, want only the gaps %v", srv.perCycle(), want)
+	}
+	if want := []int64{2}; !slices.Equal(recoveries.upserted, want) {
+		t.Errorf("fetched recoveries stored = %v, want %v", recoveries.upserted, want)
+	}
+}
+
+func TestFillBackfilledRecoveryGaps(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rangeEnd  = time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+		watermark = rangeEnd.AddDate(0, 0, -5)
+		before    = &repository.BackfillProgress{Entity: repository.BackfillEntityCycle, RangeEnd: rangeEnd}
+	)
+
+	t.Run("fills the cycles the run covered", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			srv        = &recoveryServer{byCycle: []int64{3}}
+			recoveries = &cachedRecoveryRepo{cached: []int64{1}}
+			s          = newRecoveryService(t, srv, &repository.Repository{
+				Cycles:           rangeCycleRepo{cycles: cyclesWithIDs(1, 2, 3)},
+				Recoveries:       recoveries,
+				BackfillProgress: &fakeBackfillProgressRepo{stored: &repository.BackfillProgress{Watermark: &watermark}},
+			})
+		)
+
+		if err := s.fillBackfilledRecoveryGaps(t.Context(), before); err != nil {
+			t.Fatalf("fillBackfilledRecoveryGaps() error = %v", err)
+		}
+		if want := []int64{2, 3}; !slices.Equal(srv.perCycle(), want) {
+			t.Errorf("fetched recoveries for cycles %v, want %v", srv.perCycle(), want)
+		}
+		if want := []int64{3}; !slices.Equal(recoveries.upserted, want) {
+			t.Errorf("fetched recoveries stored = %v, want %v", recoveries.upserted, want)
+		}
+		if requests := srv.requested(); slices.Contains(requests, "/v2/recovery") {
+			t.Errorf("requests = %v, want no listing", requests)
+		}
+	})
+
+	t.Run("nothing backfilled", func(t *testing.T) {
+		t.Parallel()
+
+		srv := &recoveryServer{}
+		s := newRecoveryService(t, srv, &repository.Repository{
+			Cycles:           rangeCycleRepo{cycles: cyclesWithIDs(1)},
+			Recoveries:       &cachedRecoveryRepo{},
+			BackfillProgress: &fakeBackfillProgressRepo{stored: &repository.BackfillProgress{Watermark: &rangeEnd}},
+		})
+
+		if err := s.fillBackfilledRecoveryGaps(t.Context(), before); err != nil {
+			t.Fatalf("fillBackfilledRecoveryGaps() error = %v", err)
+		}
+		if requests := srv.requested(); len(requests) != 0 {
+			t.Errorf("requests = %v, want none when the watermark didn't move", requests)
+		}
+	})
+}
@@ -2,8 +2,8 @@ package xsync
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"github.com/garrettladley/thoop/internal/client/whoop"
@@ -11,7 +11,8 @@ import (
 )
 
 type SyncService interface {
-	// StartBackfill begins a background goroutine to fetch 1 month of historical data.
+	// StartBackfill begins a background goroutine that fetches historical data
+	// back to the configured horizon, resuming from the stored watermarks.
 	StartBackfill(ctx context.Context) error
 
 	// RefreshCurrent fetches the current cycle and n-1 cycle from the API.
@@ -22,36 +23,62 @@ type SyncService interface {
 	// Used for on-demand fetching when user expands the time horizon.
 	FetchHistorical(ctx context.Context, start, end time.Time) error
 
-	// IsBackfillComplete returns whether the initial backfill has finished.
+	// IsBackfillComplete returns whether the backfill has reached the configured horizon.
 	IsBackfillComplete(ctx context.Context) (bool, error)
+
+	// BackfillProgress reports how far the backfill has got for each entity type.
+	BackfillProgress(ctx context.Context) (*BackfillProgress, error)
 }
 
 type Service struct {
-	client *whoop.Client
-	repo   *repository.Repository
-	logger *slog.Logger
+	client  *whoop.Client
+	repo    *repository.Repository
+	logger  *slog.Logger
+	horizon time.Duration
+
+	backfilling atomic.Bool
+	pausedUntil atomic.Pointer[time.Time]
 }
 
 var _ SyncService = (*Service)(nil)
 
-func NewService(client *whoop.Client, repo *repository.Repository, logger *slog.Logger) *Service {
-	return &Service{
-		client: client,
-		repo:   repo,
-		logger: logger,
+type ServiceOption func(*Service)
+
+// WithBackfillHorizon sets how far back the backfill reaches.
+// Use FullHistory to walk back through the entire account.
+func WithBackfillHorizon(horizon time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.horizon = horizon
 	}
 }
 
+func NewService(client *whoop.Client, repo *repository.Repository, logger *slog.Logger, opts ...ServiceOption) *Service {
+	s := &Service{
+		client:  client,
+		repo:    repo,
+		logger:  logger,
+		horizon: BackfillDuration,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
 func (s *Service) StartBackfill(ctx context.Context) error {
-	state, err := s.repo.SyncState.Get(ctx)
+	complete, err := s.IsBackfillComplete(ctx)
 	if err != nil {
-		return fmt.Errorf("%w", err)
+		return err
 	}
 
-	if state.BackfillComplete {
+	if complete {
 		s.logger.InfoContext(ctx, "backfill already complete, skipping")
 		return nil
 	}
+	if !s.backfilling.CompareAndSwap(false, true) {
+		s.logger.InfoContext(ctx, "backfill already running, skipping")
+		return nil
+	}
 
 	go s.runBackfill(ctx)
 	return nil
@@ -66,9 +93,9 @@ func (s *Service) FetchHistorical(ctx context.Context, start, end time.Time) err
 }
 
 func (s *Service) IsBackfillComplete(ctx context.Context) (bool, error) {
-	state, err := s.repo.SyncState.Get(ctx)
+	progress, err := s.BackfillProgress(ctx)
 	if err != nil {
-		return false, fmt.Errorf("%w", err)
+		return false, err
 	}
-	return state.BackfillComplete, nil
+	return progress.Complete(), nil
 }
@@ -0,0 +1,28 @@
+package xtime
+
+import (
+	"fmt"
+	"time"
+)
+
+// Millis converts a WHOOP millisecond count to a duration.
+func Millis(ms int) time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
+
+// FormatDuration formats d to the minute as hours and minutes, e.g. "7h 32m".
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < 0 {
+		return "-" + FormatDuration(-d)
+	}
+
+	var (
+		h = int(d.Hours())
+		m = int(d.Minutes()) % 60
+	)
+	if h == 0 {
+		return fmt.Sprintf("%dm", m)
+	}
+	return fmt.Sprintf("%dh %dm", h, m)
+}
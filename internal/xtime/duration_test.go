@@ -0,0 +1,28 @@
+package xtime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0m"},
+		{45 * time.Minute, "45m"},
+		{7*time.Hour + 32*time.Minute, "7h 32m"},
+		{8 * time.Hour, "8h 0m"},
+		{-10 * time.Minute, "-10m"},
+		{59*time.Minute + 40*time.Second, "1h 0m"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDuration(tt.d); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+-- name: GetBackfillProgress :one
+SELECT * FROM backfill_progress WHERE entity_type = ?;
+
+-- name: UpsertBackfillProgress :exec
+INSERT INTO backfill_progress (entity_type, range_end, watermark, records, exhausted, updated_at)
+VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+ON CONFLICT(entity_type) DO UPDATE SET
+    range_end = excluded.range_end,
+    watermark = excluded.watermark,
+    records = excluded.records,
+    exhausted = excluded.exhausted,
+    updated_at = CURRENT_TIMESTAMP;
@@ -0,0 +1,10 @@
+-- name: CreateGoal :one
+INSERT INTO goals (metric, comparison, value, count, period)
+VALUES (?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: ListGoals :many
+SELECT * FROM goals ORDER BY id;
+
+-- name: DeleteGoal :execrows
+DELETE FROM goals WHERE id = ?;
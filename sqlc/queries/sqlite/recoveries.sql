@@ -16,5 +16,8 @@ SELECT * FROM recoveries WHERE cycle_id = ?;
 -- name: GetRecoveriesByCycleIDs :many
 SELECT * FROM recoveries WHERE cycle_id IN (sqlc.slice('cycle_ids'));
 
+-- name: GetPendingRecoveries :many
+SELECT * FROM recoveries WHERE score_state = 'PENDING_SCORE' ORDER BY created_at DESC;
+
 -- name: DeleteRecovery :exec
 DELETE FROM recoveries WHERE cycle_id = ?;
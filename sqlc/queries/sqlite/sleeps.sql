@@ -36,5 +36,8 @@ LIMIT sqlc.arg(limit);
 -- name: GetNapsByCycleID :many
 SELECT * FROM sleeps WHERE cycle_id = ? AND nap = 1 ORDER BY start DESC;
 
+-- name: GetPendingSleeps :many
+SELECT * FROM sleeps WHERE score_state = 'PENDING_SCORE' ORDER BY start DESC;
+
 -- name: DeleteSleep :exec
 DELETE FROM sleeps WHERE id = ?;
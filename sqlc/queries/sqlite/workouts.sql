@@ -35,5 +35,8 @@ WHERE workouts.start >= (SELECT cycles.start FROM cycles WHERE cycles.id = sqlc.
   AND workouts.start <= COALESCE((SELECT cycles.end FROM cycles WHERE cycles.id = sqlc.arg(cycle_id)), CURRENT_TIMESTAMP)
 ORDER BY workouts.start DESC;
 
+-- name: GetPendingWorkouts :many
+SELECT * FROM workouts WHERE score_state = 'PENDING_SCORE' ORDER BY start DESC;
+
 -- name: DeleteWorkout :exec
 DELETE FROM workouts WHERE id = ?;